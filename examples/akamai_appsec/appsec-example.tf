@@ -0,0 +1,45 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  appsec_section = "appsec"
+}
+
+data "akamai_contract" "contract" {
+}
+
+data "akamai_group" "group" {
+}
+
+resource "akamai_appsec_configuration" "example" {
+  name = "terraform-example"
+  contract_id = data.akamai_contract.contract.id
+  group_id = data.akamai_group.group.id
+  hostnames = ["www.example.com"]
+}
+
+resource "akamai_appsec_match_target" "website" {
+  config_id = akamai_appsec_configuration.example.config_id
+  match_target = jsonencode({
+    type = "website"
+    hostnames = ["www.example.com"]
+    filePaths = ["/*"]
+  })
+}
+
+resource "akamai_appsec_rate_policy" "login" {
+  config_id = akamai_appsec_configuration.example.config_id
+  rate_policy = jsonencode({
+    name = "login"
+    matchType = "path"
+    type = "WAF"
+    averageThreshold = 5
+    burstThreshold = 10
+    clientIdentifier = "ip"
+  })
+}
+
+resource "akamai_appsec_activations" "staging" {
+  config_id = akamai_appsec_configuration.example.config_id
+  version = max(akamai_appsec_match_target.website.version, akamai_appsec_rate_policy.login.version)
+  network = "STAGING"
+  notification_emails = ["security@example.com"]
+}
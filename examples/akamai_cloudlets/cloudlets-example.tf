@@ -0,0 +1,55 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  cloudlets_section = "cloudlets"
+}
+
+data "akamai_cloudlets_edge_redirector_match_rule" "redirects" {
+  match_rules {
+    name = "old blog"
+    match_url = "www.example.com/blog/*"
+    redirect_url = "https://blog.example.com/"
+    status_code = 301
+    use_incoming_query_string = true
+  }
+
+  match_rules {
+    name = "beta testers"
+    match_url = "www.example.com/*"
+    redirect_url = "https://beta.example.com/"
+    status_code = 302
+    matches {
+      match_type = "cookie"
+      match_value = "beta=true"
+    }
+  }
+}
+
+resource "akamai_cloudlets_policy" "redirects" {
+  name = "example_redirects"
+  cloudlet_code = "ER"
+  group_id = "grp_12345"
+  description = "Redirects of www.example.com"
+  match_rules = data.akamai_cloudlets_edge_redirector_match_rule.redirects.json
+}
+
+# Reference the policy from the property rules with the edgeRedirector behavior
+output "edge_redirector_behavior" {
+  value = jsonencode({
+    name = "edgeRedirector"
+    options = {
+      enabled = true
+      isSharedPolicy = false
+      cloudletPolicy = {
+        id = tonumber(akamai_cloudlets_policy.redirects.id)
+        name = akamai_cloudlets_policy.redirects.name
+      }
+    }
+  })
+}
+
+resource "akamai_cloudlets_policy_activation" "redirects_staging" {
+  policy_id = akamai_cloudlets_policy.redirects.id
+  network = "staging"
+  version = akamai_cloudlets_policy.redirects.version
+  associated_properties = ["www.example.com"]
+}
@@ -6,7 +6,6 @@ provider "akamai" {
 
 resource "akamai_cps_enrollment" "enrollmenttwo" {
   contract = "C-1FRYVV3"
-  location = "/cps/v2/enrollments/73752"
   certificate_chain_type = "default"
   certificate_type = "san"
   change_management = false
@@ -83,3 +82,24 @@ resource "akamai_cps_enrollment" "enrollmenttwo" {
     exclude_sans = false
   }
 }
+
+data "akamai_cps_dv_challenges" "challenges" {
+  enrollment_id = akamai_cps_enrollment.enrollmenttwo.id
+}
+
+resource "akamai_dns_record" "challenge" {
+  for_each = { for c in data.akamai_cps_dv_challenges.challenges.dns_challenges : c.domain => c }
+
+  zone = "example.com"
+  name = trimsuffix(each.value.full_path, ".")
+  recordtype = "TXT"
+  ttl = 60
+  target = [each.value.response_body]
+}
+
+resource "akamai_cps_dv_validation" "validation" {
+  enrollment_id = akamai_cps_enrollment.enrollmenttwo.id
+  sans = akamai_cps_enrollment.enrollmenttwo.csr[0].sans
+
+  depends_on = [akamai_dns_record.challenge]
+}
@@ -0,0 +1,29 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  datastream_section = "datastream"
+}
+
+variable "splunk_token" {
+  type = string
+}
+
+resource "akamai_datastream" "edge_logs" {
+  stream_name = "edge-logs"
+  group_id = "grp_12345"
+  contract_id = "ctr_1-AB123"
+  property_ids = ["prp_123456"]
+  dataset_fields = [1000, 1002, 1100, 1005, 1006]
+  email_ids = ["ops@example.com"]
+  active = true
+
+  config {
+    format = "JSON"
+    frequency_in_seconds = 30
+  }
+
+  splunk_connector {
+    connector_name = "splunk"
+    url = "https://splunk.example.com:8088/services/collector/raw"
+    event_collector_token = var.splunk_token
+  }
+}
@@ -0,0 +1,23 @@
+provider "akamai" {
+    dns_section = "dns"
+}
+
+locals {
+  zone = "akavdev.net"
+}
+
+// copy the records of an existing zone into a new one
+data "akamai_dns_zone_file" "source" {
+    zone = "legacy.akavdev.net"
+}
+
+resource "akamai_dns_zone_file" "migrated" {
+    zone = local.zone
+    zone_file = replace(data.akamai_dns_zone_file.source.zone_file, "legacy.akavdev.net.", "${local.zone}.")
+}
+
+// or manage the zone from a master zone file kept next to the configuration
+resource "akamai_dns_zone_file" "static" {
+    zone = "static.akavdev.net"
+    zone_file = file("${path.module}/static.akavdev.net.zone")
+}
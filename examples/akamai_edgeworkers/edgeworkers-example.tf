@@ -0,0 +1,43 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  edgeworkers_section = "edgeworkers"
+}
+
+resource "akamai_edgeworker" "hello_world" {
+  name = "hello-world"
+  group_id = "grp_12345"
+  local_bundle = "${path.module}/hello-world"
+}
+
+resource "akamai_edgeworkers_activation" "staging" {
+  edgeworker_id = akamai_edgeworker.hello_world.edgeworker_id
+  network = "STAGING"
+  version = akamai_edgeworker.hello_world.version
+}
+
+# Run the EdgeWorker from the property rules with the edgeWorkers behavior
+output "edgeworkers_behavior" {
+  value = jsonencode({
+    name = "edgeWorkers"
+    options = {
+      enabled = true
+      edgeWorkerId = tostring(akamai_edgeworker.hello_world.edgeworker_id)
+    }
+  })
+}
+
+resource "akamai_edgekv" "config" {
+  namespace_name = "config"
+  network = "staging"
+  retention_in_seconds = 0
+}
+
+resource "akamai_edgekv_group_items" "greetings" {
+  namespace_name = akamai_edgekv.config.namespace_name
+  network = akamai_edgekv.config.network
+  group_name = "greetings"
+  items = {
+    en = "Hello World"
+    fr = "Bonjour le monde"
+  }
+}
@@ -0,0 +1,3 @@
+export function onClientRequest(request) {
+  request.respondWith(200, {}, 'Hello World from the edge');
+}
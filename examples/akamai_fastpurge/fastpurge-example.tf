@@ -0,0 +1,31 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  purge_section = "ccu"
+}
+
+variable "activation_id" {
+  description = "ID of the property activation to purge after, e.g. akamai_property_activation.example.id"
+}
+
+resource "akamai_fastpurge" "site" {
+  objects = [
+    "https://www.example.com/",
+    "https://www.example.com/index.html",
+  ]
+  network = "production"
+
+  triggers = {
+    activation = var.activation_id
+  }
+}
+
+resource "akamai_fastpurge" "assets" {
+  type = "cpcode"
+  action = "delete"
+  objects = ["cpc_12345"]
+  network = "production"
+
+  triggers = {
+    activation = var.activation_id
+  }
+}
@@ -0,0 +1,37 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  iam_section = "iam"
+}
+
+data "akamai_group" "default" {
+  contract = "ctr_1-AB123"
+}
+
+data "akamai_iam_grantable_roles" "roles" {}
+
+locals {
+  grantable = { for r in data.akamai_iam_grantable_roles.roles.grantable_roles : r.name => r.granted_role_id }
+}
+
+resource "akamai_iam_role" "publishers" {
+  name = "Publishers"
+  description = "Edit and activate property configurations"
+  granted_roles = [local.grantable["Editor"], local.grantable["Publisher"]]
+}
+
+resource "akamai_iam_group" "marketing" {
+  name = "Marketing"
+  parent_group_id = data.akamai_group.default.id
+}
+
+resource "akamai_iam_user" "jane" {
+  first_name = "Jane"
+  last_name = "Doe"
+  email = "jane@example.com"
+  country = "USA"
+
+  auth_grants {
+    group_id = akamai_iam_group.marketing.id
+    role_id = akamai_iam_role.publishers.id
+  }
+}
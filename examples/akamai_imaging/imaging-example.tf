@@ -0,0 +1,36 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  imaging_section = "imaging"
+}
+
+resource "akamai_imaging_policy_set" "images" {
+  name = "images"
+  region = "US"
+  type = "IMAGE"
+  contract_id = "ctr_1-AB123"
+}
+
+data "akamai_imaging_policy_image" "thumbnails" {
+  policy {
+    breakpoints {
+      widths = [320, 640, 1280]
+    }
+    output {
+      perceptual_quality = "mediumHigh"
+    }
+    transformations {
+      resize {
+        width = 640
+        aspect = "fit"
+      }
+    }
+  }
+}
+
+resource "akamai_imaging_policy_image" "thumbnails" {
+  policy_id = "thumbnails"
+  policyset_id = akamai_imaging_policy_set.images.id
+  contract_id = akamai_imaging_policy_set.images.contract_id
+  json = data.akamai_imaging_policy_image.thumbnails.json
+  activate_on_production = true
+}
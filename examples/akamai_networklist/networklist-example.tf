@@ -0,0 +1,18 @@
+provider "akamai" {
+  edgerc = "~/.edgerc"
+  networklist_section = "networklist"
+}
+
+resource "akamai_networklist" "blocklist" {
+  name = "terraform-blocklist"
+  type = "IP"
+  description = "Addresses blocked by the WAF"
+  list = ["192.0.2.10", "198.51.100.0/24"]
+}
+
+resource "akamai_networklist_activations" "staging" {
+  network_list_id = akamai_networklist.blocklist.unique_id
+  network = "STAGING"
+  notification_emails = ["security@example.com"]
+  sync_point = akamai_networklist.blocklist.sync_point
+}
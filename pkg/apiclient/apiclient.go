@@ -0,0 +1,145 @@
+// Package apiclient holds the requests, errors and polling shared by the subproviders
+// talking to the Akamai APIs directly.
+//
+// AkamaiOPEN-edgegrid-golang has no client for the Network Lists, Application Security,
+// EdgeWorkers, EdgeKV, Cloudlets, IAM, DataStream and Image and Video Manager APIs, so
+// their subproviders send requests through a Client holding the credentials of their
+// config section.
+package apiclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+const (
+	// NetworkStaging and NetworkProduction are the activation networks, as named by
+	// most APIs
+	NetworkStaging    = "STAGING"
+	NetworkProduction = "PRODUCTION"
+
+	// activation statuses shared by several APIs
+	StatusActive      = "ACTIVE"
+	StatusActivated   = "ACTIVATED"
+	StatusDeactivated = "DEACTIVATED"
+	StatusFailed      = "FAILED"
+)
+
+var (
+	// ErrActivationFailed is returned when an activation or deactivation fails
+	ErrActivationFailed = errors.New("activation failed")
+
+	// ErrActivationTimeout is returned when an activation does not complete in time
+	ErrActivationTimeout = errors.New("timed out waiting for activation")
+
+	// ErrPollTimeout is returned when a polled operation does not complete in time
+	ErrPollTimeout = errors.New("timed out waiting for operation")
+)
+
+// Client sends requests to an API with the credentials set by Init
+type Client struct {
+	Config edgegrid.Config
+}
+
+// Init sets the credentials used by the client
+func (c *Client) Init(config edgegrid.Config) {
+	c.Config = config
+}
+
+// NewRequest returns a request to the API, with the body sent as JSON when set
+func (c *Client) NewRequest(method, path string, body interface{}) (*http.Request, error) {
+	return NewRequest(c.Config, method, path, body)
+}
+
+// Do sends the request like the Do function
+func (c *Client) Do(req *http.Request, out interface{}) error {
+	return Do(c.Config, req, out)
+}
+
+// DoRequest sends a request like the DoRequest function
+func (c *Client) DoRequest(method, path string, body, out interface{}) error {
+	return DoRequest(c.Config, method, path, body, out)
+}
+
+// NewRequest returns a request to the API, with the body sent as JSON when set
+func NewRequest(config edgegrid.Config, method, path string, body interface{}) (*http.Request, error) {
+	if body != nil {
+		return client.NewJSONRequest(config, method, path, body)
+	}
+	return client.NewRequest(config, method, path, nil)
+}
+
+// Do sends the request and decodes the JSON response into out when set. Error responses
+// are returned as client.APIError.
+func Do(config edgegrid.Config, req *http.Request, out interface{}) error {
+	res, err := client.Do(config, req)
+	if err != nil {
+		return err
+	}
+
+	if client.IsError(res) {
+		return client.NewAPIError(res)
+	}
+
+	if out == nil {
+		return nil
+	}
+	return client.BodyJSON(res, out)
+}
+
+// DoRequest sends a request with an optional JSON body and decodes the JSON response
+// into out when set
+func DoRequest(config edgegrid.Config, method, path string, body, out interface{}) error {
+	req, err := NewRequest(config, method, path, body)
+	if err != nil {
+		return err
+	}
+	return Do(config, req, out)
+}
+
+// Error includes the API problem detail in errors returned to terraform
+func Error(err error) error {
+	if apiErr, ok := err.(client.APIError); ok && apiErr.Detail != "" {
+		return fmt.Errorf("%s: %s", apiErr.Title, apiErr.Detail)
+	}
+	return err
+}
+
+// IsNotFound reports whether err is an API 404
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(client.APIError)
+	return ok && apiErr.Status == http.StatusNotFound
+}
+
+// Poll calls poll every interval until it reports the operation done or returns an error,
+// which is returned as is. ErrPollTimeout is returned when the operation is not done after
+// the timeout.
+func Poll(interval, timeout time.Duration, poll func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := poll()
+		if err != nil || done {
+			return err
+		}
+
+		if time.Now().After(deadline) {
+			return ErrPollTimeout
+		}
+		time.Sleep(interval)
+	}
+}
+
+// WaitForActivation polls an activation like Poll, returning ErrActivationTimeout when the
+// activation is not done after the timeout
+func WaitForActivation(interval, timeout time.Duration, poll func() (bool, error)) error {
+	err := Poll(interval, timeout, poll)
+	if err == ErrPollTimeout {
+		return ErrActivationTimeout
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package apiclient
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoRequest(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	srv.Handle("POST", `/test/v1/items`, func(w http.ResponseWriter, r *http.Request, _ []string) {
+		var item map[string]string
+		if mockapi.ReadJSON(w, r, &item) {
+			mockapi.WriteJSON(w, http.StatusCreated, map[string]string{"id": "1", "name": item["name"]})
+		}
+	})
+	srv.Handle("GET", `/test/v1/items/2`, func(w http.ResponseWriter, r *http.Request, _ []string) {
+		mockapi.WriteProblem(w, http.StatusNotFound, "Not found", "item 2 does not exist")
+	})
+
+	var api Client
+	api.Init(srv.Config())
+
+	var out map[string]string
+	require.NoError(t, api.DoRequest(http.MethodPost, "/test/v1/items", map[string]string{"name": "one"}, &out))
+	assert.Equal(t, map[string]string{"id": "1", "name": "one"}, out)
+
+	err := DoRequest(srv.Config(), http.MethodGet, "/test/v1/items/2", nil, nil)
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+	assert.EqualError(t, Error(err), "Not found: item 2 does not exist")
+
+	assert.False(t, IsNotFound(errors.New("other")))
+	assert.EqualError(t, Error(errors.New("other")), "other")
+}
+
+func TestPoll(t *testing.T) {
+	var polls int
+	err := Poll(0, time.Minute, func() (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+
+	err = Poll(0, -time.Second, func() (bool, error) {
+		return false, nil
+	})
+	assert.True(t, err == ErrPollTimeout)
+}
+
+func TestWaitForActivation(t *testing.T) {
+	var polls int
+	err := WaitForActivation(0, time.Minute, func() (bool, error) {
+		polls++
+		return polls == 3, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+
+	failed := errors.New("failed")
+	err = WaitForActivation(0, time.Minute, func() (bool, error) {
+		return false, failed
+	})
+	assert.True(t, err == failed)
+
+	err = WaitForActivation(0, -time.Second, func() (bool, error) {
+		return false, nil
+	})
+	assert.True(t, errors.Is(err, ErrActivationTimeout))
+}
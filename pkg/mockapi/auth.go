@@ -0,0 +1,103 @@
+package mockapi
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const authScheme = "EG1-HMAC-SHA256"
+
+var (
+	// ErrMissingAuthorization is returned when a request is not signed
+	ErrMissingAuthorization = errors.New("missing EdgeGrid authorization header")
+
+	// ErrInvalidCredentials is returned when the request tokens are not the mock tokens
+	ErrInvalidCredentials = errors.New("invalid client or access token")
+
+	// ErrSignatureMismatch is returned when the request signature does not verify
+	ErrSignatureMismatch = errors.New("the signature does not match")
+)
+
+// verifySignature recomputes the EdgeGrid v1 signature of the request and compares it
+// to the one in the Authorization header
+func verifySignature(r *http.Request) error {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, authScheme+" ") {
+		return ErrMissingAuthorization
+	}
+
+	fields := make(map[string]string)
+	for _, part := range strings.Split(strings.TrimPrefix(header, authScheme+" "), ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+
+	if fields["client_token"] != ClientToken || fields["access_token"] != AccessToken {
+		return ErrInvalidCredentials
+	}
+
+	signature, ok := fields["signature"]
+	if !ok || fields["timestamp"] == "" || fields["nonce"] == "" {
+		return fmt.Errorf("%w: malformed authorization header", ErrMissingAuthorization)
+	}
+
+	// the signed header value is everything up to and including the ; before the signature
+	authHeader := header[:strings.LastIndex(header, "signature=")]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var contentHash string
+	if r.Method == http.MethodPost && len(body) > 0 {
+		if len(body) > MaxBody {
+			body = body[:MaxBody]
+		}
+		sum := sha256.Sum256(body)
+		contentHash = base64.StdEncoding.EncodeToString(sum[:])
+	}
+
+	scheme := "https"
+	if r.TLS == nil {
+		scheme = "http"
+	}
+
+	path := r.URL.EscapedPath()
+	if r.URL.RawQuery != "" {
+		path = path + "?" + r.URL.RawQuery
+	}
+
+	data := strings.Join([]string{
+		r.Method,
+		scheme,
+		r.Host,
+		path,
+		"", // no additional headers are signed
+		contentHash,
+		authHeader,
+	}, "\t")
+
+	key := sign(fields["timestamp"], ClientSecret)
+	if !hmac.Equal([]byte(sign(data, key)), []byte(signature)) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+func sign(message, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(message))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
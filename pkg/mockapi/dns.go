@@ -0,0 +1,1322 @@
+package mockapi
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Authorities are the name servers reported for every mock DNS contract
+var Authorities = []string{"a1-1.akam.net.", "a2-2.akam.net.", "a3-3.akam.net."}
+
+type (
+	dnsState struct {
+		lock           sync.Mutex
+		seq            int
+		zones          map[string]*dnsZone
+		deleteRequests map[string]*deleteRequest
+	}
+
+	// deleteRequest is a bulk zone delete request. It completes on the second status poll.
+	deleteRequest struct {
+		ID        string
+		Zones     []string
+		Bypass    bool
+		Polls     int
+		Deleted   []string
+		Failed    []map[string]string
+		completed bool
+	}
+
+	dnsZone struct {
+		Zone                  string       `json:"zone"`
+		Type                  string       `json:"type"`
+		Masters               []string     `json:"masters,omitempty"`
+		Comment               string       `json:"comment,omitempty"`
+		SignAndServe          bool         `json:"signAndServe"`
+		SignAndServeAlgorithm string       `json:"signAndServeAlgorithm,omitempty"`
+		TsigKey               *dnsTSIGKey  `json:"tsigKey,omitempty"`
+		Target                string       `json:"target,omitempty"`
+		EndCustomerID         string       `json:"endCustomerId,omitempty"`
+		ContractID            string       `json:"contractId,omitempty"`
+		AliasCount            int64        `json:"aliasCount,omitempty"`
+		ActivationState       string       `json:"activationState,omitempty"`
+		LastActivationDate    string       `json:"lastActivationDate,omitempty"`
+		LastModifiedBy        string       `json:"lastModifiedBy,omitempty"`
+		LastModifiedDate      string       `json:"lastModifiedDate,omitempty"`
+		VersionID             string       `json:"versionId,omitempty"`
+		DNSSec                *dnssecKeys  `json:"-"`
+		Changelist            bool         `json:"-"`
+		Changes               []*change    `json:"-"`
+		Recordsets            []*recordset `json:"-"`
+	}
+
+	// change is a recordset change added to a changelist
+	change struct {
+		recordset
+		Op string `json:"op"`
+	}
+
+	// dnssecKeys are the DNSSEC keys of a Sign&Serve zone. A key rotation adds new keys,
+	// which the mock never promotes to current ones.
+	dnssecKeys struct {
+		Algorithm int
+		Current   []dnskey
+		New       []dnskey
+		Modified  string
+	}
+
+	dnskey struct {
+		Flags     int
+		PublicKey []byte
+	}
+
+	dnsTSIGKey struct {
+		Name      string `json:"name"`
+		Algorithm string `json:"algorithm,omitempty"`
+		Secret    string `json:"secret,omitempty"`
+	}
+
+	recordset struct {
+		Name  string   `json:"name"`
+		Type  string   `json:"type"`
+		TTL   int      `json:"ttl"`
+		Rdata []string `json:"rdata"`
+	}
+)
+
+func newDNSState() *dnsState {
+	return &dnsState{zones: make(map[string]*dnsZone), deleteRequests: make(map[string]*deleteRequest)}
+}
+
+// touch records a new zone version after a change
+func (d *dnsState) touch(z *dnsZone) {
+	d.seq++
+	z.VersionID = fmt.Sprintf("mock-version-%d", d.seq)
+	z.LastModifiedDate = time.Now().UTC().Format(time.RFC3339)
+	z.LastModifiedBy = "mockapi"
+	z.ActivationState = "ACTIVE"
+}
+
+func (z *dnsZone) find(name, rtype string) (int, *recordset) {
+	for i, rs := range z.Recordsets {
+		if strings.EqualFold(rs.Name, name) && strings.EqualFold(rs.Type, rtype) {
+			return i, rs
+		}
+	}
+	return -1, nil
+}
+
+// soaSerial returns the serial number field of a SOA rdata value
+func soaSerial(rdata []string) (int64, bool) {
+	if len(rdata) == 0 {
+		return 0, false
+	}
+	fields := strings.Fields(rdata[0])
+	if len(fields) < 3 {
+		return 0, false
+	}
+	serial, err := strconv.ParseInt(fields[2], 10, 64)
+	return serial, err == nil
+}
+
+// checkSOA enforces the rule that every SOA change must increment the serial number, and
+// returns the status, title and detail of the problem when it doesn't
+func (z *dnsZone) checkSOA(rs *recordset) (int, string, string) {
+	if !strings.EqualFold(rs.Type, "SOA") {
+		return 0, "", ""
+	}
+	_, current := z.find(rs.Name, "SOA")
+	if current == nil {
+		return 0, "", ""
+	}
+	old, _ := soaSerial(current.Rdata)
+	serial, ok := soaSerial(rs.Rdata)
+	if !ok {
+		return http.StatusBadRequest, "Bad Request", "SOA rdata is malformed"
+	}
+	if serial <= old {
+		return http.StatusBadRequest, "SOA serial number not incremented", "SOA serial number must be incremented"
+	}
+	return 0, "", ""
+}
+
+// hasRecords reports whether the zone holds records other than the SOA and apex NS ones
+func (z *dnsZone) hasRecords() bool {
+	for _, rs := range z.Recordsets {
+		if !strings.EqualFold(rs.Type, "SOA") && !(strings.EqualFold(rs.Type, "NS") && strings.EqualFold(rs.Name, z.Zone)) {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultRecordsets returns the SOA and apex NS records created with a primary zone
+func defaultRecordsets(zone string) []*recordset {
+	return []*recordset{
+		{
+			Name:  zone,
+			Type:  "SOA",
+			TTL:   86400,
+			Rdata: []string{fmt.Sprintf("%s hostmaster.%s. %d 3600 600 604800 300", Authorities[0], zone, time.Now().Unix())},
+		},
+		{
+			Name:  zone,
+			Type:  "NS",
+			TTL:   86400,
+			Rdata: append([]string(nil), Authorities...),
+		},
+	}
+}
+
+func (s *Server) registerDNS() {
+	s.Handle("GET", `/config-dns/v2/data/authorities`, s.dnsAuthorities)
+	s.Handle("GET", `/config-dns/v2/zones`, s.dnsListZones)
+	s.Handle("POST", `/config-dns/v2/zones`, s.dnsCreateZone)
+	s.Handle("POST", `/config-dns/v2/zones/delete-requests`, s.dnsCreateDeleteRequest)
+	s.Handle("GET", `/config-dns/v2/zones/delete-requests/([^/]+)`, s.dnsGetDeleteRequest)
+	s.Handle("GET", `/config-dns/v2/zones/delete-requests/([^/]+)/result`, s.dnsGetDeleteRequestResult)
+	s.Handle("POST", `/config-dns/v2/zones/dns-sec-status`, s.dnsSecStatus)
+	s.Handle("POST", `/config-dns/v2/zones/zone-transfer-status`, s.dnsZoneTransferStatus)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)`, s.dnsGetZone)
+	s.Handle("PUT", `/config-dns/v2/zones/([^/]+)`, s.dnsUpdateZone)
+	s.Handle("DELETE", `/config-dns/v2/zones/([^/]+)`, s.dnsDeleteZone)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/aliases`, s.dnsListAliases)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/zone-file`, s.dnsGetZoneFile)
+	s.Handle("POST", `/config-dns/v2/zones/([^/]+)/key-rotation`, s.dnsRotateKeys)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/names`, s.dnsListNames)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/names/([^/]+)/types`, s.dnsListNameTypes)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/names/([^/]+)/types/([^/]+)`, s.dnsGetRecord)
+	s.Handle("POST", `/config-dns/v2/zones/([^/]+)/names/([^/]+)/types/([^/]+)`, s.dnsCreateRecord)
+	s.Handle("PUT", `/config-dns/v2/zones/([^/]+)/names/([^/]+)/types/([^/]+)`, s.dnsUpdateRecord)
+	s.Handle("DELETE", `/config-dns/v2/zones/([^/]+)/names/([^/]+)/types/([^/]+)`, s.dnsDeleteRecord)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/recordsets`, s.dnsListRecordsets)
+	s.Handle("POST", `/config-dns/v2/zones/([^/]+)/recordsets`, s.dnsCreateRecordsets)
+	s.Handle("PUT", `/config-dns/v2/zones/([^/]+)/recordsets`, s.dnsReplaceRecordsets)
+	s.Handle("POST", `/config-dns/v2/changelists`, s.dnsCreateChangelist)
+	s.Handle("GET", `/config-dns/v2/changelists/([^/]+)`, s.dnsGetChangelist)
+	s.Handle("DELETE", `/config-dns/v2/changelists/([^/]+)`, s.dnsDeleteChangelist)
+	s.Handle("POST", `/config-dns/v2/changelists/([^/]+)/recordsets/add-change`, s.dnsAddChange)
+	s.Handle("POST", `/config-dns/v2/changelists/([^/]+)/submit`, s.dnsSubmitChangelist)
+	s.Handle("GET", `/config-dns/v2/keys`, s.dnsListKeys)
+	s.Handle("POST", `/config-dns/v2/keys/used-by`, s.dnsKeyUsedBy)
+	s.Handle("POST", `/config-dns/v2/keys/bulk-update`, s.dnsBulkUpdateKeys)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/key`, s.dnsGetZoneKey)
+	s.Handle("PUT", `/config-dns/v2/zones/([^/]+)/key`, s.dnsUpdateZoneKey)
+	s.Handle("DELETE", `/config-dns/v2/zones/([^/]+)/key`, s.dnsDeleteZoneKey)
+	s.Handle("GET", `/config-dns/v2/zones/([^/]+)/key/used-by`, s.dnsZoneKeyUsedBy)
+}
+
+func (s *Server) dnsZone(w http.ResponseWriter, name string) *dnsZone {
+	z, ok := s.dns.zones[strings.ToLower(name)]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "Zone "+name+" does not exist")
+		return nil
+	}
+	return z
+}
+
+func (s *Server) dnsAuthorities(w http.ResponseWriter, r *http.Request, _ []string) {
+	contracts := make([]map[string]interface{}, 0)
+	for _, id := range strings.Split(r.URL.Query().Get("contractIds"), ",") {
+		if id != "" {
+			contracts = append(contracts, map[string]interface{}{"contractId": id, "authorities": Authorities})
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"contracts": contracts})
+}
+
+func (s *Server) dnsListZones(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	q := r.URL.Query()
+	contracts := q.Get("contractIds")
+	types := strings.ToUpper(q.Get("types"))
+	search := strings.ToLower(q.Get("search"))
+
+	names := make([]string, 0, len(s.dns.zones))
+	for name := range s.dns.zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zones := make([]*dnsZone, 0)
+	for _, name := range names {
+		z := s.dns.zones[name]
+		if contracts != "" && !strings.Contains(","+contracts+",", ","+z.ContractID+",") {
+			continue
+		}
+		if types != "" && !strings.Contains(","+types+",", ","+z.Type+",") {
+			continue
+		}
+		if search != "" && !strings.Contains(name, search) {
+			continue
+		}
+		zones = append(zones, z)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"contractIds":   strings.Split(contracts, ","),
+			"page":          1,
+			"pageSize":      len(zones),
+			"showAll":       true,
+			"totalElements": len(zones),
+		},
+		"zones": zones,
+	})
+}
+
+func (s *Server) dnsCreateZone(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body dnsZone
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	contractID := r.URL.Query().Get("contractId")
+	if contractID == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "contractId is required")
+		return
+	}
+
+	body.Type = strings.ToUpper(body.Type)
+	switch body.Type {
+	case "PRIMARY":
+	case "SECONDARY":
+		if len(body.Masters) == 0 {
+			WriteProblem(w, http.StatusBadRequest, "Bad Request", "masters is required for secondary zones")
+			return
+		}
+	case "ALIAS":
+		if body.Target == "" {
+			WriteProblem(w, http.StatusBadRequest, "Bad Request", "target is required for alias zones")
+			return
+		}
+	default:
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "invalid zone type "+body.Type)
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	name := strings.ToLower(body.Zone)
+	if _, ok := s.dns.zones[name]; ok {
+		WriteProblem(w, http.StatusConflict, "Conflict", "Zone "+body.Zone+" already exists")
+		return
+	}
+
+	if body.Type == "ALIAS" {
+		target := s.dnsAliasTarget(w, body.Target, contractID)
+		if target == nil {
+			return
+		}
+		target.AliasCount++
+	}
+
+	z := body
+	z.Zone = name
+	z.ContractID = contractID
+	z.Recordsets = make([]*recordset, 0)
+	z.updateDNSSec()
+	s.dns.touch(&z)
+	z.ActivationState = "NEW"
+	s.dns.zones[name] = &z
+
+	WriteJSON(w, http.StatusCreated, &z)
+}
+
+func (s *Server) dnsGetZone(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, z)
+}
+
+// dnsAliasTarget returns the target of an alias zone, which must be a primary zone of
+// the contract
+func (s *Server) dnsAliasTarget(w http.ResponseWriter, name, contractID string) *dnsZone {
+	target, ok := s.dns.zones[strings.ToLower(name)]
+	if !ok || target.Type != "PRIMARY" {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "alias target "+name+" is not a primary zone")
+		return nil
+	}
+	if strings.TrimPrefix(target.ContractID, "ctr_") != strings.TrimPrefix(contractID, "ctr_") {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "alias target "+name+" is not in contract "+contractID)
+		return nil
+	}
+	return target
+}
+
+func (s *Server) dnsListAliases(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+	if z.Type != "PRIMARY" {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "Zone "+z.Zone+" is not a primary zone")
+		return
+	}
+
+	aliases := make([]string, 0)
+	for _, alias := range s.dns.zones {
+		if alias.Type == "ALIAS" && strings.EqualFold(alias.Target, z.Zone) {
+			aliases = append(aliases, alias.Zone)
+		}
+	}
+	sort.Strings(aliases)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"aliases": aliases})
+}
+
+func (s *Server) dnsUpdateZone(w http.ResponseWriter, r *http.Request, params []string) {
+	var body dnsZone
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if body.Type != "" && !strings.EqualFold(body.Type, z.Type) {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "zone type cannot be changed")
+		return
+	}
+
+	z.Masters = body.Masters
+	z.Comment = body.Comment
+	z.SignAndServe = body.SignAndServe
+	z.SignAndServeAlgorithm = body.SignAndServeAlgorithm
+	z.TsigKey = body.TsigKey
+	z.EndCustomerID = body.EndCustomerID
+	if z.Type == "ALIAS" && body.Target != "" && !strings.EqualFold(body.Target, z.Target) {
+		target := s.dnsAliasTarget(w, body.Target, z.ContractID)
+		if target == nil {
+			return
+		}
+		if old, ok := s.dns.zones[strings.ToLower(z.Target)]; ok {
+			old.AliasCount--
+		}
+		target.AliasCount++
+		z.Target = body.Target
+	}
+	z.updateDNSSec()
+	s.dns.touch(z)
+
+	WriteJSON(w, http.StatusOK, z)
+}
+
+func (s *Server) dnsDeleteZone(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if z.AliasCount > 0 {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "Zone "+z.Zone+" is the target of alias zones")
+		return
+	}
+	if z.Type == "ALIAS" {
+		if target, ok := s.dns.zones[strings.ToLower(z.Target)]; ok {
+			target.AliasCount--
+		}
+	}
+
+	delete(s.dns.zones, z.Zone)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsCreateDeleteRequest(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		Zones []string `json:"zones"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+	if len(body.Zones) == 0 {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "zones is required")
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	s.dns.seq++
+	req := &deleteRequest{
+		ID:     fmt.Sprintf("mock-delete-%d", s.dns.seq),
+		Zones:  body.Zones,
+		Bypass: r.URL.Query().Get("bypassSafetyChecks") == "true",
+	}
+	s.dns.deleteRequests[req.ID] = req
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"requestId":      req.ID,
+		"expirationDate": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+}
+
+// complete deletes the zones of the request. Without bypassSafetyChecks, zones holding
+// records other than the SOA and apex NS ones and alias targets are not deleted.
+func (s *Server) complete(req *deleteRequest) {
+	req.completed = true
+	for _, name := range req.Zones {
+		z, ok := s.dns.zones[strings.ToLower(name)]
+		switch {
+		case !ok:
+			req.Failed = append(req.Failed, map[string]string{"zone": name, "failureReason": "ZONE_NOT_FOUND"})
+			continue
+		case z.AliasCount > 0:
+			req.Failed = append(req.Failed, map[string]string{"zone": name, "failureReason": "ZONE_HAS_ALIASES"})
+			continue
+		case !req.Bypass && z.hasRecords():
+			req.Failed = append(req.Failed, map[string]string{"zone": name, "failureReason": "ZONE_HAS_RECORDS"})
+			continue
+		}
+		if z.Type == "ALIAS" {
+			if target, ok := s.dns.zones[strings.ToLower(z.Target)]; ok {
+				target.AliasCount--
+			}
+		}
+		delete(s.dns.zones, z.Zone)
+		req.Deleted = append(req.Deleted, name)
+	}
+}
+
+func (s *Server) dnsGetDeleteRequest(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	req, ok := s.dns.deleteRequests[params[0]]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "delete request "+params[0]+" does not exist")
+		return
+	}
+	req.Polls++
+	if req.Polls > 1 && !req.completed {
+		s.complete(req)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"requestId":      req.ID,
+		"zonesSubmitted": len(req.Zones),
+		"successCount":   len(req.Deleted),
+		"failureCount":   len(req.Failed),
+		"isComplete":     req.completed,
+		"expirationDate": time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+}
+
+func (s *Server) dnsGetDeleteRequestResult(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	req, ok := s.dns.deleteRequests[params[0]]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "delete request "+params[0]+" does not exist")
+		return
+	}
+	if !req.completed {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "delete request "+req.ID+" is not complete")
+		return
+	}
+
+	failed := req.Failed
+	if failed == nil {
+		failed = make([]map[string]string, 0)
+	}
+	deleted := req.Deleted
+	if deleted == nil {
+		deleted = make([]string, 0)
+	}
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"requestId":                req.ID,
+		"successfullyDeletedZones": deleted,
+		"failedZones":              failed,
+	})
+}
+
+func (s *Server) dnsGetZoneFile(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	var b strings.Builder
+	for _, rs := range z.Recordsets {
+		for _, rdata := range rs.Rdata {
+			fmt.Fprintf(&b, "%s.\t%d\tIN\t%s\t%s\n", rs.Name, rs.TTL, rs.Type, rdata)
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/dns")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(b.String()))
+}
+
+func (s *Server) dnsListNames(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	for _, rs := range z.Recordsets {
+		if !seen[rs.Name] {
+			seen[rs.Name] = true
+			names = append(names, rs.Name)
+		}
+	}
+	sort.Strings(names)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"names": names})
+}
+
+func (s *Server) dnsListNameTypes(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	types := make([]string, 0)
+	for _, rs := range z.Recordsets {
+		if strings.EqualFold(rs.Name, params[1]) {
+			types = append(types, rs.Type)
+		}
+	}
+	if len(types) == 0 {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "Name "+params[1]+" does not exist")
+		return
+	}
+	sort.Strings(types)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"types": types})
+}
+
+func (s *Server) dnsGetRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	_, rs := z.find(params[1], params[2])
+	if rs == nil {
+		WriteProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Recordset %s %s does not exist", params[1], params[2]))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, rs)
+}
+
+func (s *Server) dnsCreateRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	var body recordset
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+	body.Name, body.Type = params[1], strings.ToUpper(params[2])
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if _, rs := z.find(body.Name, body.Type); rs != nil {
+		WriteProblem(w, http.StatusConflict, "Conflict", fmt.Sprintf("Recordset %s %s already exists", body.Name, body.Type))
+		return
+	}
+
+	z.Recordsets = append(z.Recordsets, &body)
+	s.dns.touch(z)
+
+	WriteJSON(w, http.StatusCreated, &body)
+}
+
+func (s *Server) dnsUpdateRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	var body recordset
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+	body.Name, body.Type = params[1], strings.ToUpper(params[2])
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	i, rs := z.find(body.Name, body.Type)
+	if rs == nil {
+		WriteProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Recordset %s %s does not exist", body.Name, body.Type))
+		return
+	}
+
+	if status, title, detail := z.checkSOA(&body); status != 0 {
+		WriteProblem(w, status, title, detail)
+		return
+	}
+
+	z.Recordsets[i] = &body
+	s.dns.touch(z)
+
+	WriteJSON(w, http.StatusOK, &body)
+}
+
+func (s *Server) dnsDeleteRecord(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	i, rs := z.find(params[1], params[2])
+	if rs == nil {
+		WriteProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("Recordset %s %s does not exist", params[1], params[2]))
+		return
+	}
+
+	z.Recordsets = append(z.Recordsets[:i], z.Recordsets[i+1:]...)
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsListRecordsets(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	q := r.URL.Query()
+	types := strings.ToUpper(q.Get("types"))
+	search := strings.ToLower(q.Get("search"))
+
+	recordsets := make([]*recordset, 0)
+	for _, rs := range z.Recordsets {
+		if types != "" && !strings.Contains(","+types+",", ","+rs.Type+",") {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(rs.Name), search) {
+			continue
+		}
+		recordsets = append(recordsets, rs)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"zone":          z.Zone,
+			"types":         strings.Split(types, ","),
+			"page":          1,
+			"pageSize":      len(recordsets),
+			"lastPage":      1,
+			"showAll":       true,
+			"totalElements": len(recordsets),
+		},
+		"recordsets": recordsets,
+	})
+}
+
+func (s *Server) dnsCreateRecordsets(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Recordsets []*recordset `json:"recordsets"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	for _, rs := range body.Recordsets {
+		if _, existing := z.find(rs.Name, rs.Type); existing != nil {
+			WriteProblem(w, http.StatusConflict, "Conflict", fmt.Sprintf("Recordset %s %s already exists", rs.Name, rs.Type))
+			return
+		}
+	}
+
+	z.Recordsets = append(z.Recordsets, body.Recordsets...)
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dnsReplaceRecordsets replaces every recordset in the zone, like the real endpoint
+func (s *Server) dnsReplaceRecordsets(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Recordsets []*recordset `json:"recordsets"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	for _, rs := range body.Recordsets {
+		if status, title, detail := z.checkSOA(rs); status != 0 {
+			WriteProblem(w, status, title, detail)
+			return
+		}
+	}
+
+	z.Recordsets = body.Recordsets
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsCreateChangelist(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, r.URL.Query().Get("zone"))
+	if z == nil {
+		return
+	}
+
+	if z.Changelist {
+		WriteProblem(w, http.StatusConflict, "Conflict", "a changelist already exists for zone "+z.Zone)
+		return
+	}
+	z.Changelist = true
+
+	WriteJSON(w, http.StatusCreated, s.dnsChangelistJSON(z))
+}
+
+func (s *Server) dnsChangelistJSON(z *dnsZone) map[string]interface{} {
+	return map[string]interface{}{
+		"zone":             z.Zone,
+		"changeTag":        z.VersionID,
+		"zoneVersionId":    z.VersionID,
+		"lastModifiedDate": z.LastModifiedDate,
+		"stale":            false,
+	}
+}
+
+func (s *Server) dnsGetChangelist(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if !z.Changelist {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "no changelist exists for zone "+z.Zone)
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, s.dnsChangelistJSON(z))
+}
+
+func (s *Server) dnsDeleteChangelist(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if !z.Changelist {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "no changelist exists for zone "+z.Zone)
+		return
+	}
+	z.Changelist = false
+	z.Changes = nil
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsAddChange(w http.ResponseWriter, r *http.Request, params []string) {
+	var body change
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if !z.Changelist {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "no changelist exists for zone "+z.Zone)
+		return
+	}
+
+	body.Type, body.Op = strings.ToUpper(body.Type), strings.ToUpper(body.Op)
+	switch body.Op {
+	case "ADD", "EDIT", "DELETE":
+	default:
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "invalid op "+body.Op)
+		return
+	}
+	if name := strings.ToLower(strings.TrimSuffix(body.Name, ".")); name != z.Zone && !strings.HasSuffix(name, "."+z.Zone) {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "Recordset "+body.Name+" is not in zone "+z.Zone)
+		return
+	}
+	z.Changes = append(z.Changes, &body)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// applyChanges applies the changes of the changelist to the recordsets, all or nothing
+func (z *dnsZone) applyChanges() (int, string) {
+	recordsets := append([]*recordset(nil), z.Recordsets...)
+	for _, c := range z.Changes {
+		i := -1
+		for j, rs := range recordsets {
+			if strings.EqualFold(rs.Name, c.Name) && strings.EqualFold(rs.Type, c.Type) {
+				i = j
+				break
+			}
+		}
+		rs := c.recordset
+		switch {
+		case c.Op == "ADD" && i >= 0:
+			return http.StatusConflict, fmt.Sprintf("Recordset %s %s already exists", c.Name, c.Type)
+		case c.Op == "ADD":
+			recordsets = append(recordsets, &rs)
+		case i < 0:
+			return http.StatusNotFound, fmt.Sprintf("Recordset %s %s does not exist", c.Name, c.Type)
+		case c.Op == "EDIT":
+			recordsets[i] = &rs
+		default:
+			recordsets = append(recordsets[:i], recordsets[i+1:]...)
+		}
+	}
+	z.Recordsets = recordsets
+	z.Changes = nil
+	return 0, ""
+}
+
+// dnsSubmitChangelist activates the zone with the changes of the changelist. A new
+// primary zone gets its default SOA and NS records at this point, as it does in Edge DNS.
+func (s *Server) dnsSubmitChangelist(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if !z.Changelist {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "no changelist exists for zone "+z.Zone)
+		return
+	}
+
+	// like Edge DNS, a changelist failing to submit stays open until it is discarded
+	if status, detail := z.applyChanges(); status != 0 {
+		WriteProblem(w, status, "Bad Request", detail)
+		return
+	}
+	z.Changelist = false
+	if z.Type == "PRIMARY" {
+		if _, soa := z.find(z.Zone, "SOA"); soa == nil {
+			z.Recordsets = append(defaultRecordsets(z.Zone), z.Recordsets...)
+		}
+	}
+	s.dns.touch(z)
+	z.LastActivationDate = z.LastModifiedDate
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsKeyZones(name string) []string {
+	zones := make([]string, 0)
+	for _, z := range s.dns.zones {
+		if z.TsigKey != nil && z.TsigKey.Name == name {
+			zones = append(zones, z.Zone)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+func (s *Server) dnsListKeys(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	type keyResponse struct {
+		dnsTSIGKey
+		ZoneCount int64 `json:"zoneCount"`
+	}
+	seen := make(map[string]bool)
+	keys := make([]*keyResponse, 0)
+	for _, z := range s.dns.zones {
+		if z.TsigKey == nil || seen[z.TsigKey.Name] {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(z.TsigKey.Name), search) {
+			continue
+		}
+		seen[z.TsigKey.Name] = true
+		keys = append(keys, &keyResponse{*z.TsigKey, int64(len(s.dnsKeyZones(z.TsigKey.Name)))})
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"metadata": map[string]interface{}{"totalElements": len(keys)},
+		"keys":     keys,
+	})
+}
+
+func (s *Server) dnsKeyUsedBy(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body dnsTSIGKey
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"zones": s.dnsKeyZones(body.Name)})
+}
+
+func (s *Server) dnsBulkUpdateKeys(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		Key   *dnsTSIGKey `json:"key"`
+		Zones []string    `json:"zones"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	if body.Key == nil || body.Key.Name == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "key is required")
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	for _, name := range body.Zones {
+		if _, ok := s.dns.zones[strings.ToLower(name)]; !ok {
+			WriteProblem(w, http.StatusNotFound, "Not Found", "Zone "+name+" does not exist")
+			return
+		}
+	}
+
+	for _, name := range body.Zones {
+		z := s.dns.zones[strings.ToLower(name)]
+		key := *body.Key
+		z.TsigKey = &key
+		s.dns.touch(z)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsGetZoneKey(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	if z.TsigKey == nil {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "Zone "+z.Zone+" has no TSIG key")
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"name":      z.TsigKey.Name,
+		"algorithm": z.TsigKey.Algorithm,
+		"secret":    z.TsigKey.Secret,
+		"zoneCount": len(s.dnsKeyZones(z.TsigKey.Name)),
+	})
+}
+
+func (s *Server) dnsUpdateZoneKey(w http.ResponseWriter, r *http.Request, params []string) {
+	var body dnsTSIGKey
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	z.TsigKey = &body
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsDeleteZoneKey(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	z.TsigKey = nil
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) dnsZoneKeyUsedBy(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+
+	zones := make([]string, 0)
+	if z.TsigKey != nil {
+		zones = s.dnsKeyZones(z.TsigKey.Name)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"zones": zones})
+}
+
+// dnssecAlgorithms are the DNSSEC algorithm numbers of the Sign&Serve algorithms
+var dnssecAlgorithms = map[string]int{
+	"RSA_SHA1":          5,
+	"RSA_SHA256":        8,
+	"RSA_SHA512":        10,
+	"ECDSA_P256_SHA256": 13,
+	"ECDSA_P384_SHA384": 14,
+}
+
+// newDNSKeys returns a new key signing key and zone signing key with random public keys
+func newDNSKeys() []dnskey {
+	keys := []dnskey{{Flags: 257, PublicKey: make([]byte, 64)}, {Flags: 256, PublicKey: make([]byte, 64)}}
+	for _, k := range keys {
+		_, _ = rand.Read(k.PublicKey)
+	}
+	return keys
+}
+
+// updateDNSSec creates keys when Sign&Serve is enabled or its algorithm changes, and
+// drops them when it is disabled
+func (z *dnsZone) updateDNSSec() {
+	if !z.SignAndServe {
+		z.DNSSec = nil
+		return
+	}
+	algorithm, ok := dnssecAlgorithms[strings.ToUpper(z.SignAndServeAlgorithm)]
+	if !ok {
+		algorithm = dnssecAlgorithms["RSA_SHA256"]
+	}
+	if z.DNSSec != nil && z.DNSSec.Algorithm == algorithm {
+		return
+	}
+	z.DNSSec = &dnssecKeys{Algorithm: algorithm, Current: newDNSKeys(), Modified: time.Now().UTC().Format(time.RFC3339)}
+}
+
+// rdata returns the DNSKEY rdata in wire format
+func (k dnskey) rdata(algorithm int) []byte {
+	return append([]byte{byte(k.Flags >> 8), byte(k.Flags), 3, byte(algorithm)}, k.PublicKey...)
+}
+
+// dnssecRecords returns the DNSKEY and DS records of the keys in zone file format
+func (z *dnsZone) dnssecRecords(keys []dnskey) map[string]interface{} {
+	const ttl = 7200
+	var dnskeys, ds []string
+	for _, k := range keys {
+		rdata := k.rdata(z.DNSSec.Algorithm)
+		dnskeys = append(dnskeys, fmt.Sprintf("%s. %d IN DNSKEY %d 3 %d %s", z.Zone, ttl, k.Flags, z.DNSSec.Algorithm, base64.StdEncoding.EncodeToString(k.PublicKey)))
+		if k.Flags != 257 {
+			continue
+		}
+
+		var tag uint32
+		for i, b := range rdata {
+			if i%2 == 0 {
+				tag += uint32(b) << 8
+			} else {
+				tag += uint32(b)
+			}
+		}
+		tag = (tag + tag>>16) & 0xffff
+
+		var owner []byte
+		for _, label := range strings.Split(z.Zone, ".") {
+			owner = append(append(owner, byte(len(label))), label...)
+		}
+		digest := sha256.Sum256(append(append(owner, 0), rdata...))
+		ds = append(ds, fmt.Sprintf("%s. %d IN DS %d %d 2 %X", z.Zone, ttl, tag, z.DNSSec.Algorithm, digest[:]))
+	}
+
+	return map[string]interface{}{
+		"dnskeyRecord":     strings.Join(dnskeys, "\n"),
+		"dsRecord":         strings.Join(ds, "\n"),
+		"expectedTtl":      ttl,
+		"lastModifiedDate": z.DNSSec.Modified,
+	}
+}
+
+func (s *Server) dnsSecStatus(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		Zones []string `json:"zones"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	statuses := make([]map[string]interface{}, 0, len(body.Zones))
+	for _, name := range body.Zones {
+		z := s.dnsZone(w, name)
+		if z == nil {
+			return
+		}
+		if z.DNSSec == nil {
+			WriteProblem(w, http.StatusBadRequest, "Bad Request", "Zone "+name+" is not signed")
+			return
+		}
+
+		status := map[string]interface{}{
+			"zone":           z.Zone,
+			"alerts":         []string{},
+			"currentRecords": z.dnssecRecords(z.DNSSec.Current),
+		}
+		if len(z.DNSSec.New) > 0 {
+			status["newRecords"] = z.dnssecRecords(z.DNSSec.New)
+		}
+		statuses = append(statuses, status)
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"dnsSecStatuses": statuses})
+}
+
+func (s *Server) dnsRotateKeys(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	z := s.dnsZone(w, params[0])
+	if z == nil {
+		return
+	}
+	if z.DNSSec == nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "Zone "+z.Zone+" is not signed")
+		return
+	}
+
+	z.DNSSec.New = newDNSKeys()
+	z.DNSSec.Modified = time.Now().UTC().Format(time.RFC3339)
+	s.dns.touch(z)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TransferSerial is the SOA serial number of the zone transfers of mock secondary zones
+const TransferSerial = 2020100101
+
+// dnsZoneTransferStatus reports the last zone transfer from each master of secondary
+// zones. Transfers from masters in 192.0.2.0/24 (TEST-NET-1) time out, the others
+// succeed at the last change of the zone.
+func (s *Server) dnsZoneTransferStatus(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		Zones []string `json:"zones"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.dns.lock.Lock()
+	defer s.dns.lock.Unlock()
+
+	statuses := make([]map[string]interface{}, 0, len(body.Zones))
+	for _, name := range body.Zones {
+		z := s.dnsZone(w, name)
+		if z == nil {
+			return
+		}
+		if z.Type != "SECONDARY" {
+			WriteProblem(w, http.StatusBadRequest, "Bad Request", "Zone "+z.Zone+" is not a secondary zone")
+			return
+		}
+
+		masters := make([]map[string]interface{}, 0, len(z.Masters))
+		for _, master := range z.Masters {
+			status := map[string]interface{}{"master": master, "lastTransferDate": z.LastModifiedDate}
+			if strings.HasPrefix(master, "192.0.2.") {
+				status["lastTransferError"] = "Connection to " + master + " timed out"
+			} else {
+				status["lastTransferSerialNumber"] = TransferSerial
+			}
+			masters = append(masters, status)
+		}
+		statuses = append(statuses, map[string]interface{}{"zone": z.Zone, "masters": masters})
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"zones": statuses})
+}
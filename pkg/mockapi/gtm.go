@@ -0,0 +1,420 @@
+package mockapi
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// default datacenter IDs assigned by GTM
+const (
+	gtmMapDefaultDC  = 5400
+	gtmIPv4DefaultDC = 5401
+	gtmIPv6DefaultDC = 5402
+)
+
+// gtmCollections maps the URL segment of each domain subcollection to its
+// attribute name in the domain object
+var gtmCollections = map[string]string{
+	"datacenters":     "datacenters",
+	"properties":      "properties",
+	"resources":       "resources",
+	"cidr-maps":       "cidrMaps",
+	"geographic-maps": "geographicMaps",
+	"as-maps":         "asMaps",
+}
+
+type (
+	gtmState struct {
+		lock    sync.Mutex
+		seq     int
+		domains map[string]*gtmDomain
+	}
+
+	gtmDomain struct {
+		attributes map[string]interface{}
+		// collections holds each subcollection keyed by object name, or
+		// datacenter ID for datacenters
+		collections map[string]map[string]map[string]interface{}
+		status      map[string]interface{}
+	}
+)
+
+func newGTMState() *gtmState {
+	return &gtmState{domains: make(map[string]*gtmDomain)}
+}
+
+// change records a new change ID on the domain; propagation completes immediately
+func (g *gtmState) change(d *gtmDomain) map[string]interface{} {
+	g.seq++
+	now := time.Now().UTC().Format(time.RFC3339)
+	d.attributes["lastModified"] = now
+	d.attributes["lastModifiedBy"] = "mockapi"
+	d.status = map[string]interface{}{
+		"changeId":              fmt.Sprintf("mock-change-%d", g.seq),
+		"message":               "Change Pending",
+		"passingValidation":     true,
+		"propagationStatus":     "COMPLETE",
+		"propagationStatusDate": now,
+	}
+	return d.status
+}
+
+func (d *gtmDomain) toJSON() map[string]interface{} {
+	out := make(map[string]interface{}, len(d.attributes)+len(d.collections))
+	for k, v := range d.attributes {
+		out[k] = v
+	}
+	for segment, attribute := range gtmCollections {
+		keys := make([]string, 0, len(d.collections[segment]))
+		for k := range d.collections[segment] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			items = append(items, d.collections[segment][k])
+		}
+		out[attribute] = items
+	}
+	out["status"] = d.status
+	return out
+}
+
+func (s *Server) registerGTM() {
+	s.Handle("GET", `/config-gtm/v1/domains`, s.gtmListDomains)
+	s.Handle("POST", `/config-gtm/v1/domains`, s.gtmCreateDomain)
+	s.Handle("GET", `/config-gtm/v1/domains/([^/]+)`, s.gtmGetDomain)
+	s.Handle("PUT", `/config-gtm/v1/domains/([^/]+)`, s.gtmUpdateDomain)
+	s.Handle("DELETE", `/config-gtm/v1/domains/([^/]+)`, s.gtmDeleteDomain)
+	s.Handle("GET", `/config-gtm/v1/domains/([^/]+)/status/current`, s.gtmDomainStatus)
+	s.Handle("POST", `/config-gtm/v1/domains/([^/]+)/datacenters`, s.gtmCreateDatacenter)
+	s.Handle("POST", `/config-gtm/v1/domains/([^/]+)/datacenters/(default-datacenter-for-maps|datacenter-for-ip-version-selector-ipv4|datacenter-for-ip-version-selector-ipv6)`, s.gtmCreateDefaultDatacenter)
+	s.Handle("GET", `/config-gtm/v1/domains/([^/]+)/(datacenters|properties|resources|cidr-maps|geographic-maps|as-maps)`, s.gtmListObjects)
+	s.Handle("GET", `/config-gtm/v1/domains/([^/]+)/(datacenters|properties|resources|cidr-maps|geographic-maps|as-maps)/([^/]+)`, s.gtmGetObject)
+	s.Handle("PUT", `/config-gtm/v1/domains/([^/]+)/(datacenters|properties|resources|cidr-maps|geographic-maps|as-maps)/([^/]+)`, s.gtmPutObject)
+	s.Handle("DELETE", `/config-gtm/v1/domains/([^/]+)/(datacenters|properties|resources|cidr-maps|geographic-maps|as-maps)/([^/]+)`, s.gtmDeleteObject)
+}
+
+func (s *Server) gtmDomain(w http.ResponseWriter, name string) *gtmDomain {
+	d, ok := s.gtm.domains[name]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "Domain "+name+" not found")
+		return nil
+	}
+	return d
+}
+
+func gtmEntity(segment string) string {
+	switch segment {
+	case "datacenters":
+		return "Datacenter"
+	case "properties":
+		return "Property"
+	case "resources":
+		return "Resource"
+	case "cidr-maps":
+		return "CIDR map"
+	case "geographic-maps":
+		return "Geographic map"
+	}
+	return "AS map"
+}
+
+func (s *Server) gtmListDomains(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	names := make([]string, 0, len(s.gtm.domains))
+	for name := range s.gtm.domains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]map[string]interface{}, 0, len(names))
+	for _, name := range names {
+		d := s.gtm.domains[name]
+		items = append(items, map[string]interface{}{
+			"name":         name,
+			"status":       d.status["propagationStatus"],
+			"lastModified": d.attributes["lastModified"],
+			"acgId":        "1-MOCK",
+		})
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+func (s *Server) gtmCreateDomain(w http.ResponseWriter, r *http.Request, _ []string) {
+	body := make(map[string]interface{})
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	name, _ := body["name"].(string)
+	if name == "" || r.URL.Query().Get("contractId") == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad Request", "domain name and contractId are required")
+		return
+	}
+
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	if _, ok := s.gtm.domains[name]; ok {
+		WriteProblem(w, http.StatusConflict, "Conflict", "Domain "+name+" already exists")
+		return
+	}
+
+	d := &gtmDomain{
+		attributes:  make(map[string]interface{}),
+		collections: make(map[string]map[string]map[string]interface{}),
+	}
+	for segment, attribute := range gtmCollections {
+		d.collections[segment] = make(map[string]map[string]interface{})
+		delete(body, attribute)
+	}
+	delete(body, "status")
+	delete(body, "links")
+	d.attributes = body
+	status := s.gtm.change(d)
+	s.gtm.domains[name] = d
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{"resource": d.toJSON(), "status": status})
+}
+
+func (s *Server) gtmGetDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, d.toJSON())
+}
+
+func (s *Server) gtmUpdateDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	body := make(map[string]interface{})
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	for _, attribute := range gtmCollections {
+		delete(body, attribute)
+	}
+	delete(body, "status")
+	delete(body, "links")
+	body["name"] = params[0]
+	d.attributes = body
+	status := s.gtm.change(d)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"resource": d.toJSON(), "status": status})
+}
+
+func (s *Server) gtmDeleteDomain(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	status := s.gtm.change(d)
+	delete(s.gtm.domains, params[0])
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"resource": nil, "status": status})
+}
+
+func (s *Server) gtmDomainStatus(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, d.status)
+}
+
+func (s *Server) gtmCreateDatacenter(w http.ResponseWriter, r *http.Request, params []string) {
+	body := make(map[string]interface{})
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	// user datacenters are numbered from 3131, as in the GTM examples
+	id := 3131
+	for {
+		if _, ok := d.collections["datacenters"][strconv.Itoa(id)]; !ok {
+			break
+		}
+		id++
+	}
+	body["datacenterId"] = id
+	d.collections["datacenters"][strconv.Itoa(id)] = body
+	status := s.gtm.change(d)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{"resource": body, "status": status})
+}
+
+func (s *Server) gtmCreateDefaultDatacenter(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	id := gtmMapDefaultDC
+	switch params[1] {
+	case "datacenter-for-ip-version-selector-ipv4":
+		id = gtmIPv4DefaultDC
+	case "datacenter-for-ip-version-selector-ipv6":
+		id = gtmIPv6DefaultDC
+	}
+
+	dc, ok := d.collections["datacenters"][strconv.Itoa(id)]
+	if !ok {
+		dc = map[string]interface{}{
+			"datacenterId": id,
+			"nickname":     "Default Datacenter",
+			"virtual":      true,
+			"defaultLoadObject": map[string]interface{}{
+				"loadObject":     nil,
+				"loadObjectPort": 0,
+				"loadServers":    nil,
+			},
+		}
+		d.collections["datacenters"][strconv.Itoa(id)] = dc
+	}
+	status := s.gtm.change(d)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{"resource": dc, "status": status})
+}
+
+func (s *Server) gtmListObjects(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	items := d.toJSON()[gtmCollections[params[1]]]
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+func (s *Server) gtmGetObject(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	obj, ok := d.collections[params[1]][params[2]]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s %s not found", gtmEntity(params[1]), params[2]))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, obj)
+}
+
+// gtmPutObject creates or replaces a named object. Datacenters must already exist,
+// since they are created with a POST that assigns their ID.
+func (s *Server) gtmPutObject(w http.ResponseWriter, r *http.Request, params []string) {
+	body := make(map[string]interface{})
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	_, exists := d.collections[params[1]][params[2]]
+	if params[1] == "datacenters" {
+		if !exists {
+			WriteProblem(w, http.StatusNotFound, "Not Found", "Datacenter "+params[2]+" not found")
+			return
+		}
+		id, _ := strconv.Atoi(params[2])
+		body["datacenterId"] = id
+	} else {
+		body["name"] = params[2]
+	}
+	delete(body, "links")
+
+	d.collections[params[1]][params[2]] = body
+	status := s.gtm.change(d)
+
+	code := http.StatusOK
+	if !exists {
+		code = http.StatusCreated
+	}
+	WriteJSON(w, code, map[string]interface{}{"resource": body, "status": status})
+}
+
+func (s *Server) gtmDeleteObject(w http.ResponseWriter, r *http.Request, params []string) {
+	s.gtm.lock.Lock()
+	defer s.gtm.lock.Unlock()
+
+	d := s.gtmDomain(w, params[0])
+	if d == nil {
+		return
+	}
+
+	if _, ok := d.collections[params[1]][params[2]]; !ok {
+		WriteProblem(w, http.StatusNotFound, "Not Found", fmt.Sprintf("%s %s not found", gtmEntity(params[1]), params[2]))
+		return
+	}
+
+	if params[1] == "datacenters" {
+		id, _ := strconv.Atoi(params[2])
+		for _, p := range d.collections["properties"] {
+			targets, _ := p["trafficTargets"].([]interface{})
+			for _, t := range targets {
+				if tt, ok := t.(map[string]interface{}); ok && fmt.Sprint(tt["datacenterId"]) == strconv.Itoa(id) {
+					WriteProblem(w, http.StatusBadRequest, "Bad Request", "Datacenter "+params[2]+" is referenced by property "+fmt.Sprint(p["name"]))
+					return
+				}
+			}
+		}
+	}
+
+	delete(d.collections[params[1]], params[2])
+	status := s.gtm.change(d)
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"resource": nil, "status": status})
+}
@@ -0,0 +1,1088 @@
+package mockapi
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// AccountID is the account every mock object belongs to
+	AccountID = "act_1-MOCK"
+
+	// ContractID is the contract seeded in the mock PAPI state
+	ContractID = "ctr_1-MOCK"
+
+	// GroupID is the group seeded in the mock PAPI state
+	GroupID = "grp_1"
+
+	// GroupName is the name of the seeded group
+	GroupName = "Mock Group"
+
+	// RuleFormat is the latest rule format reported by the mock PAPI
+	RuleFormat = "v2020-03-04"
+)
+
+type (
+	papiState struct {
+		lock sync.Mutex
+		seq  int
+
+		products      []map[string]interface{}
+		cpCodes       []*papiCPCode
+		edgeHostnames []*papiEdgeHostname
+		properties    map[string]*papiProperty
+	}
+
+	papiCPCode struct {
+		ID         string    `json:"cpcodeId"`
+		Name       string    `json:"cpcodeName"`
+		ProductIDs []string  `json:"productIds"`
+		Created    time.Time `json:"createdDate"`
+		ContractID string    `json:"-"`
+		GroupID    string    `json:"-"`
+	}
+
+	papiEdgeHostname struct {
+		ID                string `json:"edgeHostnameId"`
+		Domain            string `json:"edgeHostnameDomain"`
+		ProductID         string `json:"productId"`
+		DomainPrefix      string `json:"domainPrefix"`
+		DomainSuffix      string `json:"domainSuffix"`
+		Secure            bool   `json:"secure"`
+		IPVersionBehavior string `json:"ipVersionBehavior"`
+		CertEnrollmentID  int    `json:"certEnrollmentId,omitempty"`
+		Status            string `json:"status"`
+		ContractID        string `json:"-"`
+		GroupID           string `json:"-"`
+	}
+
+	papiProperty struct {
+		ID          string
+		Name        string
+		ContractID  string
+		GroupID     string
+		ProductID   string
+		RuleFormat  string
+		Note        string
+		Versions    []*papiVersion
+		Activations []*papiActivation
+	}
+
+	papiVersion struct {
+		PropertyVersion   int
+		CreateFromVersion int
+		Etag              string
+		Note              string
+		ProductID         string
+		RuleFormat        string
+		StagingStatus     string
+		ProductionStatus  string
+		UpdatedDate       time.Time
+		Rules             map[string]interface{}
+		Hostnames         []map[string]interface{}
+	}
+
+	papiActivation struct {
+		ID              string   `json:"activationId"`
+		Type            string   `json:"activationType"`
+		PropertyID      string   `json:"propertyId"`
+		PropertyName    string   `json:"propertyName"`
+		PropertyVersion int      `json:"propertyVersion"`
+		Network         string   `json:"network"`
+		Status          string   `json:"status"`
+		SubmitDate      string   `json:"submitDate"`
+		UpdateDate      string   `json:"updateDate"`
+		Note            string   `json:"note,omitempty"`
+		NotifyEmails    []string `json:"notifyEmails"`
+	}
+)
+
+func newPAPIState() *papiState {
+	return &papiState{
+		products: []map[string]interface{}{
+			{"productId": "prd_SPM", "productName": "Ion Premier"},
+			{"productId": "prd_Fresca", "productName": "Ion Standard"},
+			{"productId": "prd_Site_Del", "productName": "Dynamic Site Delivery"},
+		},
+		properties: make(map[string]*papiProperty),
+	}
+}
+
+func (p *papiState) next() int {
+	p.seq++
+	return p.seq
+}
+
+func etag(v interface{}) string {
+	data, _ := json.Marshal(v)
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Server) registerPAPI() {
+	s.Handle("GET", `/papi/v1/contracts`, s.papiListContracts)
+	s.Handle("GET", `/papi/v1/groups`, s.papiListGroups)
+	s.Handle("GET", `/papi/v1/products`, s.papiListProducts)
+	s.Handle("GET", `/papi/v1/rule-formats`, s.papiListRuleFormats)
+	s.Handle("GET", `/papi/v1/schemas/products/([^/]+)/([^/]+)`, s.papiGetRuleFormatSchema)
+	s.Handle("GET", `/papi/v1/cpcodes`, s.papiListCPCodes)
+	s.Handle("POST", `/papi/v1/cpcodes`, s.papiCreateCPCode)
+	s.Handle("GET", `/papi/v1/cpcodes/([^/]+)`, s.papiGetCPCode)
+	s.Handle("GET", `/papi/v1/edgehostnames`, s.papiListEdgeHostnames)
+	s.Handle("POST", `/papi/v1/edgehostnames`, s.papiCreateEdgeHostname)
+	s.Handle("GET", `/papi/v1/edgehostnames/([^/]+)`, s.papiGetEdgeHostname)
+	s.Handle("GET", `/papi/v1/properties`, s.papiListProperties)
+	s.Handle("POST", `/papi/v1/properties`, s.papiCreateProperty)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)`, s.papiGetProperty)
+	s.Handle("DELETE", `/papi/v1/properties/([^/]+)`, s.papiDeleteProperty)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/versions`, s.papiListVersions)
+	s.Handle("POST", `/papi/v1/properties/([^/]+)/versions`, s.papiCreateVersion)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/versions/(latest|\d+)`, s.papiGetVersion)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/versions/(\d+)/rules`, s.papiGetRules)
+	s.Handle("PUT", `/papi/v1/properties/([^/]+)/versions/(\d+)/rules`, s.papiUpdateRules)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/versions/(\d+)/hostnames`, s.papiGetHostnames)
+	s.Handle("PUT", `/papi/v1/properties/([^/]+)/versions/(\d+)/hostnames`, s.papiUpdateHostnames)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/activations`, s.papiListActivations)
+	s.Handle("POST", `/papi/v1/properties/([^/]+)/activations`, s.papiCreateActivation)
+	s.Handle("GET", `/papi/v1/properties/([^/]+)/activations/([^/]+)`, s.papiGetActivation)
+	s.Handle("DELETE", `/papi/v1/properties/([^/]+)/activations/([^/]+)`, s.papiCancelActivation)
+	s.Handle("POST", `/papi/v1/search/find-by-value`, s.papiSearch)
+}
+
+func (s *Server) papiListContracts(w http.ResponseWriter, r *http.Request, _ []string) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId": AccountID,
+		"contracts": map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"contractId": ContractID, "contractTypeName": "Direct Customer"},
+			},
+		},
+	})
+}
+
+func (s *Server) papiListGroups(w http.ResponseWriter, r *http.Request, _ []string) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId":   AccountID,
+		"accountName": "Mock Account",
+		"groups": map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"groupId": GroupID, "groupName": GroupName, "contractIds": []string{ContractID}},
+			},
+		},
+	})
+}
+
+func (s *Server) papiListProducts(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId":  AccountID,
+		"contractId": r.URL.Query().Get("contractId"),
+		"products":   map[string]interface{}{"items": s.papi.products},
+	})
+}
+
+func (s *Server) papiListRuleFormats(w http.ResponseWriter, r *http.Request, _ []string) {
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"ruleFormats": map[string]interface{}{
+			"items": []string{"latest", "v2019-07-25", RuleFormat},
+		},
+	})
+}
+
+// papiGetRuleFormatSchema returns a rule format schema with the origin and cpCode behaviors
+// and the path criterion
+func (s *Server) papiGetRuleFormatSchema(w http.ResponseWriter, r *http.Request, params []string) {
+	known := false
+	for _, f := range []string{"latest", "v2019-07-25", RuleFormat} {
+		known = known || f == params[1]
+	}
+	if !known {
+		WriteProblem(w, http.StatusNotFound, "Not Found", "Unknown rule format "+params[1])
+		return
+	}
+
+	entry := func(name string, options map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":    map[string]interface{}{"enum": []string{name}},
+				"options": map[string]interface{}{"type": "object", "properties": options, "additionalProperties": false},
+			},
+		}
+	}
+	// like PAPI, option types are shared through $ref, some of them recursive
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"definitions": map[string]interface{}{
+			"type": map[string]interface{}{
+				"port": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 65535},
+				"cpCode": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"id":     map[string]interface{}{"type": "integer"},
+						"parent": map[string]interface{}{"$ref": "#/definitions/type/cpCode"},
+					},
+					"required": []string{"id"},
+				},
+			},
+			"catalog": map[string]interface{}{
+				"behaviors": map[string]interface{}{
+					"origin": entry("origin", map[string]interface{}{
+						"hostname":   map[string]interface{}{"type": "string"},
+						"originType": map[string]interface{}{"type": "string", "enum": []string{"CUSTOMER", "NET_STORAGE"}},
+						"httpPort":   map[string]interface{}{"$ref": "#/definitions/type/port"},
+					}),
+					"cpCode": entry("cpCode", map[string]interface{}{
+						"value": map[string]interface{}{"$ref": "#/definitions/type/cpCode"},
+					}),
+				},
+				"criteria": map[string]interface{}{
+					"path": entry("path", map[string]interface{}{
+						"matchOperator": map[string]interface{}{"type": "string", "enum": []string{"MATCHES_ONE_OF", "DOES_NOT_MATCH_ONE_OF"}},
+						"values":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					}),
+				},
+			},
+		},
+	})
+}
+
+func (s *Server) papiListCPCodes(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := make([]*papiCPCode, 0)
+	for _, c := range s.papi.cpCodes {
+		if c.ContractID == contractID && c.GroupID == groupID {
+			items = append(items, c)
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId":  AccountID,
+		"contractId": contractID,
+		"groupId":    groupID,
+		"cpcodes":    map[string]interface{}{"items": items},
+	})
+}
+
+func (s *Server) papiCreateCPCode(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		ProductID string `json:"productId"`
+		Name      string `json:"cpcodeName"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	if body.Name == "" || body.ProductID == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "cpcodeName and productId are required")
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	c := &papiCPCode{
+		ID:         fmt.Sprintf("cpc_%d", 100000+s.papi.next()),
+		Name:       body.Name,
+		ProductIDs: []string{body.ProductID},
+		Created:    time.Now().UTC(),
+		ContractID: r.URL.Query().Get("contractId"),
+		GroupID:    r.URL.Query().Get("groupId"),
+	}
+	s.papi.cpCodes = append(s.papi.cpCodes, c)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"cpcodeLink": fmt.Sprintf("/papi/v1/cpcodes/%s?contractId=%s&groupId=%s", c.ID, c.ContractID, c.GroupID),
+	})
+}
+
+func (s *Server) papiGetCPCode(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	id := params[0]
+	if !strings.HasPrefix(id, "cpc_") {
+		id = "cpc_" + id
+	}
+	for _, c := range s.papi.cpCodes {
+		if c.ID == id {
+			WriteJSON(w, http.StatusOK, map[string]interface{}{
+				"accountId":  AccountID,
+				"contractId": c.ContractID,
+				"groupId":    c.GroupID,
+				"cpcodes":    map[string]interface{}{"items": []*papiCPCode{c}},
+			})
+			return
+		}
+	}
+
+	WriteProblem(w, http.StatusNotFound, "Not found", "CP code "+params[0]+" not found")
+}
+
+func (s *Server) papiListEdgeHostnames(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := make([]*papiEdgeHostname, 0)
+	for _, e := range s.papi.edgeHostnames {
+		if e.ContractID == contractID && e.GroupID == groupID {
+			items = append(items, e)
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"accountId":     AccountID,
+		"contractId":    contractID,
+		"groupId":       groupID,
+		"edgeHostnames": map[string]interface{}{"items": items},
+	})
+}
+
+func (s *Server) papiCreateEdgeHostname(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body papiEdgeHostname
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	if body.DomainPrefix == "" || body.DomainSuffix == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "domainPrefix and domainSuffix are required")
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	body.Domain = body.DomainPrefix + "." + body.DomainSuffix
+	for _, e := range s.papi.edgeHostnames {
+		if e.Domain == body.Domain {
+			WriteProblem(w, http.StatusConflict, "Conflict", "edge hostname "+body.Domain+" already exists")
+			return
+		}
+	}
+
+	body.ID = fmt.Sprintf("ehn_%d", s.papi.next())
+	body.Status = "ACTIVE"
+	body.ContractID = r.URL.Query().Get("contractId")
+	body.GroupID = r.URL.Query().Get("groupId")
+	if body.IPVersionBehavior == "" {
+		body.IPVersionBehavior = "IPV4"
+	}
+	s.papi.edgeHostnames = append(s.papi.edgeHostnames, &body)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"edgeHostnameLink": fmt.Sprintf("/papi/v1/edgehostnames/%s?contractId=%s&groupId=%s", body.ID, body.ContractID, body.GroupID),
+	})
+}
+
+func (s *Server) papiGetEdgeHostname(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	for _, e := range s.papi.edgeHostnames {
+		if e.ID == params[0] {
+			WriteJSON(w, http.StatusOK, map[string]interface{}{
+				"accountId":     AccountID,
+				"contractId":    e.ContractID,
+				"groupId":       e.GroupID,
+				"edgeHostnames": map[string]interface{}{"items": []*papiEdgeHostname{e}},
+			})
+			return
+		}
+	}
+
+	WriteProblem(w, http.StatusNotFound, "Not found", "edge hostname "+params[0]+" not found")
+}
+
+// latest returns the most recent version of the property
+func (p *papiProperty) latest() *papiVersion {
+	return p.Versions[len(p.Versions)-1]
+}
+
+// version returns the given version, or nil if it does not exist
+func (p *papiProperty) version(v int) *papiVersion {
+	if v < 1 || v > len(p.Versions) {
+		return nil
+	}
+	return p.Versions[v-1]
+}
+
+// activeVersion returns the version number currently active on the network, or 0
+func (p *papiProperty) activeVersion(network string) int {
+	for _, v := range p.Versions {
+		status := v.StagingStatus
+		if network == "PRODUCTION" {
+			status = v.ProductionStatus
+		}
+		if status == "ACTIVE" {
+			return v.PropertyVersion
+		}
+	}
+	return 0
+}
+
+func (p *papiProperty) toJSON() map[string]interface{} {
+	out := map[string]interface{}{
+		"accountId":     AccountID,
+		"contractId":    p.ContractID,
+		"groupId":       p.GroupID,
+		"propertyId":    p.ID,
+		"propertyName":  p.Name,
+		"latestVersion": p.latest().PropertyVersion,
+		"productId":     p.ProductID,
+		"ruleFormat":    p.RuleFormat,
+	}
+	if v := p.activeVersion("STAGING"); v > 0 {
+		out["stagingVersion"] = v
+	}
+	if v := p.activeVersion("PRODUCTION"); v > 0 {
+		out["productionVersion"] = v
+	}
+	if p.Note != "" {
+		out["note"] = p.Note
+	}
+	return out
+}
+
+func (v *papiVersion) toJSON() map[string]interface{} {
+	out := map[string]interface{}{
+		"propertyVersion":  v.PropertyVersion,
+		"updatedByUser":    "mockapi",
+		"updatedDate":      v.UpdatedDate.Format(time.RFC3339),
+		"productionStatus": v.ProductionStatus,
+		"stagingStatus":    v.StagingStatus,
+		"etag":             v.Etag,
+		"productId":        v.ProductID,
+		"ruleFormat":       v.RuleFormat,
+	}
+	if v.Note != "" {
+		out["note"] = v.Note
+	}
+	if v.CreateFromVersion > 0 {
+		out["createFromVersion"] = v.CreateFromVersion
+	}
+	return out
+}
+
+func (s *Server) papiProperty(w http.ResponseWriter, id string) *papiProperty {
+	p, ok := s.papi.properties[id]
+	if !ok {
+		WriteProblem(w, http.StatusNotFound, "Not found", "property "+id+" not found")
+		return nil
+	}
+	return p
+}
+
+func (s *Server) papiPropertyVersion(w http.ResponseWriter, id, version string) (*papiProperty, *papiVersion) {
+	p := s.papiProperty(w, id)
+	if p == nil {
+		return nil, nil
+	}
+
+	if version == "latest" {
+		return p, p.latest()
+	}
+
+	n, _ := strconv.Atoi(version)
+	v := p.version(n)
+	if v == nil {
+		WriteProblem(w, http.StatusNotFound, "Not found", fmt.Sprintf("version %s of property %s not found", version, id))
+		return nil, nil
+	}
+	return p, v
+}
+
+func (s *Server) papiListProperties(w http.ResponseWriter, r *http.Request, _ []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	contractID, groupID := r.URL.Query().Get("contractId"), r.URL.Query().Get("groupId")
+	items := make([]map[string]interface{}, 0)
+	for _, p := range s.papi.sortedProperties() {
+		if p.ContractID == contractID && p.GroupID == groupID {
+			items = append(items, p.toJSON())
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"properties": map[string]interface{}{"items": items},
+	})
+}
+
+func (p *papiState) sortedProperties() []*papiProperty {
+	out := make([]*papiProperty, 0, len(p.properties))
+	for _, prop := range p.properties {
+		out = append(out, prop)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (s *Server) papiCreateProperty(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body struct {
+		Name       string `json:"propertyName"`
+		ProductID  string `json:"productId"`
+		RuleFormat string `json:"ruleFormat"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	if body.Name == "" || body.ProductID == "" {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "propertyName and productId are required")
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	for _, p := range s.papi.properties {
+		if p.Name == body.Name {
+			WriteProblem(w, http.StatusConflict, "Conflict", "property "+body.Name+" already exists")
+			return
+		}
+	}
+
+	if body.RuleFormat == "" || body.RuleFormat == "latest" {
+		body.RuleFormat = RuleFormat
+	}
+
+	p := &papiProperty{
+		ID:         fmt.Sprintf("prp_%d", s.papi.next()),
+		Name:       body.Name,
+		ContractID: r.URL.Query().Get("contractId"),
+		GroupID:    r.URL.Query().Get("groupId"),
+		ProductID:  body.ProductID,
+		RuleFormat: body.RuleFormat,
+	}
+	v := &papiVersion{
+		PropertyVersion:  1,
+		ProductID:        p.ProductID,
+		RuleFormat:       p.RuleFormat,
+		StagingStatus:    "INACTIVE",
+		ProductionStatus: "INACTIVE",
+		UpdatedDate:      time.Now().UTC(),
+		Rules: map[string]interface{}{
+			"name":     "default",
+			"options":  map[string]interface{}{},
+			"children": []interface{}{},
+			"behaviors": []interface{}{
+				map[string]interface{}{"name": "origin", "options": map[string]interface{}{}},
+			},
+		},
+		Hostnames: make([]map[string]interface{}, 0),
+	}
+	v.Etag = etag(v.Rules)
+	p.Versions = []*papiVersion{v}
+	s.papi.properties[p.ID] = p
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"propertyLink": fmt.Sprintf("/papi/v1/properties/%s?contractId=%s&groupId=%s", p.ID, p.ContractID, p.GroupID),
+	})
+}
+
+func (s *Server) papiGetProperty(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"properties": map[string]interface{}{"items": []interface{}{p.toJSON()}},
+	})
+}
+
+func (s *Server) papiDeleteProperty(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	if p.activeVersion("STAGING") > 0 || p.activeVersion("PRODUCTION") > 0 {
+		WriteProblem(w, http.StatusBadRequest, "Property is active", "an active property cannot be deleted")
+		return
+	}
+
+	delete(s.papi.properties, p.ID)
+	WriteJSON(w, http.StatusOK, map[string]interface{}{"message": "Deletion Successful."})
+}
+
+func (s *Server) papiVersionsJSON(p *papiProperty, versions []*papiVersion) map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(versions))
+	for _, v := range versions {
+		items = append(items, v.toJSON())
+	}
+
+	return map[string]interface{}{
+		"propertyId":   p.ID,
+		"propertyName": p.Name,
+		"accountId":    AccountID,
+		"contractId":   p.ContractID,
+		"groupId":      p.GroupID,
+		"versions":     map[string]interface{}{"items": items},
+	}
+}
+
+func (s *Server) papiListVersions(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	// PAPI lists the most recent versions first
+	versions := make([]*papiVersion, 0, len(p.Versions))
+	for i := len(p.Versions) - 1; i >= 0; i-- {
+		versions = append(versions, p.Versions[i])
+	}
+
+	WriteJSON(w, http.StatusOK, s.papiVersionsJSON(p, versions))
+}
+
+func (s *Server) papiCreateVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		CreateFromVersion     int    `json:"createFromVersion"`
+		CreateFromVersionEtag string `json:"createFromVersionEtag"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	from := p.version(body.CreateFromVersion)
+	if from == nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", fmt.Sprintf("createFromVersion %d does not exist", body.CreateFromVersion))
+		return
+	}
+
+	if body.CreateFromVersionEtag != "" && body.CreateFromVersionEtag != from.Etag {
+		WriteProblem(w, http.StatusPreconditionFailed, "Precondition failed", "createFromVersionEtag does not match")
+		return
+	}
+
+	var rules map[string]interface{}
+	data, _ := json.Marshal(from.Rules)
+	_ = json.Unmarshal(data, &rules)
+
+	hostnames := make([]map[string]interface{}, len(from.Hostnames))
+	copy(hostnames, from.Hostnames)
+
+	v := &papiVersion{
+		PropertyVersion:   len(p.Versions) + 1,
+		CreateFromVersion: from.PropertyVersion,
+		Etag:              from.Etag,
+		ProductID:         from.ProductID,
+		RuleFormat:        from.RuleFormat,
+		StagingStatus:     "INACTIVE",
+		ProductionStatus:  "INACTIVE",
+		UpdatedDate:       time.Now().UTC(),
+		Rules:             rules,
+		Hostnames:         hostnames,
+	}
+	p.Versions = append(p.Versions, v)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"versionLink": fmt.Sprintf("/papi/v1/properties/%s/versions/%d?contractId=%s&groupId=%s", p.ID, v.PropertyVersion, p.ContractID, p.GroupID),
+	})
+}
+
+func (s *Server) papiGetVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p, v := s.papiPropertyVersion(w, params[0], params[1])
+	if v == nil {
+		return
+	}
+
+	if network := r.URL.Query().Get("activatedOn"); params[1] == "latest" && network != "" {
+		v = p.version(p.activeVersion(network))
+		if v == nil {
+			WriteProblem(w, http.StatusNotFound, "Not found", "no version is active on "+network)
+			return
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, s.papiVersionsJSON(p, []*papiVersion{v}))
+}
+
+func (s *Server) papiGetRules(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p, v := s.papiPropertyVersion(w, params[0], params[1])
+	if v == nil {
+		return
+	}
+
+	out := map[string]interface{}{
+		"accountId":       AccountID,
+		"contractId":      p.ContractID,
+		"groupId":         p.GroupID,
+		"propertyId":      p.ID,
+		"propertyVersion": v.PropertyVersion,
+		"etag":            v.Etag,
+		"ruleFormat":      v.RuleFormat,
+		"rules":           v.Rules,
+	}
+	if v.Note != "" {
+		out["comments"] = v.Note
+	}
+
+	w.Header().Set("Etag", v.Etag)
+	WriteJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) papiUpdateRules(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		Comments   string                 `json:"comments"`
+		RuleFormat string                 `json:"ruleFormat"`
+		Rules      map[string]interface{} `json:"rules"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p, v := s.papiPropertyVersion(w, params[0], params[1])
+	if v == nil {
+		return
+	}
+
+	if v.StagingStatus != "INACTIVE" || v.ProductionStatus != "INACTIVE" {
+		WriteProblem(w, http.StatusForbidden, "Forbidden", "an activated version cannot be modified")
+		return
+	}
+
+	if body.Rules == nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "rules are required")
+		return
+	}
+
+	if name, _ := body.Rules["name"].(string); name != "default" {
+		WriteProblem(w, http.StatusBadRequest, "Invalid rule tree", "the top level rule must be named default")
+		return
+	}
+
+	if body.RuleFormat != "" {
+		v.RuleFormat = body.RuleFormat
+	}
+	if body.Comments != "" {
+		v.Note = body.Comments
+	}
+	v.Rules = body.Rules
+	v.Etag = etag(body)
+	v.UpdatedDate = time.Now().UTC()
+
+	out := map[string]interface{}{
+		"accountId":       AccountID,
+		"contractId":      p.ContractID,
+		"groupId":         p.GroupID,
+		"propertyId":      p.ID,
+		"propertyVersion": v.PropertyVersion,
+		"etag":            v.Etag,
+		"ruleFormat":      v.RuleFormat,
+		"rules":           v.Rules,
+	}
+	if v.Note != "" {
+		out["comments"] = v.Note
+	}
+
+	WriteJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) papiHostnamesJSON(p *papiProperty, v *papiVersion) map[string]interface{} {
+	return map[string]interface{}{
+		"accountId":       AccountID,
+		"contractId":      p.ContractID,
+		"groupId":         p.GroupID,
+		"propertyId":      p.ID,
+		"propertyVersion": v.PropertyVersion,
+		"etag":            v.Etag,
+		"hostnames":       map[string]interface{}{"items": v.Hostnames},
+	}
+}
+
+func (s *Server) papiGetHostnames(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p, v := s.papiPropertyVersion(w, params[0], params[1])
+	if v == nil {
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, s.papiHostnamesJSON(p, v))
+}
+
+func (s *Server) papiUpdateHostnames(w http.ResponseWriter, r *http.Request, params []string) {
+	var body []map[string]interface{}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p, v := s.papiPropertyVersion(w, params[0], params[1])
+	if v == nil {
+		return
+	}
+
+	if v.StagingStatus != "INACTIVE" || v.ProductionStatus != "INACTIVE" {
+		WriteProblem(w, http.StatusForbidden, "Forbidden", "an activated version cannot be modified")
+		return
+	}
+
+	if body == nil {
+		body = make([]map[string]interface{}, 0)
+	}
+	v.Hostnames = body
+
+	WriteJSON(w, http.StatusOK, s.papiHostnamesJSON(p, v))
+}
+
+func (s *Server) papiActivationsJSON(p *papiProperty, activations []*papiActivation) map[string]interface{} {
+	return map[string]interface{}{
+		"accountId":   AccountID,
+		"contractId":  p.ContractID,
+		"groupId":     p.GroupID,
+		"activations": map[string]interface{}{"items": activations},
+	}
+}
+
+func (s *Server) papiListActivations(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	// most recent first, like PAPI
+	activations := make([]*papiActivation, 0, len(p.Activations))
+	for i := len(p.Activations) - 1; i >= 0; i-- {
+		activations = append(activations, p.Activations[i])
+	}
+
+	WriteJSON(w, http.StatusOK, s.papiActivationsJSON(p, activations))
+}
+
+// papiCreateActivation activates or deactivates a version. Activations complete
+// immediately so pollers see ACTIVE on their first status check.
+func (s *Server) papiCreateActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	var body struct {
+		PropertyVersion int      `json:"propertyVersion"`
+		Network         string   `json:"network"`
+		ActivationType  string   `json:"activationType"`
+		Note            string   `json:"note"`
+		NotifyEmails    []string `json:"notifyEmails"`
+	}
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	if body.Network != "STAGING" && body.Network != "PRODUCTION" {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "network must be STAGING or PRODUCTION")
+		return
+	}
+
+	if len(body.NotifyEmails) == 0 {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", "notifyEmails is required")
+		return
+	}
+
+	if body.ActivationType == "" {
+		body.ActivationType = "ACTIVATE"
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	v := p.version(body.PropertyVersion)
+	if v == nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", fmt.Sprintf("version %d does not exist", body.PropertyVersion))
+		return
+	}
+
+	setStatus := func(v *papiVersion, status string) {
+		if body.Network == "STAGING" {
+			v.StagingStatus = status
+		} else {
+			v.ProductionStatus = status
+		}
+	}
+
+	if body.ActivationType == "ACTIVATE" {
+		if active := p.version(p.activeVersion(body.Network)); active != nil {
+			setStatus(active, "DEACTIVATED")
+		}
+		setStatus(v, "ACTIVE")
+	} else {
+		setStatus(v, "DEACTIVATED")
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	a := &papiActivation{
+		ID:              fmt.Sprintf("atv_%d", s.papi.next()),
+		Type:            body.ActivationType,
+		PropertyID:      p.ID,
+		PropertyName:    p.Name,
+		PropertyVersion: v.PropertyVersion,
+		Network:         body.Network,
+		Status:          "ACTIVE",
+		SubmitDate:      now,
+		UpdateDate:      now,
+		Note:            body.Note,
+		NotifyEmails:    body.NotifyEmails,
+	}
+	p.Activations = append(p.Activations, a)
+
+	WriteJSON(w, http.StatusCreated, map[string]interface{}{
+		"activationLink": fmt.Sprintf("/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s", p.ID, a.ID, p.ContractID, p.GroupID),
+	})
+}
+
+func (s *Server) papiGetActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	for _, a := range p.Activations {
+		if a.ID == params[1] {
+			WriteJSON(w, http.StatusOK, s.papiActivationsJSON(p, []*papiActivation{a}))
+			return
+		}
+	}
+
+	WriteProblem(w, http.StatusNotFound, "Not found", "activation "+params[1]+" not found")
+}
+
+func (s *Server) papiCancelActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	p := s.papiProperty(w, params[0])
+	if p == nil {
+		return
+	}
+
+	for _, a := range p.Activations {
+		if a.ID == params[1] {
+			if a.Status != "PENDING" {
+				WriteProblem(w, http.StatusUnprocessableEntity, "Activation not pending", "only pending activations can be canceled")
+				return
+			}
+			a.Status = "ABORTED"
+			WriteJSON(w, http.StatusOK, s.papiActivationsJSON(p, []*papiActivation{a}))
+			return
+		}
+	}
+
+	WriteProblem(w, http.StatusNotFound, "Not found", "activation "+params[1]+" not found")
+}
+
+func (s *Server) papiSearch(w http.ResponseWriter, r *http.Request, _ []string) {
+	var body map[string]string
+	if !ReadJSON(w, r, &body) {
+		return
+	}
+
+	s.papi.lock.Lock()
+	defer s.papi.lock.Unlock()
+
+	edgeHostnameDomains := make(map[string]string)
+	for _, e := range s.papi.edgeHostnames {
+		edgeHostnameDomains[e.ID] = e.Domain
+	}
+
+	matches := func(p *papiProperty, v *papiVersion) bool {
+		if name, ok := body["propertyName"]; ok {
+			return p.Name == name
+		}
+		for _, h := range v.Hostnames {
+			if hostname, ok := body["hostname"]; ok && h["cnameFrom"] == hostname {
+				return true
+			}
+			if ehn, ok := body["edgeHostname"]; ok {
+				if h["cnameTo"] == ehn {
+					return true
+				}
+				if id, _ := h["edgeHostnameId"].(string); id != "" && edgeHostnameDomains[id] == ehn {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	items := make([]map[string]interface{}, 0)
+	for _, p := range s.papi.sortedProperties() {
+		for _, v := range p.Versions {
+			// like PAPI, only the latest and active versions are reported
+			if v != p.latest() && v.StagingStatus != "ACTIVE" && v.ProductionStatus != "ACTIVE" {
+				continue
+			}
+			if !matches(p, v) {
+				continue
+			}
+			items = append(items, map[string]interface{}{
+				"accountId":        AccountID,
+				"assetId":          "aid_" + strings.TrimPrefix(p.ID, "prp_"),
+				"contractId":       p.ContractID,
+				"groupId":          p.GroupID,
+				"propertyId":       p.ID,
+				"propertyName":     p.Name,
+				"propertyVersion":  v.PropertyVersion,
+				"stagingStatus":    v.StagingStatus,
+				"productionStatus": v.ProductionStatus,
+				"updatedByUser":    "mockapi",
+				"updatedDate":      v.UpdatedDate.Format(time.RFC3339),
+			})
+		}
+	}
+
+	WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"versions": map[string]interface{}{"items": items},
+	})
+}
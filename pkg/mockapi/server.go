@@ -0,0 +1,232 @@
+// Package mockapi is an in-memory fake of the Akamai OPEN APIs used by the provider.
+//
+// The server validates EdgeGrid signatures against a fixed set of credentials and keeps
+// all state in memory, so the subproviders can be exercised end-to-end without a real
+// .edgerc. Point a provider at it through the `host` field of a config.Options block:
+//
+//	srv := mockapi.NewServer()
+//	defer srv.Close()
+//	defer srv.Install()()
+//
+//	config := srv.ProviderConfig("property", "dns", "gtm")
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+)
+
+const (
+	// ClientToken is the client token the server accepts
+	ClientToken = "akab-mock-client-token"
+
+	// ClientSecret is the client secret used to validate request signatures
+	ClientSecret = "bW9jay1jbGllbnQtc2VjcmV0LWZvci10ZXN0cw=="
+
+	// AccessToken is the access token the server accepts
+	AccessToken = "akab-mock-access-token"
+
+	// MaxBody is the maximum body size used when hashing signed requests
+	MaxBody = 131072
+)
+
+type (
+	// Server is a running mock Akamai API endpoint
+	Server struct {
+		*httptest.Server
+
+		lock   sync.Mutex
+		routes []route
+		log    []string
+
+		papi *papiState
+		dns  *dnsState
+		gtm  *gtmState
+	}
+
+	// Handler serves a single mock API route. Path parameters matched by the
+	// route pattern are passed in order.
+	Handler func(w http.ResponseWriter, r *http.Request, params []string)
+
+	route struct {
+		method  string
+		pattern *regexp.Regexp
+		handler Handler
+	}
+)
+
+// NewServer starts a new TLS mock server with empty PAPI, Config DNS v2 and GTM v1.4 state
+func NewServer() *Server {
+	s := &Server{}
+
+	s.papi = newPAPIState()
+	s.dns = newDNSState()
+	s.gtm = newGTMState()
+
+	s.registerPAPI()
+	s.registerDNS()
+	s.registerGTM()
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Host returns the value to use for the `host` field of a provider config block
+func (s *Server) Host() string {
+	return s.URL
+}
+
+// Config returns an edgegrid configuration that signs requests for this server
+func (s *Server) Config() edgegrid.Config {
+	return edgegrid.Config{
+		Host:         s.Host(),
+		ClientToken:  ClientToken,
+		ClientSecret: ClientSecret,
+		AccessToken:  AccessToken,
+		MaxBody:      MaxBody,
+	}
+}
+
+// Install points the shared edgegrid http client at the server so its self-signed
+// certificate is trusted. The returned function restores the previous client.
+func (s *Server) Install() func() {
+	prev := client.Client
+	client.Client = s.Client()
+
+	return func() {
+		client.Client = prev
+	}
+}
+
+// ProviderConfig returns a provider block with a credentials block for each section,
+// e.g. ProviderConfig("property", "dns")
+func (s *Server) ProviderConfig(sections ...string) string {
+	var b strings.Builder
+
+	b.WriteString("provider \"akamai\" {\n")
+	for _, section := range sections {
+		fmt.Fprintf(&b, `  %s {
+    host          = %q
+    access_token  = %q
+    client_token  = %q
+    client_secret = %q
+    max_body      = %d
+  }
+`, section, s.Host(), AccessToken, ClientToken, ClientSecret, MaxBody)
+	}
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// Handle registers an additional route on the server. The pattern is a regular
+// expression matched against the whole request path.
+func (s *Server) Handle(method, pattern string, handler Handler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.routes = append(s.routes, route{
+		method:  method,
+		pattern: regexp.MustCompile("^" + pattern + "$"),
+		handler: handler,
+	})
+}
+
+// Requests returns the method and path of every request the server accepted
+func (s *Server) Requests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	out := make([]string, len(s.log))
+	copy(out, s.log)
+
+	return out
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := verifySignature(r); err != nil {
+		WriteProblem(w, http.StatusUnauthorized, "Not authorized", err.Error())
+		return
+	}
+
+	// routes may be registered while requests are served, so copy under lock
+	s.lock.Lock()
+	routes := s.routes
+	s.log = append(s.log, r.Method+" "+r.URL.Path)
+	s.lock.Unlock()
+
+	path := r.URL.Path
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	var methodMismatch bool
+	for _, rt := range routes {
+		m := rt.pattern.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		if rt.method != r.Method {
+			methodMismatch = true
+			continue
+		}
+		rt.handler(w, r, m[1:])
+		return
+	}
+
+	if methodMismatch {
+		WriteProblem(w, http.StatusMethodNotAllowed, "Method not allowed", r.Method+" "+r.URL.Path)
+		return
+	}
+
+	WriteProblem(w, http.StatusNotFound, "Not found", r.URL.Path)
+}
+
+// WriteJSON writes the value as a JSON response
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// WriteProblem writes an RFC 7807 problem response, as returned by the OPEN APIs
+func WriteProblem(w http.ResponseWriter, status int, title, detail string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"type":   "https://problems.luna.akamaiapis.net/mock/" + strings.ToLower(strings.Replace(title, " ", "-", -1)),
+		"title":  title,
+		"status": status,
+		"detail": detail,
+	})
+}
+
+// ReadJSON decodes the request body into v, writing a 400 response on failure
+func ReadJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", err.Error())
+		return false
+	}
+
+	if len(body) == 0 {
+		return true
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		WriteProblem(w, http.StatusBadRequest, "Bad request", err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,222 @@
+package mockapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	gtm "github.com/akamai/AkamaiOPEN-edgegrid-golang/configgtm-v1_4"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	papi "github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_Signature(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	tests := map[string]struct {
+		config   edgegrid.Config
+		sign     bool
+		expected int
+	}{
+		"valid signature": {
+			config:   srv.Config(),
+			sign:     true,
+			expected: http.StatusOK,
+		},
+		"unsigned request": {
+			config:   srv.Config(),
+			expected: http.StatusUnauthorized,
+		},
+		"wrong secret": {
+			config: func() edgegrid.Config {
+				c := srv.Config()
+				c.ClientSecret = "bm90LXRoZS1yaWdodC1zZWNyZXQ="
+				return c
+			}(),
+			sign:     true,
+			expected: http.StatusUnauthorized,
+		},
+		"wrong client token": {
+			config: func() edgegrid.Config {
+				c := srv.Config()
+				c.ClientToken = "akab-someone-else"
+				return c
+			}(),
+			sign:     true,
+			expected: http.StatusUnauthorized,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := client.NewRequest(test.config, "GET", "/papi/v1/groups", nil)
+			require.NoError(t, err)
+
+			if test.sign {
+				req = edgegrid.AddRequestHeader(test.config, req)
+			}
+
+			res, err := client.Client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expected, res.StatusCode)
+		})
+	}
+}
+
+func TestServer_SignedPostBody(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	config := srv.Config()
+	req, err := client.NewJSONRequest(config, "POST", "/papi/v1/search/find-by-value", map[string]string{"propertyName": "none"})
+	require.NoError(t, err)
+
+	res, err := client.Do(config, req)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	// tampering with the body after signing must be rejected
+	req, err = client.NewJSONRequest(config, "POST", "/papi/v1/search/find-by-value", map[string]string{"propertyName": "none"})
+	require.NoError(t, err)
+	req = edgegrid.AddRequestHeader(config, req)
+	tampered, err := http.NewRequest("POST", req.URL.String(), strings.NewReader(`{"propertyName":"other"}`))
+	require.NoError(t, err)
+	tampered.Header = req.Header
+
+	res, err = client.Client.Do(tampered)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
+
+func TestServer_PAPI(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	papi.Config = srv.Config()
+
+	groups, err := papi.GetGroups()
+	require.NoError(t, err)
+	group, err := groups.FindGroup(GroupID)
+	require.NoError(t, err)
+
+	contracts, err := papi.GetContracts()
+	require.NoError(t, err)
+	contract, err := contracts.FindContract(ContractID)
+	require.NoError(t, err)
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.Contract = contract
+	property.Group = group
+	property.PropertyName = "www.example.com"
+	property.ProductID = "prd_SPM"
+	require.NoError(t, property.Save(""))
+	assert.Equal(t, "prp_1", property.PropertyID)
+	assert.Equal(t, 1, property.LatestVersion)
+
+	rules, err := property.GetRules("")
+	require.NoError(t, err)
+	assert.Equal(t, "default", rules.Rule.Name)
+
+	rules.Rule.Comments = "updated"
+	require.NoError(t, rules.Save(""))
+
+	activation := papi.NewActivation(papi.NewActivations())
+	activation.PropertyVersion = 1
+	activation.Network = papi.NetworkStaging
+	activation.NotifyEmails = []string{"noreply@example.com"}
+	require.NoError(t, activation.Save(property, true))
+
+	_, err = activation.GetActivation(property)
+	require.NoError(t, err)
+	assert.Equal(t, papi.StatusActive, activation.Status)
+
+	require.NoError(t, property.GetProperty(""))
+	assert.Equal(t, 1, property.StagingVersion)
+
+	versions, err := property.GetVersions("")
+	require.NoError(t, err)
+	require.Len(t, versions.Versions.Items, 1)
+	assert.Equal(t, papi.StatusActive, versions.Versions.Items[0].StagingStatus)
+}
+
+func TestServer_DNS(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	dnsv2.Config = srv.Config()
+
+	zone := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: "example.com", Type: "PRIMARY"})
+	query := dnsv2.ZoneQueryString{Contract: ContractID}
+	require.NoError(t, zone.Save(query))
+	require.NoError(t, zone.SaveChangelist())
+	require.NoError(t, zone.SubmitChangelist())
+
+	_, err := dnsv2.GetZone("missing.com")
+	assert.True(t, dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound())
+
+	soa, err := dnsv2.GetRecord("example.com", "example.com", "SOA")
+	require.NoError(t, err)
+	assert.Len(t, soa.Target, 1)
+
+	record := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	require.NoError(t, record.Save("example.com"))
+
+	err = record.Save("example.com")
+	require.Error(t, err)
+	assert.True(t, err.(dnsv2.ConfigDNSError).ConcurrencyConflict())
+
+	// SOA updates must increment the serial number
+	stale := &dnsv2.RecordBody{Name: "example.com", RecordType: "SOA", TTL: 86400, Target: soa.Target}
+	err = stale.Update("example.com")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SOA serial number must be incremented")
+
+	recordsets, err := dnsv2.GetRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, recordsets.Recordsets, 3)
+}
+
+func TestServer_GTM(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+
+	gtm.Config = srv.Config()
+
+	domain := gtm.NewDomain("example.akadns.net", "basic")
+	_, err := domain.Create(map[string]string{"contractId": ContractID, "gid": GroupID})
+	require.NoError(t, err)
+
+	dc := gtm.NewDatacenter()
+	dc.Nickname = "dc1"
+	resp, err := dc.Create("example.akadns.net")
+	require.NoError(t, err)
+	assert.Equal(t, 3131, resp.Resource.DatacenterId)
+	assert.Equal(t, "COMPLETE", resp.Status.PropagationStatus)
+
+	def, err := gtm.CreateMapsDefaultDatacenter("example.akadns.net")
+	require.NoError(t, err)
+	assert.Equal(t, gtm.MapDefaultDC, def.DatacenterId)
+
+	_, err = gtm.GetProperty("missing", "example.akadns.net")
+	assert.Error(t, err)
+
+	got, err := gtm.GetDomain("example.akadns.net")
+	require.NoError(t, err)
+	assert.Len(t, got.Datacenters, 2)
+
+	status, err := gtm.GetDomainStatus("example.akadns.net")
+	require.NoError(t, err)
+	assert.Equal(t, "COMPLETE", status.PropagationStatus)
+}
@@ -0,0 +1,193 @@
+package appsec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	actionActivate   = "ACTIVATE"
+	actionDeactivate = "DEACTIVATE"
+)
+
+var (
+	// api sends the requests to the AppSec API
+	api apiclient.Client
+
+	// activationPollInterval and activationPollTimeout bound waitForActivation
+	activationPollInterval = 30 * time.Second
+	activationPollTimeout  = 90 * time.Minute
+)
+
+type (
+	configuration struct {
+		ID                int    `json:"id,omitempty"`
+		Name              string `json:"name"`
+		Description       string `json:"description,omitempty"`
+		LatestVersion     int    `json:"latestVersion,omitempty"`
+		StagingVersion    int    `json:"stagingVersion,omitempty"`
+		ProductionVersion int    `json:"productionVersion,omitempty"`
+	}
+
+	createConfigurationRequest struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		ContractID  string   `json:"contractId"`
+		GroupID     int      `json:"groupId"`
+		Hostnames   []string `json:"hostnames"`
+	}
+
+	createConfigurationResponse struct {
+		ConfigID int `json:"configId"`
+		Version  int `json:"version"`
+	}
+
+	selectedHostnames struct {
+		HostnameList []struct {
+			Hostname string `json:"hostname"`
+		} `json:"hostnameList"`
+	}
+
+	configurationVersion struct {
+		ConfigID int `json:"configId"`
+		Version  int `json:"version"`
+	}
+
+	activationConfig struct {
+		ConfigID      int `json:"configId"`
+		ConfigVersion int `json:"configVersion"`
+	}
+
+	activationRequest struct {
+		Action             string             `json:"action"`
+		Network            string             `json:"network"`
+		Note               string             `json:"note,omitempty"`
+		NotificationEmails []string           `json:"notificationEmails"`
+		ActivationConfigs  []activationConfig `json:"activationConfigs"`
+	}
+
+	activation struct {
+		ActivationID int    `json:"activationId"`
+		Action       string `json:"action"`
+		Network      string `json:"network"`
+		Status       string `json:"status"`
+	}
+)
+
+func configLocation(configID int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d", configID)
+}
+
+func versionLocation(configID, version int) string {
+	return fmt.Sprintf("/appsec/v1/configs/%d/versions/%d", configID, version)
+}
+
+func getConfiguration(configID int) (*configuration, error) {
+	var c configuration
+	if err := api.DoRequest(http.MethodGet, configLocation(configID), nil, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// editableVersion returns the latest version of the configuration, cloning it first
+// when it has been activated since active versions are read only
+func editableVersion(log hclog.Logger, configID int) (int, error) {
+	c, err := getConfiguration(configID)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.LatestVersion != c.StagingVersion && c.LatestVersion != c.ProductionVersion {
+		return c.LatestVersion, nil
+	}
+
+	log.Debug(fmt.Sprintf("cloning active version %d of configuration %d", c.LatestVersion, configID))
+	var clone configurationVersion
+	body := map[string]interface{}{"createFromVersion": c.LatestVersion, "ruleSets": true}
+	if err := api.DoRequest(http.MethodPost, configLocation(configID)+"/versions", body, &clone); err != nil {
+		return 0, err
+	}
+	return clone.Version, nil
+}
+
+// waitForActivation polls an activation until it reaches the target status or fails
+func waitForActivation(log hclog.Logger, id int, target string) (*activation, error) {
+	var a *activation
+	err := apiclient.WaitForActivation(activationPollInterval, activationPollTimeout, func() (bool, error) {
+		a = &activation{}
+		if err := api.DoRequest(http.MethodGet, fmt.Sprintf("/appsec/v1/activations/%d", id), nil, a); err != nil {
+			a = nil
+			return false, err
+		}
+		log.Debug(fmt.Sprintf("activation %d status [%s]", id, a.Status))
+
+		switch a.Status {
+		case target:
+			return true, nil
+		case apiclient.StatusFailed:
+			return true, fmt.Errorf("%w: security configuration activation %d", apiclient.ErrActivationFailed, id)
+		}
+		return false, nil
+	})
+	if errors.Is(err, apiclient.ErrActivationTimeout) {
+		return a, fmt.Errorf("%w: security configuration activation %d, last status %q", err, id, a.Status)
+	}
+	return a, err
+}
+
+// serverFields are assigned by the API and never part of the configured JSON
+var serverFields = []string{"configId", "configVersion", "id", "targetId", "sequence", "version", "updateDate", "createDate", "used"}
+
+// normalizeJSON decodes a JSON object and drops the fields assigned by the API
+func normalizeJSON(data string) (map[string]interface{}, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &obj); err != nil {
+		return nil, err
+	}
+	for _, f := range serverFields {
+		delete(obj, f)
+	}
+	return obj, nil
+}
+
+// stateJSON returns the API object as JSON without the fields assigned by the API
+func stateJSON(obj map[string]interface{}) (string, error) {
+	for _, f := range serverFields {
+		delete(obj, f)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// suppressEquivalentJSONDiffs ignores formatting, key order and API assigned fields
+func suppressEquivalentJSONDiffs(k, old, new string, d *schema.ResourceData) bool {
+	o, err := normalizeJSON(old)
+	if err != nil {
+		return false
+	}
+	n, err := normalizeJSON(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+// validateJSONObject checks the value is a JSON object
+func validateJSONObject(v interface{}, k string) ([]string, []error) {
+	if _, err := normalizeJSON(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON object: %s", k, err)}
+	}
+	return nil, nil
+}
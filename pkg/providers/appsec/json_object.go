@@ -0,0 +1,256 @@
+package appsec
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// jsonObject implements the resources managing an AppSec object from its JSON
+// representation, such as match targets, custom rules and rate policies. Versioned
+// objects are written to the latest editable version of the configuration.
+type jsonObject struct {
+	// name is used in log messages, e.g. "match target"
+	name string
+	// attribute holds the object JSON in the resource schema
+	attribute string
+	// idAttribute exports the object ID in the resource schema
+	idAttribute string
+	// idField is the object ID field in the API response
+	idField string
+	// collection is the object collection under the configuration or version path
+	collection string
+	// versioned objects belong to a configuration version
+	versioned bool
+}
+
+func (o *jsonObject) resource() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"config_id": {
+			Type:     schema.TypeInt,
+			Required: true,
+			ForceNew: true,
+		},
+		o.attribute: {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateFunc:     validateJSONObject,
+			DiffSuppressFunc: suppressEquivalentJSONDiffs,
+		},
+		o.idAttribute: {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+	}
+	if o.versioned {
+		s["version"] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The configuration version the object was last written to",
+		}
+	}
+
+	return &schema.Resource{
+		Create: o.create,
+		Read:   o.read,
+		Update: o.update,
+		Delete: o.delete,
+		Importer: &schema.ResourceImporter{
+			State: o.importState,
+		},
+		Schema: s,
+	}
+}
+
+// path returns the collection path, in the editable version for versioned objects
+func (o *jsonObject) path(d *schema.ResourceData, configID int, editable bool) (string, error) {
+	if !o.versioned {
+		return configLocation(configID) + "/" + o.collection, nil
+	}
+
+	var version int
+	if editable {
+		log := akamai.ContextGet(inst.Name()).Log("APPSEC", o.attribute, "editableVersion")
+		v, err := editableVersion(log, configID)
+		if err != nil {
+			return "", err
+		}
+		version = v
+	} else {
+		c, err := getConfiguration(configID)
+		if err != nil {
+			return "", err
+		}
+		version = c.LatestVersion
+	}
+
+	if err := d.Set("version", version); err != nil {
+		return "", err
+	}
+	return versionLocation(configID, version) + "/" + o.collection, nil
+}
+
+func (o *jsonObject) create(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", o.attribute, "create")
+
+	configID := d.Get("config_id").(int)
+	body, err := normalizeJSON(d.Get(o.attribute).(string))
+	if err != nil {
+		return err
+	}
+
+	path, err := o.path(d, configID, true)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	log.Debug(fmt.Sprintf("creating %s in configuration %d", o.name, configID))
+	var res map[string]interface{}
+	if err := api.DoRequest(http.MethodPost, path, body, &res); err != nil {
+		log.Error(fmt.Sprintf("%s create failed: %s", o.name, err))
+		return apiclient.Error(err)
+	}
+
+	id, ok := res[o.idField].(float64)
+	if !ok {
+		return fmt.Errorf("%s create did not return %q", o.name, o.idField)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%d", configID, int(id)))
+	return o.read(d, meta)
+}
+
+func (o *jsonObject) read(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", o.attribute, "read")
+
+	configID, id, err := parseObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	path, err := o.path(d, configID, false)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	var res map[string]interface{}
+	if err := api.DoRequest(http.MethodGet, fmt.Sprintf("%s/%d", path, id), nil, &res); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("%s %s not found, removing from state", o.name, d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	state, err := stateJSON(res)
+	if err != nil {
+		return err
+	}
+
+	if err := d.Set("config_id", configID); err != nil {
+		return err
+	}
+	if err := d.Set(o.idAttribute, id); err != nil {
+		return err
+	}
+	return d.Set(o.attribute, state)
+}
+
+func (o *jsonObject) update(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", o.attribute, "update")
+
+	configID, id, err := parseObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	body, err := normalizeJSON(d.Get(o.attribute).(string))
+	if err != nil {
+		return err
+	}
+
+	path, err := o.path(d, configID, true)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	log.Debug(fmt.Sprintf("updating %s %s", o.name, d.Id()))
+	if err := api.DoRequest(http.MethodPut, fmt.Sprintf("%s/%d", path, id), body, nil); err != nil {
+		log.Error(fmt.Sprintf("%s update failed: %s", o.name, err))
+		return apiclient.Error(err)
+	}
+
+	return o.read(d, meta)
+}
+
+func (o *jsonObject) delete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", o.attribute, "delete")
+
+	configID, id, err := parseObjectID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	path, err := o.path(d, configID, true)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	log.Debug(fmt.Sprintf("deleting %s %s", o.name, d.Id()))
+	if err := api.DoRequest(http.MethodDelete, fmt.Sprintf("%s/%d", path, id), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("%s delete failed: %s", o.name, err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<config_id>:<object_id>"
+func (o *jsonObject) importState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseObjectID(d.Id()); err != nil {
+		return nil, err
+	}
+	if err := o.read(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("%s not found", o.name)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseObjectID splits a "<config_id>:<object_id>" resource ID
+func parseObjectID(id string) (int, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid ID %q, expected <config_id>:<id>", id)
+	}
+	configID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ID %q, expected <config_id>:<id>", id)
+	}
+	objectID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid ID %q, expected <config_id>:<id>", id)
+	}
+	return configID, objectID, nil
+}
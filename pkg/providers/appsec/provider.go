@@ -0,0 +1,144 @@
+package appsec
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"appsec_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("appsec_section"),
+			},
+			"appsec": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("appsec"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_appsec_activations":   resourceActivations(),
+			"akamai_appsec_configuration": resourceConfiguration(),
+			"akamai_appsec_custom_rule":   resourceCustomRule(),
+			"akamai_appsec_match_target":  resourceMatchTarget(),
+			"akamai_appsec_rate_policy":   resourceRatePolicy(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigAppSecService(d resourceData) (*edgegrid.Config, error) {
+	var AppSecConfig edgegrid.Config
+	if _, ok := d.GetOk("appsec"); ok {
+		log.Printf("[DEBUG] Setting appsec config via HCL")
+		config := d.Get("appsec").(set).List()[0].(map[string]interface{})
+
+		AppSecConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(AppSecConfig)
+		return &AppSecConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("appsec_section"); ok && section != "default" {
+		AppSecConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		AppSecConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(AppSecConfig)
+	return &AppSecConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "appsec"
+}
+
+// AppSecProviderVersion update version string anytime provider adds new features
+const AppSecProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return AppSecProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigAppSecService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
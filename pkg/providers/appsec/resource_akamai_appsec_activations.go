@@ -0,0 +1,152 @@
+package appsec
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// AppSec Activations
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html#activation
+func resourceActivations() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceActivationsCreate,
+		Read:   resourceActivationsRead,
+		Update: resourceActivationsUpdate,
+		Delete: resourceActivationsDelete,
+		Schema: map[string]*schema.Schema{
+			"config_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      apiclient.NetworkStaging,
+				StateFunc:    upperStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{apiclient.NetworkStaging, apiclient.NetworkProduction}, true),
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Activated by Terraform",
+			},
+			"notification_emails": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"activate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceActivationsCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceActivationsCreate")
+
+	if !d.Get("activate").(bool) {
+		d.SetId("none")
+		return nil
+	}
+
+	a, err := activate(log, d, actionActivate, apiclient.StatusActivated)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(strconv.Itoa(a.ActivationID))
+	return d.Set("status", a.Status)
+}
+
+func resourceActivationsRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceActivationsRead")
+
+	if d.Id() == "none" {
+		return nil
+	}
+
+	var a activation
+	if err := api.DoRequest(http.MethodGet, "/appsec/v1/activations/"+d.Id(), nil, &a); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("activation %s not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	return d.Set("status", a.Status)
+}
+
+func resourceActivationsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChanges("version", "activate") {
+		return resourceActivationsCreate(d, meta)
+	}
+	return resourceActivationsRead(d, meta)
+}
+
+func resourceActivationsDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceActivationsDelete")
+
+	if d.Id() != "none" && d.Get("activate").(bool) {
+		if _, err := activate(log, d, actionDeactivate, apiclient.StatusDeactivated); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// activate starts an activation or deactivation of the configuration version and waits for it
+func activate(log hclog.Logger, d *schema.ResourceData, action, target string) (*activation, error) {
+	configID := d.Get("config_id").(int)
+	version := d.Get("version").(int)
+	network := strings.ToUpper(d.Get("network").(string))
+
+	req := activationRequest{
+		Action:             action,
+		Network:            network,
+		Note:               d.Get("notes").(string),
+		NotificationEmails: expandStringSet(d.Get("notification_emails")),
+		ActivationConfigs:  []activationConfig{{ConfigID: configID, ConfigVersion: version}},
+	}
+
+	log.Debug(fmt.Sprintf("%s configuration %d version %d on %s", strings.ToLower(action), configID, version, network))
+	var a activation
+	if err := api.DoRequest(http.MethodPost, "/appsec/v1/activations", req, &a); err != nil {
+		log.Error(fmt.Sprintf("%s failed: %s", strings.ToLower(action), err))
+		return nil, apiclient.Error(err)
+	}
+
+	return waitForActivation(log, a.ActivationID, target)
+}
+
+func upperStateFunc(v interface{}) string {
+	return strings.ToUpper(v.(string))
+}
@@ -0,0 +1,51 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceActivations(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeAppSec(srv)
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	testConfiguration(t)
+
+	d := schema.TestResourceDataRaw(t, resourceActivations().Schema, map[string]interface{}{
+		"config_id":           101,
+		"version":             1,
+		"network":             "production",
+		"notification_emails": []interface{}{"noreply@example.com"},
+	})
+	require.NoError(t, resourceActivationsCreate(d, nil))
+	assert.Equal(t, "102", d.Id())
+	assert.Equal(t, apiclient.StatusActivated, d.Get("status"))
+	assert.Equal(t, 1, fake.configs[101].ProductionVersion)
+	assert.Equal(t, apiclient.NetworkProduction, fake.activations[102].Network)
+
+	require.NoError(t, resourceActivationsRead(d, nil))
+	assert.Equal(t, apiclient.StatusActivated, d.Get("status"))
+
+	require.NoError(t, resourceActivationsDelete(d, nil))
+	assert.Equal(t, 0, fake.configs[101].ProductionVersion)
+	assert.Equal(t, actionDeactivate, fake.activations[103].Action)
+
+	inactive := schema.TestResourceDataRaw(t, resourceActivations().Schema, map[string]interface{}{
+		"config_id":           101,
+		"version":             1,
+		"activate":            false,
+		"notification_emails": []interface{}{"noreply@example.com"},
+	})
+	require.NoError(t, resourceActivationsCreate(inactive, nil))
+	assert.Equal(t, "none", inactive.Id())
+	assert.Len(t, fake.activations, 2)
+}
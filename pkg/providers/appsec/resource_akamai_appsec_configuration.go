@@ -0,0 +1,218 @@
+package appsec
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// AppSec Security Configuration
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html#configuration
+func resourceConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceConfigurationCreate,
+		Read:   resourceConfigurationRead,
+		Update: resourceConfigurationUpdate,
+		Delete: resourceConfigurationDelete,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("ctr_"),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("grp_"),
+			},
+			"hostnames": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"config_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"latest_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"staging_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"production_version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceConfigurationCreate")
+
+	group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+	if err != nil {
+		return fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+	}
+
+	req := createConfigurationRequest{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		ContractID:  strings.TrimPrefix(d.Get("contract_id").(string), "ctr_"),
+		GroupID:     group,
+		Hostnames:   expandStringSet(d.Get("hostnames")),
+	}
+
+	log.Debug(fmt.Sprintf("creating security configuration %q", req.Name))
+	var res createConfigurationResponse
+	if err := api.DoRequest(http.MethodPost, "/appsec/v1/configs", req, &res); err != nil {
+		log.Error(fmt.Sprintf("configuration create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(strconv.Itoa(res.ConfigID))
+	return resourceConfigurationRead(d, meta)
+}
+
+func resourceConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceConfigurationRead")
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid configuration ID %q", d.Id())
+	}
+
+	c, err := getConfiguration(configID)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("configuration %d not found, removing from state", configID))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	var hostnames selectedHostnames
+	if err := api.DoRequest(http.MethodGet, versionLocation(configID, c.LatestVersion)+"/selected-hostnames", nil, &hostnames); err != nil {
+		return apiclient.Error(err)
+	}
+	names := make([]string, 0, len(hostnames.HostnameList))
+	for _, h := range hostnames.HostnameList {
+		names = append(names, h.Hostname)
+	}
+
+	attrs := map[string]interface{}{
+		"name":               c.Name,
+		"description":        c.Description,
+		"hostnames":          names,
+		"config_id":          c.ID,
+		"latest_version":     c.LatestVersion,
+		"staging_version":    c.StagingVersion,
+		"production_version": c.ProductionVersion,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceConfigurationUpdate")
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid configuration ID %q", d.Id())
+	}
+
+	if d.HasChanges("name", "description") {
+		body := map[string]string{
+			"name":        d.Get("name").(string),
+			"description": d.Get("description").(string),
+		}
+		if err := api.DoRequest(http.MethodPut, configLocation(configID), body, nil); err != nil {
+			log.Error(fmt.Sprintf("configuration update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	if d.HasChange("hostnames") {
+		version, err := editableVersion(log, configID)
+		if err != nil {
+			return apiclient.Error(err)
+		}
+
+		var body selectedHostnames
+		for _, h := range expandStringSet(d.Get("hostnames")) {
+			body.HostnameList = append(body.HostnameList, struct {
+				Hostname string `json:"hostname"`
+			}{h})
+		}
+		log.Debug(fmt.Sprintf("updating hostnames of configuration %d version %d", configID, version))
+		if err := api.DoRequest(http.MethodPut, versionLocation(configID, version)+"/selected-hostnames", body, nil); err != nil {
+			return apiclient.Error(err)
+		}
+	}
+
+	return resourceConfigurationRead(d, meta)
+}
+
+func resourceConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("APPSEC", "resourceConfigurationDelete")
+
+	configID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid configuration ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting configuration %d", configID))
+	if err := api.DoRequest(http.MethodDelete, configLocation(configID), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("configuration delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandStringSet(v interface{}) []string {
+	out := make([]string, 0)
+	if s, ok := v.(*schema.Set); ok {
+		for _, e := range s.List() {
+			out = append(out, e.(string))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
@@ -0,0 +1,470 @@
+package appsec
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiAppSecConfiguration_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAkamaiAppSecConfigurationConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akamai_appsec_configuration.test", "config_id"),
+					resource.TestCheckResourceAttrSet("akamai_appsec_match_target.test", "target_id"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAkamaiAppSecConfigurationConfig = `
+provider "akamai" {
+  appsec_section = "appsec"
+}
+
+data "akamai_contract" "contract" {
+}
+
+data "akamai_group" "group" {
+}
+
+resource "akamai_appsec_configuration" "test" {
+  name = "terraform-test"
+  contract_id = data.akamai_contract.contract.id
+  group_id = data.akamai_group.group.id
+  hostnames = ["terraform-test.example.com"]
+}
+
+resource "akamai_appsec_match_target" "test" {
+  config_id = akamai_appsec_configuration.test.config_id
+  match_target = jsonencode({
+    type = "website"
+    hostnames = ["terraform-test.example.com"]
+    filePaths = ["/*"]
+  })
+}
+`
+
+// fakeAppSec serves the AppSec endpoints used by the provider from the mock server.
+// Objects are stored by collection path, and active versions reject writes.
+type fakeAppSec struct {
+	lock        sync.Mutex
+	configs     map[int]*configuration
+	hostnames   map[string][]string
+	objects     map[string]map[int]map[string]interface{}
+	activations map[int]*fakeActivation
+	nextID      int
+}
+
+type fakeActivation struct {
+	activation
+	configID int
+	version  int
+	polled   bool
+}
+
+const collectionPattern = `(/appsec/v1/configs/(\d+)(?:/versions/(\d+))?/(?:match-targets|custom-rules|rate-policies))`
+
+func newFakeAppSec(srv *mockapi.Server) *fakeAppSec {
+	f := &fakeAppSec{
+		configs:     make(map[int]*configuration),
+		hostnames:   make(map[string][]string),
+		objects:     make(map[string]map[int]map[string]interface{}),
+		activations: make(map[int]*fakeActivation),
+		nextID:      100,
+	}
+
+	srv.Handle("POST", `/appsec/v1/configs`, f.createConfig)
+	srv.Handle("GET", `/appsec/v1/configs/(\d+)`, f.getConfig)
+	srv.Handle("PUT", `/appsec/v1/configs/(\d+)`, f.updateConfig)
+	srv.Handle("DELETE", `/appsec/v1/configs/(\d+)`, f.deleteConfig)
+	srv.Handle("POST", `/appsec/v1/configs/(\d+)/versions`, f.cloneVersion)
+	srv.Handle("GET", `/appsec/v1/configs/(\d+)/versions/(\d+)/selected-hostnames`, f.getHostnames)
+	srv.Handle("PUT", `/appsec/v1/configs/(\d+)/versions/(\d+)/selected-hostnames`, f.putHostnames)
+	srv.Handle("POST", collectionPattern, f.createObject)
+	srv.Handle("GET", collectionPattern+`/(\d+)`, f.getObject)
+	srv.Handle("PUT", collectionPattern+`/(\d+)`, f.updateObject)
+	srv.Handle("DELETE", collectionPattern+`/(\d+)`, f.deleteObject)
+	srv.Handle("POST", `/appsec/v1/activations`, f.activate)
+	srv.Handle("GET", `/appsec/v1/activations/(\d+)`, f.getActivation)
+
+	return f
+}
+
+// editable reports whether the version of a config can be written
+func (f *fakeAppSec) editable(configID, version string) bool {
+	id, _ := strconv.Atoi(configID)
+	v, _ := strconv.Atoi(version)
+	c, ok := f.configs[id]
+	return ok && v <= c.LatestVersion && v != c.StagingVersion && v != c.ProductionVersion
+}
+
+func (f *fakeAppSec) createConfig(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var req createConfigurationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if req.ContractID == "" || req.GroupID == 0 || len(req.Hostnames) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.nextID++
+	f.configs[f.nextID] = &configuration{ID: f.nextID, Name: req.Name, Description: req.Description, LatestVersion: 1}
+	f.hostnames[versionLocation(f.nextID, 1)] = req.Hostnames
+	mockapi.WriteJSON(w, http.StatusCreated, createConfigurationResponse{ConfigID: f.nextID, Version: 1})
+}
+
+func (f *fakeAppSec) getConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	c, ok := f.configs[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, c)
+}
+
+func (f *fakeAppSec) updateConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	c, ok := f.configs[id]
+	var body configuration
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+	c.Name, c.Description = body.Name, body.Description
+	mockapi.WriteJSON(w, http.StatusOK, c)
+}
+
+func (f *fakeAppSec) deleteConfig(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	delete(f.configs, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAppSec) cloneVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	c, ok := f.configs[id]
+	var body struct {
+		CreateFromVersion int `json:"createFromVersion"`
+	}
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+
+	c.LatestVersion++
+	from, to := versionLocation(id, body.CreateFromVersion), versionLocation(id, c.LatestVersion)
+	f.hostnames[to] = f.hostnames[from]
+	for path, objects := range f.objects {
+		if strings.HasPrefix(path, from+"/") {
+			clone := make(map[int]map[string]interface{})
+			for k, v := range objects {
+				clone[k] = v
+			}
+			f.objects[to+strings.TrimPrefix(path, from)] = clone
+		}
+	}
+	mockapi.WriteJSON(w, http.StatusOK, configurationVersion{ConfigID: id, Version: c.LatestVersion})
+}
+
+func (f *fakeAppSec) getHostnames(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var res selectedHostnames
+	for _, h := range f.hostnames[strings.TrimSuffix(r.URL.Path, "/selected-hostnames")] {
+		res.HostnameList = append(res.HostnameList, struct {
+			Hostname string `json:"hostname"`
+		}{h})
+	}
+	mockapi.WriteJSON(w, http.StatusOK, res)
+}
+
+func (f *fakeAppSec) putHostnames(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var body selectedHostnames
+	if !f.editable(params[0], params[1]) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+	names := make([]string, 0)
+	for _, h := range body.HostnameList {
+		names = append(names, h.Hostname)
+	}
+	f.hostnames[strings.TrimSuffix(r.URL.Path, "/selected-hostnames")] = names
+	mockapi.WriteJSON(w, http.StatusOK, body)
+}
+
+func (f *fakeAppSec) idField(collection string) string {
+	if strings.HasSuffix(collection, "/match-targets") {
+		return "targetId"
+	}
+	return "id"
+}
+
+func (f *fakeAppSec) createObject(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	body := make(map[string]interface{})
+	if params[2] != "" && !f.editable(params[1], params[2]) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+
+	f.nextID++
+	body[f.idField(params[0])] = f.nextID
+	body["configId"], _ = strconv.Atoi(params[1])
+	if f.objects[params[0]] == nil {
+		f.objects[params[0]] = make(map[int]map[string]interface{})
+	}
+	f.objects[params[0]][f.nextID] = body
+	mockapi.WriteJSON(w, http.StatusCreated, body)
+}
+
+func (f *fakeAppSec) getObject(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[3])
+	obj, ok := f.objects[params[0]][id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, obj)
+}
+
+func (f *fakeAppSec) updateObject(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[3])
+	body := make(map[string]interface{})
+	if _, ok := f.objects[params[0]][id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if params[2] != "" && !f.editable(params[1], params[2]) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+	body[f.idField(params[0])] = id
+	f.objects[params[0]][id] = body
+	mockapi.WriteJSON(w, http.StatusOK, body)
+}
+
+func (f *fakeAppSec) deleteObject(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[3])
+	if _, ok := f.objects[params[0]][id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if params[2] != "" && !f.editable(params[1], params[2]) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	delete(f.objects[params[0]], id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeAppSec) activate(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var req activationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if len(req.ActivationConfigs) != 1 || len(req.NotificationEmails) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if _, ok := f.configs[req.ActivationConfigs[0].ConfigID]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.nextID++
+	a := &fakeActivation{
+		activation: activation{ActivationID: f.nextID, Action: req.Action, Network: req.Network, Status: "RECEIVED"},
+		configID:   req.ActivationConfigs[0].ConfigID,
+		version:    req.ActivationConfigs[0].ConfigVersion,
+	}
+	f.activations[a.ActivationID] = a
+	mockapi.WriteJSON(w, http.StatusOK, a.activation)
+}
+
+// getActivation completes the activation the first time it is polled
+func (f *fakeAppSec) getActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	a, ok := f.activations[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !a.polled {
+		a.polled = true
+		version := a.version
+		a.Status = apiclient.StatusActivated
+		if a.Action == actionDeactivate {
+			version = 0
+			a.Status = apiclient.StatusDeactivated
+		}
+		if c, ok := f.configs[a.configID]; ok {
+			if a.Network == apiclient.NetworkProduction {
+				c.ProductionVersion = version
+			} else {
+				c.StagingVersion = version
+			}
+		}
+	}
+	mockapi.WriteJSON(w, http.StatusOK, a.activation)
+}
+
+func testConfiguration(t *testing.T) *schema.ResourceData {
+	d := schema.TestResourceDataRaw(t, resourceConfiguration().Schema, map[string]interface{}{
+		"name":        "security",
+		"description": "WAF",
+		"contract_id": "ctr_1-MOCK",
+		"group_id":    "grp_1",
+		"hostnames":   []interface{}{"www.example.com"},
+	})
+	require.NoError(t, resourceConfigurationCreate(d, nil))
+	return d
+}
+
+func TestResourceConfiguration(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeAppSec(srv)
+	api.Init(srv.Config())
+
+	d := testConfiguration(t)
+	assert.Equal(t, "101", d.Id())
+	assert.Equal(t, 101, d.Get("config_id"))
+	assert.Equal(t, 1, d.Get("latest_version"))
+
+	// the latest version is editable until activated
+	d.Set("hostnames", []interface{}{"www.example.com", "api.example.com"})
+	require.NoError(t, resourceConfigurationUpdate(d, nil))
+	assert.Equal(t, 1, d.Get("latest_version"))
+	assert.Equal(t, 2, d.Get("hostnames").(*schema.Set).Len())
+
+	fake.configs[101].StagingVersion = 1
+	d.Set("name", "renamed")
+	d.Set("hostnames", []interface{}{"api.example.com"})
+	require.NoError(t, resourceConfigurationUpdate(d, nil))
+	assert.Equal(t, "renamed", d.Get("name"))
+	assert.Equal(t, 2, d.Get("latest_version"))
+	assert.Equal(t, 1, d.Get("staging_version"))
+	assert.Equal(t, []string{"api.example.com", "www.example.com"}, sorted(fake.hostnames[versionLocation(101, 1)]))
+	assert.Equal(t, []string{"api.example.com"}, fake.hostnames[versionLocation(101, 2)])
+
+	require.NoError(t, resourceConfigurationDelete(d, nil))
+	assert.Empty(t, fake.configs)
+
+	d.SetId("101")
+	require.NoError(t, resourceConfigurationRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func sorted(in []string) []string {
+	out := append([]string{}, in...)
+	sort.Strings(out)
+	return out
+}
+
+func TestSuppressEquivalentJSONDiffs(t *testing.T) {
+	tests := map[string]struct {
+		old, new string
+		expected bool
+	}{
+		"formatting": {
+			old:      `{"type":"website","filePaths":["/*"]}`,
+			new:      "{\n  \"filePaths\": [\"/*\"],\n  \"type\": \"website\"\n}",
+			expected: true,
+		},
+		"server fields": {
+			old:      `{"type":"website","targetId":3,"configId":1,"configVersion":2,"sequence":1}`,
+			new:      `{"type":"website"}`,
+			expected: true,
+		},
+		"changed value": {
+			old: `{"type":"website","filePaths":["/*"]}`,
+			new: `{"type":"website","filePaths":["/api/*"]}`,
+		},
+		"array order": {
+			old: `{"hostnames":["a.example.com","b.example.com"]}`,
+			new: `{"hostnames":["b.example.com","a.example.com"]}`,
+		},
+		"invalid": {
+			old: `{"type":"website"}`,
+			new: `{"type":`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, suppressEquivalentJSONDiffs("", test.old, test.new, nil), fmt.Sprintf("%s vs %s", test.old, test.new))
+		})
+	}
+}
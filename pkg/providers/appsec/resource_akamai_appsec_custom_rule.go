@@ -0,0 +1,17 @@
+package appsec
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// AppSec Custom Rule
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html#customrule
+func resourceCustomRule() *schema.Resource {
+	return (&jsonObject{
+		name:        "custom rule",
+		attribute:   "custom_rule",
+		idAttribute: "rule_id",
+		idField:     "id",
+		collection:  "custom-rules",
+		versioned:   false,
+	}).resource()
+}
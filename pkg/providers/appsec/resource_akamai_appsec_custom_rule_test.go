@@ -0,0 +1,42 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCustomRule(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeAppSec(srv)
+	api.Init(srv.Config())
+
+	testConfiguration(t)
+	fake.configs[101].StagingVersion = 1
+
+	// custom rules are not versioned, activations don't affect them
+	d := schema.TestResourceDataRaw(t, resourceCustomRule().Schema, map[string]interface{}{
+		"config_id":   101,
+		"custom_rule": `{"name": "block-admin", "conditions": [{"type": "pathMatch", "value": ["/admin"]}]}`,
+	})
+	require.NoError(t, resourceCustomRule().Create(d, nil))
+	assert.Equal(t, "101:102", d.Id())
+	assert.Equal(t, 102, d.Get("rule_id"))
+	assert.Contains(t, fake.objects, configLocation(101)+"/custom-rules")
+
+	d.Set("custom_rule", `{"name": "block-admin", "conditions": [{"type": "pathMatch", "value": ["/admin", "/wp-admin"]}]}`)
+	require.NoError(t, resourceCustomRule().Update(d, nil))
+	assert.Equal(t, 1, fake.configs[101].LatestVersion)
+
+	require.NoError(t, resourceCustomRule().Delete(d, nil))
+	assert.Empty(t, fake.objects[configLocation(101)+"/custom-rules"])
+
+	d.SetId("101:102")
+	require.NoError(t, resourceCustomRule().Read(d, nil))
+	assert.Equal(t, "", d.Id())
+}
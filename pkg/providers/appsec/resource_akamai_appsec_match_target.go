@@ -0,0 +1,17 @@
+package appsec
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// AppSec Match Target
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html#matchtarget
+func resourceMatchTarget() *schema.Resource {
+	return (&jsonObject{
+		name:        "match target",
+		attribute:   "match_target",
+		idAttribute: "target_id",
+		idField:     "targetId",
+		collection:  "match-targets",
+		versioned:   true,
+	}).resource()
+}
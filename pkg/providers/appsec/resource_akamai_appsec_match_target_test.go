@@ -0,0 +1,54 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceMatchTarget(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeAppSec(srv)
+	api.Init(srv.Config())
+
+	testConfiguration(t)
+
+	d := schema.TestResourceDataRaw(t, resourceMatchTarget().Schema, map[string]interface{}{
+		"config_id":    101,
+		"match_target": `{"type": "website", "hostnames": ["www.example.com"], "filePaths": ["/*"]}`,
+	})
+	require.NoError(t, resourceMatchTarget().Create(d, nil))
+
+	assert.Equal(t, "101:102", d.Id())
+	assert.Equal(t, 102, d.Get("target_id"))
+	assert.Equal(t, 1, d.Get("version"))
+	assert.Equal(t, `{"filePaths":["/*"],"hostnames":["www.example.com"],"type":"website"}`, d.Get("match_target"))
+
+	// writing after an activation goes to a new version
+	fake.configs[101].ProductionVersion = 1
+	d.Set("match_target", `{"type": "website", "hostnames": ["www.example.com"], "filePaths": ["/api/*"]}`)
+	require.NoError(t, resourceMatchTarget().Update(d, nil))
+	assert.Equal(t, 2, d.Get("version"))
+	assert.Equal(t, []interface{}{"/*"}, fake.objects[versionLocation(101, 1)+"/match-targets"][102]["filePaths"])
+	assert.Equal(t, []interface{}{"/api/*"}, fake.objects[versionLocation(101, 2)+"/match-targets"][102]["filePaths"])
+
+	imported := schema.TestResourceDataRaw(t, resourceMatchTarget().Schema, map[string]interface{}{})
+	imported.SetId("101:102")
+	_, err := resourceMatchTarget().Importer.State(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 101, imported.Get("config_id"))
+	assert.Equal(t, d.Get("match_target"), imported.Get("match_target"))
+
+	require.NoError(t, resourceMatchTarget().Delete(d, nil))
+	assert.Empty(t, fake.objects[versionLocation(101, 2)+"/match-targets"])
+
+	bad := schema.TestResourceDataRaw(t, resourceMatchTarget().Schema, map[string]interface{}{})
+	bad.SetId("101")
+	_, err = resourceMatchTarget().Importer.State(bad, nil)
+	assert.Error(t, err)
+}
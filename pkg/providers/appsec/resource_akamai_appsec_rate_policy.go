@@ -0,0 +1,17 @@
+package appsec
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+// AppSec Rate Policy
+//
+// https://developer.akamai.com/api/cloud_security/application_security/v1.html#ratepolicy
+func resourceRatePolicy() *schema.Resource {
+	return (&jsonObject{
+		name:        "rate policy",
+		attribute:   "rate_policy",
+		idAttribute: "policy_id",
+		idField:     "id",
+		collection:  "rate-policies",
+		versioned:   true,
+	}).resource()
+}
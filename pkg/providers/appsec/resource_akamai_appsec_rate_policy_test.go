@@ -0,0 +1,34 @@
+package appsec
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRatePolicy(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeAppSec(srv)
+	api.Init(srv.Config())
+
+	testConfiguration(t)
+
+	d := schema.TestResourceDataRaw(t, resourceRatePolicy().Schema, map[string]interface{}{
+		"config_id":   101,
+		"rate_policy": `{"name": "login", "averageThreshold": 5, "burstThreshold": 10, "matchType": "path"}`,
+	})
+	require.NoError(t, resourceRatePolicy().Create(d, nil))
+	assert.Equal(t, "101:102", d.Id())
+	assert.Equal(t, 102, d.Get("policy_id"))
+	assert.Contains(t, fake.objects[versionLocation(101, 1)+"/rate-policies"], 102)
+
+	// removed outside of terraform
+	delete(fake.objects[versionLocation(101, 1)+"/rate-policies"], 102)
+	require.NoError(t, resourceRatePolicy().Read(d, nil))
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,228 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The Cloudlets API names networks and activation statuses in lower case, unlike the
+// shared apiclient ones
+const (
+	networkStaging    = "staging"
+	networkProduction = "prod"
+
+	// activation statuses
+	statusActive  = "active"
+	statusPending = "pending"
+	statusFailed  = "failed"
+)
+
+var (
+	// api sends the requests to the Cloudlets API
+	api apiclient.Client
+
+	// activationPollInterval and activationPollTimeout bound waitForActivation
+	activationPollInterval = 30 * time.Second
+	activationPollTimeout  = 60 * time.Minute
+
+	// cloudletIDs are the cloudlets supported by the provider by code
+	cloudletIDs = map[string]int{
+		"ER": 0, // Edge Redirector
+		"FR": 3, // Forward Rewrite
+	}
+)
+
+type (
+	policy struct {
+		PolicyID     int          `json:"policyId,omitempty"`
+		GroupID      int          `json:"groupId"`
+		Name         string       `json:"name"`
+		Description  string       `json:"description,omitempty"`
+		CloudletID   int          `json:"cloudletId"`
+		CloudletCode string       `json:"cloudletCode,omitempty"`
+		Activations  []activation `json:"activations,omitempty"`
+	}
+
+	policyVersion struct {
+		PolicyID        int             `json:"policyId,omitempty"`
+		Version         int             `json:"version,omitempty"`
+		Description     string          `json:"description,omitempty"`
+		MatchRuleFormat string          `json:"matchRuleFormat,omitempty"`
+		MatchRules      json.RawMessage `json:"matchRules"`
+		Activations     []activation    `json:"activations,omitempty"`
+	}
+
+	policyInfo struct {
+		PolicyID     int    `json:"policyId"`
+		Version      int    `json:"version"`
+		Status       string `json:"status"`
+		StatusDetail string `json:"statusDetail,omitempty"`
+	}
+
+	propertyInfo struct {
+		Name    string `json:"name"`
+		Version int    `json:"version"`
+		GroupID int    `json:"groupId"`
+		Status  string `json:"status"`
+	}
+
+	activation struct {
+		Network      string       `json:"network"`
+		PolicyInfo   policyInfo   `json:"policyInfo"`
+		PropertyInfo propertyInfo `json:"propertyInfo"`
+	}
+
+	activationRequest struct {
+		Network string `json:"network"`
+	}
+
+	propertyVersion struct {
+		Version int `json:"version"`
+	}
+
+	// associatedProperty is a property whose rules reference the policy
+	associatedProperty struct {
+		Name       string           `json:"name"`
+		GroupID    int              `json:"groupId"`
+		Staging    *propertyVersion `json:"staging"`
+		Production *propertyVersion `json:"production"`
+	}
+)
+
+func policyLocation(policyID int) string {
+	return fmt.Sprintf("/cloudlets/api/v2/policies/%d", policyID)
+}
+
+func versionLocation(policyID, version int) string {
+	return fmt.Sprintf("/cloudlets/api/v2/policies/%d/versions/%d", policyID, version)
+}
+
+// latestVersion returns the latest version of the policy with its match rules
+func latestVersion(policyID int) (*policyVersion, error) {
+	var versions []policyVersion
+	if err := api.DoRequest(http.MethodGet, policyLocation(policyID)+"/versions?includeRules=false", nil, &versions); err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+
+	latest := versions[0].Version
+	for _, v := range versions {
+		if v.Version > latest {
+			latest = v.Version
+		}
+	}
+
+	var v policyVersion
+	if err := api.DoRequest(http.MethodGet, versionLocation(policyID, latest)+"?omitRules=false", nil, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// isLocked reports whether the version has been activated, after which it is read only
+func (v *policyVersion) isLocked() bool {
+	for _, a := range v.Activations {
+		if a.PolicyInfo.Status == statusActive || a.PolicyInfo.Status == statusPending {
+			return true
+		}
+	}
+	return false
+}
+
+// associatedProperties returns the properties referencing the policy by name
+func associatedProperties(policyID int) (map[string]associatedProperty, error) {
+	properties := make(map[string]associatedProperty)
+	if err := api.DoRequest(http.MethodGet, policyLocation(policyID)+"/properties", nil, &properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// waitForActivation polls the activations of the policy version on the network until
+// they are all active or one fails
+func waitForActivation(log hclog.Logger, policyID, version int, network string) ([]activation, error) {
+	var activations []activation
+	err := apiclient.WaitForActivation(activationPollInterval, activationPollTimeout, func() (bool, error) {
+		activations = nil
+		path := fmt.Sprintf("%s/activations?network=%s", policyLocation(policyID), network)
+		if err := api.DoRequest(http.MethodGet, path, nil, &activations); err != nil {
+			return false, err
+		}
+
+		var found, pending bool
+		for _, a := range activations {
+			if a.PolicyInfo.Version != version {
+				continue
+			}
+			found = true
+			log.Debug(fmt.Sprintf("policy %d version %d on %s for property %s status [%s]", policyID, version, network, a.PropertyInfo.Name, a.PolicyInfo.Status))
+			switch a.PolicyInfo.Status {
+			case statusFailed:
+				return true, fmt.Errorf("%w: cloudlets policy %d version %d on %s: %s", apiclient.ErrActivationFailed, policyID, version, network, a.PolicyInfo.StatusDetail)
+			case statusActive:
+			default:
+				pending = true
+			}
+		}
+		return found && !pending, nil
+	})
+	if errors.Is(err, apiclient.ErrActivationTimeout) {
+		return activations, fmt.Errorf("%w: cloudlets policy %d version %d on %s", err, policyID, version, network)
+	}
+	return activations, err
+}
+
+// serverFields are assigned by the API to match rules and never part of the configured JSON
+var serverFields = []string{"location", "akaRuleId"}
+
+// normalizeMatchRules decodes a JSON array of match rules and drops the fields assigned by the API
+func normalizeMatchRules(data string) ([]interface{}, error) {
+	rules := make([]interface{}, 0)
+	if data == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(data), &rules); err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("match rules must be JSON objects")
+		}
+		for _, f := range serverFields {
+			delete(rule, f)
+		}
+	}
+	return rules, nil
+}
+
+// suppressEquivalentMatchRulesDiffs ignores formatting, key order and API assigned fields
+func suppressEquivalentMatchRulesDiffs(k, old, new string, d *schema.ResourceData) bool {
+	o, err := normalizeMatchRules(old)
+	if err != nil {
+		return false
+	}
+	n, err := normalizeMatchRules(new)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(o, n)
+}
+
+// validateMatchRules checks the value is a JSON array of match rules
+func validateMatchRules(v interface{}, k string) ([]string, []error) {
+	if _, err := normalizeMatchRules(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON array of match rules: %s", k, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,9 @@
+// +build all cloudlets
+
+package cloudlets
+
+import "github.com/akamai/terraform-provider-akamai/v2/pkg/providers/registry"
+
+func init() {
+	registry.RegisterProvider(Subprovider())
+}
@@ -0,0 +1,52 @@
+package cloudlets
+
+import (
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Edge Redirector match rules as JSON
+//
+// https://developer.akamai.com/api/web_performance/cloudlets/v2.html#matchrule
+func dataSourceEdgeRedirectorMatchRule() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEdgeRedirectorMatchRuleRead,
+		Schema: matchRuleSchema(map[string]*schema.Schema{
+			"redirect_url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"status_code": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntInSlice([]int{301, 302, 303, 307, 308}),
+			},
+			"use_incoming_query_string": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"use_relative_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				ValidateFunc: validation.StringInSlice([]string{"none", "copy_scheme_hostname", "relative_url"}, false),
+			},
+		}),
+	}
+}
+
+func dataSourceEdgeRedirectorMatchRuleRead(d *schema.ResourceData, meta interface{}) error {
+	data, err := buildMatchRules(d, "erMatchRule", func(block, rule map[string]interface{}) {
+		rule["redirectURL"] = block["redirect_url"]
+		rule["statusCode"] = block["status_code"]
+		rule["useIncomingQueryString"] = block["use_incoming_query_string"]
+		rule["useRelativeUrl"] = block["use_relative_url"]
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(tools.GetSHAString(data))
+	return d.Set("json", data)
+}
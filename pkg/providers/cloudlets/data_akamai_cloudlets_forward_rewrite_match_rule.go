@@ -0,0 +1,63 @@
+package cloudlets
+
+import (
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Forward Rewrite match rules as JSON
+//
+// https://developer.akamai.com/api/web_performance/cloudlets/v2.html#matchrule
+func dataSourceForwardRewriteMatchRule() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceForwardRewriteMatchRuleRead,
+		Schema: matchRuleSchema(map[string]*schema.Schema{
+			"forward_settings": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"origin_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The origin to forward to, defaults to the origin of the property",
+						},
+						"path_and_qs": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The path and query string to forward to, defaults to the incoming ones",
+						},
+						"use_incoming_query_string": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceForwardRewriteMatchRuleRead(d *schema.ResourceData, meta interface{}) error {
+	data, err := buildMatchRules(d, "frMatchRule", func(block, rule map[string]interface{}) {
+		settings := make(map[string]interface{})
+		if list := block["forward_settings"].([]interface{}); len(list) > 0 && list[0] != nil {
+			fs := list[0].(map[string]interface{})
+			if v := fs["origin_id"].(string); v != "" {
+				settings["originId"] = v
+			}
+			if v := fs["path_and_qs"].(string); v != "" {
+				settings["pathAndQS"] = v
+			}
+			settings["useIncomingQueryString"] = fs["use_incoming_query_string"]
+		}
+		rule["forwardSettings"] = settings
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(tools.GetSHAString(data))
+	return d.Set("json", data)
+}
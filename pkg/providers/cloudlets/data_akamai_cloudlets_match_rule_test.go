@@ -0,0 +1,64 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceEdgeRedirectorMatchRule(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceEdgeRedirectorMatchRule().Schema, map[string]interface{}{
+		"match_rules": []interface{}{
+			map[string]interface{}{
+				"name":         "old",
+				"match_url":    "/old",
+				"redirect_url": "/new",
+				"status_code":  301,
+				"matches": []interface{}{
+					map[string]interface{}{"match_type": "header", "match_value": "x-beta"},
+				},
+			},
+		},
+	})
+	require.NoError(t, dataSourceEdgeRedirectorMatchRuleRead(d, nil))
+	assert.NotEmpty(t, d.Id())
+
+	var rules []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(d.Get("json").(string)), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "erMatchRule", rules[0]["type"])
+	assert.Equal(t, "/old", rules[0]["matchURL"])
+	assert.Equal(t, "/new", rules[0]["redirectURL"])
+	assert.Equal(t, float64(301), rules[0]["statusCode"])
+	assert.Equal(t, "none", rules[0]["useRelativeUrl"])
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"matchType": "header", "matchValue": "x-beta", "matchOperator": "equals", "caseSensitive": false, "negate": false,
+	}}, rules[0]["matches"])
+	assert.NotContains(t, rules[0], "disabled")
+}
+
+func TestDataSourceForwardRewriteMatchRule(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceForwardRewriteMatchRule().Schema, map[string]interface{}{
+		"match_rules": []interface{}{
+			map[string]interface{}{
+				"name":      "api",
+				"match_url": "/api/*",
+				"disabled":  true,
+				"forward_settings": []interface{}{
+					map[string]interface{}{"origin_id": "api_origin"},
+				},
+			},
+		},
+	})
+	require.NoError(t, dataSourceForwardRewriteMatchRuleRead(d, nil))
+
+	var rules []map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(d.Get("json").(string)), &rules))
+	require.Len(t, rules, 1)
+	assert.Equal(t, "frMatchRule", rules[0]["type"])
+	assert.Equal(t, true, rules[0]["disabled"])
+	assert.Equal(t, map[string]interface{}{"originId": "api_origin", "useIncomingQueryString": false}, rules[0]["forwardSettings"])
+}
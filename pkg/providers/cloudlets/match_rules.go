@@ -0,0 +1,137 @@
+package cloudlets
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// matchRuleSchema returns the match_rules block of a match rule data source, the attributes
+// shared by every cloudlet plus the given cloudlet specific ones
+func matchRuleSchema(attrs map[string]*schema.Schema) map[string]*schema.Schema {
+	rule := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"start": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Epoch time the rule starts applying at, 0 for always",
+		},
+		"end": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "Epoch time the rule stops applying at, 0 for never",
+		},
+		"match_url": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"disabled": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"matches": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"match_type": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"match_value": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"match_operator": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "equals",
+						ValidateFunc: validation.StringInSlice([]string{"contains", "exists", "equals"}, false),
+					},
+					"case_sensitive": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"negate": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"check_ips": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice([]string{"CONNECTING_IP", "XFF_HEADERS", "CONNECTING_IP XFF_HEADERS"}, false),
+					},
+				},
+			},
+		},
+	}
+	for k, v := range attrs {
+		rule[k] = v
+	}
+
+	return map[string]*schema.Schema{
+		"match_rules": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem:     &schema.Resource{Schema: rule},
+		},
+		"json": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The match rules as JSON, to use in akamai_cloudlets_policy.match_rules",
+		},
+	}
+}
+
+// buildMatchRules converts the match_rules blocks to the API match rules of the given type,
+// extra maps the cloudlet specific attributes of a block
+func buildMatchRules(d *schema.ResourceData, ruleType string, extra func(map[string]interface{}, map[string]interface{})) (string, error) {
+	rules := make([]interface{}, 0)
+	for _, r := range d.Get("match_rules").([]interface{}) {
+		block := r.(map[string]interface{})
+		rule := map[string]interface{}{
+			"type":  ruleType,
+			"name":  block["name"],
+			"start": block["start"],
+			"end":   block["end"],
+		}
+		if v := block["match_url"].(string); v != "" {
+			rule["matchURL"] = v
+		}
+		if block["disabled"].(bool) {
+			rule["disabled"] = true
+		}
+
+		var matches []interface{}
+		for _, m := range block["matches"].([]interface{}) {
+			match := m.(map[string]interface{})
+			out := map[string]interface{}{
+				"matchType":     match["match_type"],
+				"matchValue":    match["match_value"],
+				"matchOperator": match["match_operator"],
+				"caseSensitive": match["case_sensitive"],
+				"negate":        match["negate"],
+			}
+			if v := match["check_ips"].(string); v != "" {
+				out["checkIPs"] = v
+			}
+			matches = append(matches, out)
+		}
+		if len(matches) > 0 {
+			rule["matches"] = matches
+		}
+
+		extra(block, rule)
+		rules = append(rules, rule)
+	}
+
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,144 @@
+package cloudlets
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"cloudlets_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("cloudlets_section"),
+			},
+			"cloudlets": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("cloudlets"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_cloudlets_edge_redirector_match_rule": dataSourceEdgeRedirectorMatchRule(),
+			"akamai_cloudlets_forward_rewrite_match_rule": dataSourceForwardRewriteMatchRule(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_cloudlets_policy":            resourcePolicy(),
+			"akamai_cloudlets_policy_activation": resourcePolicyActivation(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigCloudletsService(d resourceData) (*edgegrid.Config, error) {
+	var CloudletsConfig edgegrid.Config
+	if _, ok := d.GetOk("cloudlets"); ok {
+		log.Printf("[DEBUG] Setting cloudlets config via HCL")
+		config := d.Get("cloudlets").(set).List()[0].(map[string]interface{})
+
+		CloudletsConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(CloudletsConfig)
+		return &CloudletsConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("cloudlets_section"); ok && section != "default" {
+		CloudletsConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		CloudletsConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(CloudletsConfig)
+	return &CloudletsConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "cloudlets"
+}
+
+// CloudletsProviderVersion update version string anytime provider adds new features
+const CloudletsProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return CloudletsProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigCloudletsService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,267 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Cloudlets policy and its match rules
+//
+// https://developer.akamai.com/api/web_performance/cloudlets/v2.html#policy
+func resourcePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePolicyCreate,
+		Read:   resourcePolicyRead,
+		Update: resourcePolicyUpdate,
+		Delete: resourcePolicyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"cloudlet_code": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ER", "FR"}, false),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				StateFunc: trimPrefixStateFunc("grp_"),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"match_rule_format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "1.0",
+			},
+			"match_rules": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateMatchRules,
+				DiffSuppressFunc: suppressEquivalentMatchRulesDiffs,
+				Description:      "JSON array of match rules, e.g. from the akamai_cloudlets_*_match_rule data sources",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The latest version of the policy",
+			},
+			"cloudlet_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyCreate")
+
+	group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+	if err != nil {
+		return fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+	}
+
+	req := policy{
+		Name:        d.Get("name").(string),
+		GroupID:     group,
+		Description: d.Get("description").(string),
+		CloudletID:  cloudletIDs[d.Get("cloudlet_code").(string)],
+	}
+
+	log.Debug(fmt.Sprintf("creating %s policy %q", d.Get("cloudlet_code"), req.Name))
+	var p policy
+	if err := api.DoRequest(http.MethodPost, "/cloudlets/api/v2/policies", req, &p); err != nil {
+		log.Error(fmt.Sprintf("policy create failed: %s", err))
+		return apiclient.Error(err)
+	}
+	d.SetId(strconv.Itoa(p.PolicyID))
+
+	if err := writeVersion(log, d, p.PolicyID, nil); err != nil {
+		return err
+	}
+	return resourcePolicyRead(d, meta)
+}
+
+func resourcePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyRead")
+
+	policyID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid policy ID %q", d.Id())
+	}
+
+	var p policy
+	if err := api.DoRequest(http.MethodGet, policyLocation(policyID), nil, &p); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("policy %d not found, removing from state", policyID))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	attrs := map[string]interface{}{
+		"name":          p.Name,
+		"cloudlet_code": p.CloudletCode,
+		"cloudlet_id":   p.CloudletID,
+		"group_id":      strconv.Itoa(p.GroupID),
+		"description":   p.Description,
+	}
+
+	v, err := latestVersion(policyID)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+	if v != nil {
+		rules, err := normalizeMatchRules(string(v.MatchRules))
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(rules)
+		if err != nil {
+			return err
+		}
+		attrs["version"] = v.Version
+		attrs["match_rules"] = string(data)
+		if v.MatchRuleFormat != "" {
+			attrs["match_rule_format"] = v.MatchRuleFormat
+		}
+	}
+
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourcePolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyUpdate")
+
+	policyID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid policy ID %q", d.Id())
+	}
+
+	if d.HasChanges("name", "group_id", "description") {
+		group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+		if err != nil {
+			return fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+		}
+		req := policy{
+			Name:        d.Get("name").(string),
+			GroupID:     group,
+			Description: d.Get("description").(string),
+			CloudletID:  cloudletIDs[d.Get("cloudlet_code").(string)],
+		}
+		log.Debug(fmt.Sprintf("updating policy %d", policyID))
+		if err := api.DoRequest(http.MethodPut, policyLocation(policyID), req, nil); err != nil {
+			log.Error(fmt.Sprintf("policy update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	if d.HasChanges("match_rules", "match_rule_format") {
+		current, err := latestVersion(policyID)
+		if err != nil {
+			return apiclient.Error(err)
+		}
+		if err := writeVersion(log, d, policyID, current); err != nil {
+			return err
+		}
+	}
+
+	return resourcePolicyRead(d, meta)
+}
+
+func resourcePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyDelete")
+
+	policyID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid policy ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting policy %d", policyID))
+	if err := api.DoRequest(http.MethodDelete, policyLocation(policyID), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("policy delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourcePolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourcePolicyRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("policy not found")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// writeVersion saves the match rules to the current version of the policy, or to a
+// new version when there is none yet or the current one has been activated
+func writeVersion(log hclog.Logger, d *schema.ResourceData, policyID int, current *policyVersion) error {
+	rules, err := normalizeMatchRules(d.Get("match_rules").(string))
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	req := policyVersion{
+		Description:     d.Get("description").(string),
+		MatchRuleFormat: d.Get("match_rule_format").(string),
+		MatchRules:      data,
+	}
+
+	if current != nil && !current.isLocked() {
+		log.Debug(fmt.Sprintf("updating version %d of policy %d", current.Version, policyID))
+		if err := api.DoRequest(http.MethodPut, versionLocation(policyID, current.Version), req, nil); err != nil {
+			log.Error(fmt.Sprintf("version update failed: %s", err))
+			return apiclient.Error(err)
+		}
+		return nil
+	}
+
+	log.Debug(fmt.Sprintf("creating a version of policy %d", policyID))
+	if err := api.DoRequest(http.MethodPost, policyLocation(policyID)+"/versions", req, nil); err != nil {
+		log.Error(fmt.Sprintf("version create failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return nil
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
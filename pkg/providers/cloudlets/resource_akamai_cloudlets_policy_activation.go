@@ -0,0 +1,247 @@
+package cloudlets
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Cloudlets policy version activation
+//
+// https://developer.akamai.com/api/web_performance/cloudlets/v2.html#policyactivation
+func resourcePolicyActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePolicyActivationCreate,
+		Read:   resourcePolicyActivationRead,
+		Update: resourcePolicyActivationUpdate,
+		Delete: resourcePolicyActivationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourcePolicyActivationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    networkStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{networkStaging, networkProduction, "production"}, true),
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"associated_properties": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the properties whose rules reference the policy, checked before activation",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePolicyActivationCreate(d *schema.ResourceData, meta interface{}) error {
+	network := normalizeNetwork(d.Get("network").(string))
+	d.SetId(fmt.Sprintf("%d:%s", d.Get("policy_id").(int), network))
+
+	if err := activatePolicyVersion(d); err != nil {
+		d.SetId("")
+		return err
+	}
+	return resourcePolicyActivationRead(d, meta)
+}
+
+func resourcePolicyActivationRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyActivationRead")
+
+	policyID, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var activations []activation
+	path := fmt.Sprintf("%s/activations?network=%s", policyLocation(policyID), network)
+	if err := api.DoRequest(http.MethodGet, path, nil, &activations); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("policy %d not found, removing activation from state", policyID))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	version := 0
+	properties := make([]interface{}, 0)
+	for _, a := range activations {
+		if a.PolicyInfo.Status != statusActive || a.PolicyInfo.Version < version {
+			continue
+		}
+		if a.PolicyInfo.Version > version {
+			version = a.PolicyInfo.Version
+			properties = properties[:0]
+		}
+		properties = append(properties, a.PropertyInfo.Name)
+	}
+	if version == 0 {
+		log.Warn(fmt.Sprintf("policy %d is not active on %s, removing activation from state", policyID, network))
+		d.SetId("")
+		return nil
+	}
+
+	attrs := map[string]interface{}{
+		"policy_id":             policyID,
+		"network":               network,
+		"version":               version,
+		"associated_properties": schema.NewSet(schema.HashString, properties),
+		"status":                statusActive,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourcePolicyActivationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if err := activatePolicyVersion(d); err != nil {
+		return err
+	}
+	return resourcePolicyActivationRead(d, meta)
+}
+
+// The Cloudlets v2 API has no policy deactivation, destroying the resource only removes it
+// from the state
+func resourcePolicyActivationDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "resourcePolicyActivationDelete")
+
+	log.Warn(fmt.Sprintf("policy activation %s can't be deactivated, removing it from state only", d.Id()))
+	d.SetId("")
+	return nil
+}
+
+// Import by "<policy_id>:<network>"
+func resourcePolicyActivationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	policyID, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%d:%s", policyID, network))
+
+	if err := resourcePolicyActivationRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("policy %d is not active on %s", policyID, network)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// activatePolicyVersion checks the associated properties then activates the configured version
+// and waits for it to be active on every property
+func activatePolicyVersion(d *schema.ResourceData) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CLOUDLETS", "activatePolicyVersion")
+
+	policyID, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return err
+	}
+	version := d.Get("version").(int)
+
+	if err := checkAssociatedProperties(policyID, network, d.Get("associated_properties").(*schema.Set)); err != nil {
+		return err
+	}
+
+	log.Debug(fmt.Sprintf("activating policy %d version %d on %s", policyID, version, network))
+	path := versionLocation(policyID, version) + "/activations"
+	if err := api.DoRequest(http.MethodPost, path, activationRequest{Network: network}, nil); err != nil {
+		log.Error(fmt.Sprintf("policy activation failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	if _, err := waitForActivation(log, policyID, version, network); err != nil {
+		return apiclient.Error(err)
+	}
+	return nil
+}
+
+// checkAssociatedProperties fails when a configured property doesn't reference the policy or
+// has no version active on the network, the activation would otherwise fail server side
+// after a long wait or apply to a property nobody expected
+func checkAssociatedProperties(policyID int, network string, configured *schema.Set) error {
+	associated, err := associatedProperties(policyID)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	var missing, inactive []string
+	for _, name := range configured.List() {
+		p, ok := associated[name.(string)]
+		switch {
+		case !ok:
+			missing = append(missing, name.(string))
+		case network == networkStaging && p.Staging == nil, network == networkProduction && p.Production == nil:
+			inactive = append(inactive, name.(string))
+		}
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("properties %s are not associated with policy %d, add the cloudlet behavior referencing it to their rules first", strings.Join(missing, ", "), policyID)
+	}
+	if len(inactive) > 0 {
+		sort.Strings(inactive)
+		return fmt.Errorf("properties %s associated with policy %d have no version active on %s", strings.Join(inactive, ", "), policyID, network)
+	}
+	return nil
+}
+
+// parseActivationID splits a "<policy_id>:<network>" resource ID
+func parseActivationID(id string) (int, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("invalid ID %q, expected <policy_id>:<network>", id)
+	}
+	policyID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid ID %q, expected <policy_id>:<network>", id)
+	}
+	network := normalizeNetwork(parts[1])
+	if network != networkStaging && network != networkProduction {
+		return 0, "", fmt.Errorf("invalid ID %q, network must be %s or %s", id, networkStaging, networkProduction)
+	}
+	return policyID, network, nil
+}
+
+// normalizeNetwork lower cases the network and accepts "production" for "prod"
+func normalizeNetwork(network string) string {
+	network = strings.ToLower(network)
+	if network == "production" {
+		return networkProduction
+	}
+	return network
+}
+
+func networkStateFunc(v interface{}) string {
+	return normalizeNetwork(v.(string))
+}
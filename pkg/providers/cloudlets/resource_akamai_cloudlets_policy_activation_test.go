@@ -0,0 +1,71 @@
+package cloudlets
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcePolicyActivation(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCloudlets(srv)
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	fake.policies[1001] = &policy{PolicyID: 1001, Name: "redirects", CloudletCode: "ER"}
+	fake.versions[1001] = []*policyVersion{{PolicyID: 1001, Version: 1}, {PolicyID: 1001, Version: 2}}
+	fake.properties[1001] = map[string]associatedProperty{
+		"www.example.com": {Name: "www.example.com", GroupID: 42, Staging: &propertyVersion{Version: 3}},
+		"api.example.com": {Name: "api.example.com", GroupID: 42},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePolicyActivation().Schema, map[string]interface{}{
+		"policy_id":             1001,
+		"network":               "production",
+		"version":               1,
+		"associated_properties": []interface{}{"www.example.com"},
+	})
+	err := resourcePolicyActivationCreate(d, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no version active on prod")
+	assert.Equal(t, "", d.Id())
+
+	d.Set("associated_properties", []interface{}{"www.example.com", "shop.example.com"})
+	d.Set("network", "staging")
+	err = resourcePolicyActivationCreate(d, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "shop.example.com are not associated")
+	assert.Empty(t, fake.activations)
+
+	d.Set("associated_properties", []interface{}{"www.example.com"})
+	require.NoError(t, resourcePolicyActivationCreate(d, nil))
+	assert.Equal(t, "1001:staging", d.Id())
+	assert.Equal(t, 1, d.Get("version"))
+	assert.Equal(t, statusActive, d.Get("status"))
+
+	d.Set("version", 2)
+	require.NoError(t, resourcePolicyActivationUpdate(d, nil))
+	assert.Equal(t, 2, d.Get("version"))
+
+	imported := schema.TestResourceDataRaw(t, resourcePolicyActivation().Schema, map[string]interface{}{})
+	imported.SetId("1001:STAGING")
+	_, err = resourcePolicyActivationImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "1001:staging", imported.Id())
+	assert.Equal(t, 2, imported.Get("version"))
+
+	for _, id := range []string{"1001", "policy:staging", "1001:qa", "1001:production"} {
+		invalid := schema.TestResourceDataRaw(t, resourcePolicyActivation().Schema, map[string]interface{}{})
+		invalid.SetId(id)
+		_, err := resourcePolicyActivationImport(invalid, nil)
+		assert.Error(t, err, id)
+	}
+
+	require.NoError(t, resourcePolicyActivationDelete(d, nil))
+	assert.Equal(t, "", d.Id())
+}
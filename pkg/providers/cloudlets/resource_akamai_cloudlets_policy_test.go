@@ -0,0 +1,340 @@
+package cloudlets
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCloudlets serves the Cloudlets endpoints used by the provider from the mock server
+type fakeCloudlets struct {
+	lock     sync.Mutex
+	nextID   int
+	policies map[int]*policy
+	versions map[int][]*policyVersion
+	// associated properties by policy ID
+	properties map[int]map[string]associatedProperty
+	// activations by policy ID
+	activations map[int][]activation
+}
+
+func newFakeCloudlets(srv *mockapi.Server) *fakeCloudlets {
+	f := &fakeCloudlets{
+		nextID:      1000,
+		policies:    make(map[int]*policy),
+		versions:    make(map[int][]*policyVersion),
+		properties:  make(map[int]map[string]associatedProperty),
+		activations: make(map[int][]activation),
+	}
+
+	srv.Handle("POST", `/cloudlets/api/v2/policies`, f.createPolicy)
+	srv.Handle("GET", `/cloudlets/api/v2/policies/(\d+)`, f.getPolicy)
+	srv.Handle("PUT", `/cloudlets/api/v2/policies/(\d+)`, f.updatePolicy)
+	srv.Handle("DELETE", `/cloudlets/api/v2/policies/(\d+)`, f.deletePolicy)
+	srv.Handle("GET", `/cloudlets/api/v2/policies/(\d+)/versions`, f.listVersions)
+	srv.Handle("POST", `/cloudlets/api/v2/policies/(\d+)/versions`, f.createVersion)
+	srv.Handle("GET", `/cloudlets/api/v2/policies/(\d+)/versions/(\d+)`, f.getVersion)
+	srv.Handle("PUT", `/cloudlets/api/v2/policies/(\d+)/versions/(\d+)`, f.updateVersion)
+	srv.Handle("GET", `/cloudlets/api/v2/policies/(\d+)/properties`, f.getProperties)
+	srv.Handle("GET", `/cloudlets/api/v2/policies/(\d+)/activations`, f.listActivations)
+	srv.Handle("POST", `/cloudlets/api/v2/policies/(\d+)/versions/(\d+)/activations`, f.activate)
+
+	return f
+}
+
+func (f *fakeCloudlets) createPolicy(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var p policy
+	if !mockapi.ReadJSON(w, r, &p) {
+		return
+	}
+	if p.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	p.PolicyID = f.nextID
+	for code, id := range cloudletIDs {
+		if id == p.CloudletID {
+			p.CloudletCode = code
+		}
+	}
+	f.policies[p.PolicyID] = &p
+	mockapi.WriteJSON(w, http.StatusCreated, p)
+}
+
+func (f *fakeCloudlets) policy(w http.ResponseWriter, params []string) *policy {
+	id, _ := strconv.Atoi(params[0])
+	p, ok := f.policies[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+	}
+	return p
+}
+
+func (f *fakeCloudlets) getPolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if p := f.policy(w, params); p != nil {
+		mockapi.WriteJSON(w, http.StatusOK, p)
+	}
+}
+
+func (f *fakeCloudlets) updatePolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	p := f.policy(w, params)
+	if p == nil {
+		return
+	}
+	var req policy
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	p.Name, p.Description, p.GroupID = req.Name, req.Description, req.GroupID
+	mockapi.WriteJSON(w, http.StatusOK, p)
+}
+
+func (f *fakeCloudlets) deletePolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if p := f.policy(w, params); p != nil {
+		delete(f.policies, p.PolicyID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeCloudlets) listVersions(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if p := f.policy(w, params); p != nil {
+		versions := make([]policyVersion, 0)
+		for _, v := range f.versions[p.PolicyID] {
+			versions = append(versions, policyVersion{PolicyID: v.PolicyID, Version: v.Version, Activations: v.Activations})
+		}
+		mockapi.WriteJSON(w, http.StatusOK, versions)
+	}
+}
+
+func (f *fakeCloudlets) createVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	p := f.policy(w, params)
+	if p == nil {
+		return
+	}
+	v, ok := f.readVersion(w, r)
+	if !ok {
+		return
+	}
+	v.PolicyID = p.PolicyID
+	v.Version = len(f.versions[p.PolicyID]) + 1
+	f.versions[p.PolicyID] = append(f.versions[p.PolicyID], v)
+	mockapi.WriteJSON(w, http.StatusCreated, v)
+}
+
+// readVersion decodes a version and assigns the server fields to its match rules,
+// writing a 400 response on failure
+func (f *fakeCloudlets) readVersion(w http.ResponseWriter, r *http.Request) (*policyVersion, bool) {
+	var v policyVersion
+	if !mockapi.ReadJSON(w, r, &v) {
+		return nil, false
+	}
+	var rules []map[string]interface{}
+	if err := json.Unmarshal(v.MatchRules, &rules); err != nil {
+		mockapi.WriteProblem(w, http.StatusBadRequest, "Bad request", err.Error())
+		return nil, false
+	}
+	for i, rule := range rules {
+		rule["location"] = "/cloudlets/api/v2/rules/" + strconv.Itoa(i)
+		rule["akaRuleId"] = strconv.Itoa(i)
+	}
+	v.MatchRules, _ = json.Marshal(rules)
+	return &v, true
+}
+
+func (f *fakeCloudlets) version(w http.ResponseWriter, params []string) *policyVersion {
+	id, _ := strconv.Atoi(params[0])
+	n, _ := strconv.Atoi(params[1])
+	if n < 1 || n > len(f.versions[id]) {
+		w.WriteHeader(http.StatusNotFound)
+		return nil
+	}
+	return f.versions[id][n-1]
+}
+
+func (f *fakeCloudlets) getVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if v := f.version(w, params); v != nil {
+		mockapi.WriteJSON(w, http.StatusOK, v)
+	}
+}
+
+func (f *fakeCloudlets) updateVersion(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	current := f.version(w, params)
+	if current == nil {
+		return
+	}
+	if current.isLocked() {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	v, ok := f.readVersion(w, r)
+	if !ok {
+		return
+	}
+	current.Description, current.MatchRuleFormat, current.MatchRules = v.Description, v.MatchRuleFormat, v.MatchRules
+	mockapi.WriteJSON(w, http.StatusOK, current)
+}
+
+func (f *fakeCloudlets) getProperties(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if p := f.policy(w, params); p != nil {
+		properties := f.properties[p.PolicyID]
+		if properties == nil {
+			properties = make(map[string]associatedProperty)
+		}
+		mockapi.WriteJSON(w, http.StatusOK, properties)
+	}
+}
+
+func (f *fakeCloudlets) listActivations(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	p := f.policy(w, params)
+	if p == nil {
+		return
+	}
+	activations := make([]activation, 0)
+	for i, a := range f.activations[p.PolicyID] {
+		if a.Network != r.URL.Query().Get("network") {
+			continue
+		}
+		activations = append(activations, a)
+		// activations complete on the next poll
+		if a.PolicyInfo.Status == statusPending {
+			f.activations[p.PolicyID][i].PolicyInfo.Status = statusActive
+		}
+	}
+	mockapi.WriteJSON(w, http.StatusOK, activations)
+}
+
+func (f *fakeCloudlets) activate(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	v := f.version(w, params)
+	if v == nil {
+		return
+	}
+	var req activationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+
+	var created []activation
+	// the policy activates on the properties with a version active on the network
+	for name, p := range f.properties[v.PolicyID] {
+		if (req.Network == networkStaging && p.Staging == nil) || (req.Network == networkProduction && p.Production == nil) {
+			continue
+		}
+		created = append(created, activation{
+			Network:      req.Network,
+			PolicyInfo:   policyInfo{PolicyID: v.PolicyID, Version: v.Version, Status: statusPending},
+			PropertyInfo: propertyInfo{Name: name, GroupID: p.GroupID},
+		})
+	}
+	f.activations[v.PolicyID] = append(f.activations[v.PolicyID], created...)
+	v.Activations = append(v.Activations, activation{Network: req.Network, PolicyInfo: policyInfo{Status: statusActive}})
+	mockapi.WriteJSON(w, http.StatusOK, created)
+}
+
+func TestResourcePolicy(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCloudlets(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourcePolicy().Schema, map[string]interface{}{
+		"name":          "redirects",
+		"cloudlet_code": "ER",
+		"group_id":      "grp_42",
+		"match_rules":   `[{"type": "erMatchRule", "name": "old", "matchURL": "/old", "redirectURL": "/new", "statusCode": 301}]`,
+	})
+	require.NoError(t, resourcePolicyCreate(d, nil))
+	assert.Equal(t, "1001", d.Id())
+	assert.Equal(t, 42, fake.policies[1001].GroupID)
+	assert.Equal(t, 1, d.Get("version"))
+	assert.Equal(t, "1.0", fake.versions[1001][0].MatchRuleFormat)
+	assert.NotContains(t, d.Get("match_rules"), "akaRuleId", "server fields are dropped")
+	assert.True(t, suppressEquivalentMatchRulesDiffs("", string(fake.versions[1001][0].MatchRules), d.Get("match_rules").(string), nil))
+
+	// an inactive version is updated in place
+	d.Set("match_rules", `[{"type": "erMatchRule", "name": "old", "matchURL": "/old", "redirectURL": "/newer", "statusCode": 302}]`)
+	require.NoError(t, resourcePolicyUpdate(d, nil))
+	assert.Len(t, fake.versions[1001], 1)
+	assert.Contains(t, string(fake.versions[1001][0].MatchRules), "/newer")
+
+	// an activated version gets a new version
+	fake.versions[1001][0].Activations = []activation{{Network: networkStaging, PolicyInfo: policyInfo{Status: statusActive}}}
+	d.Set("match_rules", `[]`)
+	require.NoError(t, resourcePolicyUpdate(d, nil))
+	assert.Len(t, fake.versions[1001], 2)
+	assert.Equal(t, 2, d.Get("version"))
+
+	imported := schema.TestResourceDataRaw(t, resourcePolicy().Schema, map[string]interface{}{})
+	imported.SetId("1001")
+	_, err := resourcePolicyImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ER", imported.Get("cloudlet_code"))
+	assert.Equal(t, "42", imported.Get("group_id"))
+
+	require.NoError(t, resourcePolicyDelete(d, nil))
+	assert.Empty(t, fake.policies)
+
+	d.SetId("1001")
+	require.NoError(t, resourcePolicyRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestSuppressEquivalentMatchRulesDiffs(t *testing.T) {
+	tests := map[string]struct {
+		old, new string
+		suppress bool
+	}{
+		"formatting":    {`[{"name":"a","matchURL":"/a"}]`, "[\n  {\"matchURL\": \"/a\", \"name\": \"a\"}\n]", true},
+		"server fields": {`[{"name":"a","akaRuleId":"1","location":"/rules/1"}]`, `[{"name":"a"}]`, true},
+		"empty":         {``, `[]`, true},
+		"value":         {`[{"name":"a"}]`, `[{"name":"b"}]`, false},
+		"order":         {`[{"name":"a"},{"name":"b"}]`, `[{"name":"b"},{"name":"a"}]`, false},
+		"invalid":       {`[{"name":"a"}]`, `{`, false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.suppress, suppressEquivalentMatchRulesDiffs("match_rules", test.old, test.new, nil))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package cps
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CPS DV Challenges
+//
+// https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#getchangeletsencryptchallenges
+func dataSourceCPSDVChallenges() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCPSDVChallengesRead,
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"change_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_challenges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     challengeSchema(),
+			},
+			"http_challenges": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     challengeSchema(),
+			},
+		},
+	}
+}
+
+func challengeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"full_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"response_body": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceCPSDVChallengesRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "dataSourceCPSDVChallengesRead")
+
+	id := d.Get("enrollment_id").(int)
+
+	log.Debug(fmt.Sprintf("reading DV challenges of enrollment %d", id))
+	_, status, err := findDVChange(id)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	dnsChallenges := make([]interface{}, 0)
+	httpChallenges := make([]interface{}, 0)
+	changeStatus := ""
+	if status != nil {
+		changeStatus = status.StatusInfo.Status
+
+		challenges, err := getDVChallenges(status.input(inputLetsEncryptChallenges).Info)
+		if err != nil {
+			return apiclient.Error(err)
+		}
+
+		for _, dv := range challenges.DV {
+			for _, c := range dv.Challenges {
+				challenge := map[string]interface{}{
+					"domain":        dv.Domain,
+					"full_path":     c.FullPath,
+					"response_body": c.ResponseBody,
+				}
+				switch c.Type {
+				case "dns-01":
+					dnsChallenges = append(dnsChallenges, challenge)
+				case "http-01":
+					httpChallenges = append(httpChallenges, challenge)
+				}
+			}
+		}
+	}
+
+	d.SetId(strconv.Itoa(id))
+	if err := d.Set("change_status", changeStatus); err != nil {
+		return err
+	}
+	if err := d.Set("dns_challenges", dnsChallenges); err != nil {
+		return err
+	}
+	return d.Set("http_challenges", httpChallenges)
+}
+
+// findDVChange returns the pending change of an enrollment waiting for DV challenges
+// to be fulfilled. The status is nil when no change is waiting.
+func findDVChange(id int) (string, *changeStatus, error) {
+	e, err := getEnrollment(id)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for i := len(e.PendingChanges) - 1; i >= 0; i-- {
+		location := e.PendingChanges[i]
+		status, err := getChangeStatus(location)
+		if err != nil {
+			return "", nil, err
+		}
+		if status.input(inputLetsEncryptChallenges) != nil {
+			return location, status, nil
+		}
+	}
+	return "", nil, nil
+}
@@ -0,0 +1,46 @@
+package cps
+
+import (
+	"testing"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceCPSDVChallenges(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	e := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, testEnrollmentConfig())
+	require.NoError(t, resourceCPSEnrollmentCreate(e, nil))
+
+	d := schema.TestResourceDataRaw(t, dataSourceCPSDVChallenges().Schema, map[string]interface{}{
+		"enrollment_id": 10001,
+	})
+	require.NoError(t, dataSourceCPSDVChallengesRead(d, nil))
+
+	assert.Equal(t, "10001", d.Id())
+	assert.Equal(t, "coordinate-domain-validation", d.Get("change_status"))
+	require.Equal(t, 1, d.Get("dns_challenges.#"))
+	assert.Equal(t, "www.example.com", d.Get("dns_challenges.0.domain"))
+	assert.Equal(t, "_acme-challenge.www.example.com.", d.Get("dns_challenges.0.full_path"))
+	assert.Equal(t, "dns-token", d.Get("dns_challenges.0.response_body"))
+	require.Equal(t, 1, d.Get("http_challenges.#"))
+	assert.Equal(t, "http-token", d.Get("http_challenges.0.response_body"))
+
+	// once validated there is nothing left to publish
+	for change := range fake.changes {
+		fake.changes[change] = "complete"
+	}
+	require.NoError(t, dataSourceCPSDVChallengesRead(d, nil))
+	assert.Equal(t, 0, d.Get("dns_challenges.#"))
+	assert.Equal(t, 0, d.Get("http_challenges.#"))
+}
@@ -0,0 +1,309 @@
+package cps
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+// The cps-v2 client only supports creating and reading enrollments with an older
+// subset of the enrollment schema, so the provider talks to the CPS v2 API directly.
+//
+// https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html
+
+const (
+	enrollmentContentType = "application/vnd.akamai.cps.enrollment.v7+json"
+	enrollmentStatusType  = "application/vnd.akamai.cps.enrollment-status.v1+json"
+	changeStatusType      = "application/vnd.akamai.cps.change.v2+json"
+	dvChallengesType      = "application/vnd.akamai.cps.dv-challenges.v2+json"
+	acknowledgementType   = "application/vnd.akamai.cps.acknowledgement.v1+json"
+	changeIDType          = "application/vnd.akamai.cps.change-id.v1+json"
+
+	// inputLetsEncryptChallenges is the allowed input of a change waiting for DV
+	inputLetsEncryptChallenges = "lets-encrypt-challenges"
+
+	// change states reported in statusInfo.state
+	changeStateRunning       = "running"
+	changeStateAwaitingInput = "awaiting-input"
+	changeStateError         = "error"
+
+	changeStatusComplete = "complete"
+)
+
+var (
+	// ErrChangeFailed is returned when a CPS change ends in an error state
+	ErrChangeFailed = errors.New("CPS change failed")
+
+	// changePollInterval and changePollTimeout bound waitForChange
+	changePollInterval = 15 * time.Second
+	changePollTimeout  = 90 * time.Minute
+)
+
+type (
+	enrollment struct {
+		Location                       string                `json:"location,omitempty"`
+		AdminContact                   *contact              `json:"adminContact"`
+		CertificateChainType           string                `json:"certificateChainType,omitempty"`
+		CertificateType                string                `json:"certificateType"`
+		CSR                            *csr                  `json:"csr"`
+		ChangeManagement               bool                  `json:"changeManagement"`
+		EnableMultiStackedCertificates bool                  `json:"enableMultiStackedCertificates"`
+		NetworkConfiguration           *networkConfiguration `json:"networkConfiguration"`
+		Org                            *organization         `json:"org"`
+		PendingChanges                 []string              `json:"pendingChanges,omitempty"`
+		RA                             string                `json:"ra"`
+		SignatureAlgorithm             string                `json:"signatureAlgorithm,omitempty"`
+		TechContact                    *contact              `json:"techContact"`
+		ThirdParty                     *thirdParty           `json:"thirdParty,omitempty"`
+		ValidationType                 string                `json:"validationType"`
+	}
+
+	contact struct {
+		FirstName        string `json:"firstName"`
+		LastName         string `json:"lastName"`
+		Title            string `json:"title,omitempty"`
+		OrganizationName string `json:"organizationName,omitempty"`
+		Email            string `json:"email"`
+		Phone            string `json:"phone"`
+		AddressLineOne   string `json:"addressLineOne,omitempty"`
+		AddressLineTwo   string `json:"addressLineTwo,omitempty"`
+		City             string `json:"city,omitempty"`
+		Region           string `json:"region,omitempty"`
+		PostalCode       string `json:"postalCode,omitempty"`
+		Country          string `json:"country,omitempty"`
+	}
+
+	organization struct {
+		Name           string `json:"name"`
+		Phone          string `json:"phone,omitempty"`
+		AddressLineOne string `json:"addressLineOne,omitempty"`
+		AddressLineTwo string `json:"addressLineTwo,omitempty"`
+		City           string `json:"city,omitempty"`
+		Region         string `json:"region,omitempty"`
+		PostalCode     string `json:"postalCode,omitempty"`
+		Country        string `json:"country,omitempty"`
+	}
+
+	csr struct {
+		CN   string   `json:"cn"`
+		C    string   `json:"c,omitempty"`
+		ST   string   `json:"st,omitempty"`
+		L    string   `json:"l,omitempty"`
+		O    string   `json:"o,omitempty"`
+		OU   string   `json:"ou,omitempty"`
+		SANs []string `json:"sans,omitempty"`
+	}
+
+	networkConfiguration struct {
+		DisallowedTLSVersions []string         `json:"disallowedTlsVersions,omitempty"`
+		DNSNameSettings       *dnsNameSettings `json:"dnsNameSettings,omitempty"`
+		Geography             string           `json:"geography"`
+		MustHaveCiphers       string           `json:"mustHaveCiphers,omitempty"`
+		NetworkType           string           `json:"networkType,omitempty"`
+		OCSPStapling          string           `json:"ocspStapling,omitempty"`
+		PreferredCiphers      string           `json:"preferredCiphers,omitempty"`
+		QUICEnabled           bool             `json:"quicEnabled"`
+		SecureNetwork         string           `json:"secureNetwork"`
+		SNIOnly               bool             `json:"sniOnly"`
+	}
+
+	dnsNameSettings struct {
+		CloneDNSNames bool     `json:"cloneDnsNames"`
+		DNSNames      []string `json:"dnsNames,omitempty"`
+	}
+
+	thirdParty struct {
+		ExcludeSANs bool `json:"excludeSans"`
+	}
+
+	// enrollmentStatus is returned by the create, update and delete operations
+	enrollmentStatus struct {
+		Enrollment string   `json:"enrollment"`
+		Changes    []string `json:"changes"`
+	}
+
+	changeStatus struct {
+		StatusInfo struct {
+			Status      string `json:"status"`
+			State       string `json:"state"`
+			Description string `json:"description"`
+			Error       *struct {
+				Code        string `json:"code"`
+				Description string `json:"description"`
+				Timestamp   string `json:"timestamp"`
+			} `json:"error,omitempty"`
+		} `json:"statusInfo"`
+		AllowedInput []allowedInput `json:"allowedInput"`
+	}
+
+	dvChallenges struct {
+		DV []struct {
+			Domain           string `json:"domain"`
+			ValidationStatus string `json:"validationStatus"`
+			Error            string `json:"error,omitempty"`
+			Challenges       []struct {
+				Type         string `json:"type"`
+				FullPath     string `json:"fullPath"`
+				RedirectPath string `json:"redirectFullPath,omitempty"`
+				ResponseBody string `json:"responseBody"`
+				Status       string `json:"status"`
+				Token        string `json:"token"`
+			} `json:"challenges"`
+		} `json:"dv"`
+	}
+
+	allowedInput struct {
+		Type              string `json:"type"`
+		RequiredToProceed bool   `json:"requiredToProceed"`
+		Info              string `json:"info"`
+		Update            string `json:"update"`
+	}
+)
+
+// enrollmentLocation returns the API path of an enrollment ID
+func enrollmentLocation(id int) string {
+	return fmt.Sprintf("/cps/v2/enrollments/%d", id)
+}
+
+// enrollmentID extracts the enrollment ID from an enrollment or change location
+func enrollmentID(location string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(location, "/cps/v2/enrollments/"), "/")
+	id, err := strconv.Atoi(parts[0])
+	if err != nil || !strings.HasPrefix(location, "/cps/v2/enrollments/") {
+		return 0, fmt.Errorf("invalid enrollment location %q", location)
+	}
+	return id, nil
+}
+
+func doEnrollmentRequest(method, path, accept string, body, out interface{}) error {
+	req, err := apiclient.NewRequest(cps.Config, method, path, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", enrollmentContentType)
+	}
+	req.Header.Set("Accept", accept)
+	return apiclient.Do(cps.Config, req, out)
+}
+
+func createEnrollment(contractID string, e *enrollment) (*enrollmentStatus, error) {
+	path := "/cps/v2/enrollments?contractId=" + strings.TrimPrefix(contractID, "ctr_")
+
+	var status enrollmentStatus
+	if err := doEnrollmentRequest(http.MethodPost, path, enrollmentStatusType, e, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func getEnrollment(id int) (*enrollment, error) {
+	var e enrollment
+	if err := doEnrollmentRequest(http.MethodGet, enrollmentLocation(id), enrollmentContentType, nil, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// updateEnrollment replaces the enrollment, cancelling any pending change so the
+// new configuration takes effect
+func updateEnrollment(id int, e *enrollment) (*enrollmentStatus, error) {
+	path := enrollmentLocation(id) + "?allow-cancel-pending-changes=true"
+
+	var status enrollmentStatus
+	if err := doEnrollmentRequest(http.MethodPut, path, enrollmentStatusType, e, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func deleteEnrollment(id int) (*enrollmentStatus, error) {
+	path := enrollmentLocation(id) + "?allow-cancel-pending-changes=true"
+
+	var status enrollmentStatus
+	if err := doEnrollmentRequest(http.MethodDelete, path, enrollmentStatusType, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func getChangeStatus(location string) (*changeStatus, error) {
+	var status changeStatus
+	if err := doEnrollmentRequest(http.MethodGet, location, changeStatusType, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// waitForChange polls a change until it completes, fails or needs input from the
+// user, such as DV challenges or a third party certificate upload
+func waitForChange(log hclog.Logger, location string) (*changeStatus, error) {
+	return pollChange(log, location, func(status *changeStatus) bool {
+		info := status.StatusInfo
+		return info.Status == changeStatusComplete || info.State == changeStateAwaitingInput
+	})
+}
+
+// pollChange polls a change until done returns true or the change fails
+func pollChange(log hclog.Logger, location string, done func(*changeStatus) bool) (*changeStatus, error) {
+	var status *changeStatus
+	err := apiclient.Poll(changePollInterval, changePollTimeout, func() (bool, error) {
+		var err error
+		if status, err = getChangeStatus(location); err != nil {
+			return false, err
+		}
+
+		info := status.StatusInfo
+		log.Debug(fmt.Sprintf("change %s status [%s] state [%s]", location, info.Status, info.State))
+
+		if info.State == changeStateError {
+			msg := info.Description
+			if info.Error != nil && info.Error.Description != "" {
+				msg = info.Error.Description
+			}
+			return false, fmt.Errorf("%w: %s: %s", ErrChangeFailed, info.Status, msg)
+		}
+		return done(status), nil
+	})
+	if errors.Is(err, apiclient.ErrPollTimeout) {
+		return status, fmt.Errorf("%w: CPS change %s, last status %q", err, location, status.StatusInfo.Status)
+	}
+	return status, err
+}
+
+// input returns the allowed input of the given type, or nil
+func (c *changeStatus) input(inputType string) *allowedInput {
+	for i := range c.AllowedInput {
+		if c.AllowedInput[i].Type == inputType {
+			return &c.AllowedInput[i]
+		}
+	}
+	return nil
+}
+
+// getDVChallenges returns the Let's Encrypt challenges of a change waiting for domain validation
+func getDVChallenges(info string) (*dvChallenges, error) {
+	var challenges dvChallenges
+	if err := doEnrollmentRequest(http.MethodGet, info, dvChallengesType, nil, &challenges); err != nil {
+		return nil, err
+	}
+	return &challenges, nil
+}
+
+// acknowledgeDVChallenges tells CPS the challenges are published so it can validate the domains
+func acknowledgeDVChallenges(update string) error {
+	req, err := apiclient.NewRequest(cps.Config, http.MethodPost, update, map[string]string{"acknowledgement": "acknowledge"})
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", acknowledgementType)
+	req.Header.Set("Accept", changeIDType)
+	return apiclient.Do(cps.Config, req, nil)
+}
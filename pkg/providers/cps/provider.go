@@ -0,0 +1,144 @@
+package cps
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"cps_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("cps_section"),
+			},
+			"cps": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("cps"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_cps_dv_challenges": dataSourceCPSDVChallenges(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_cps_dv_validation": resourceCPSDVValidation(),
+			"akamai_cps_enrollment":    resourceCPSEnrollment(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigCPSV2Service(d resourceData) (*edgegrid.Config, error) {
+	var CPSv2Config edgegrid.Config
+	if _, ok := d.GetOk("cps"); ok {
+		log.Printf("[DEBUG] Setting cps config via HCL")
+		config := d.Get("cps").(set).List()[0].(map[string]interface{})
+
+		CPSv2Config = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		cps.Init(CPSv2Config)
+		return &CPSv2Config, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("cps_section"); ok && section != "default" {
+		CPSv2Config, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		CPSv2Config, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	cps.Init(CPSv2Config)
+	return &CPSv2Config, nil
+}
+
+func (p *provider) Name() string {
+	return "cps"
+}
+
+// CPSProviderVersion update version string anytime provider adds new features
+const CPSProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return CPSProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigCPSV2Service(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
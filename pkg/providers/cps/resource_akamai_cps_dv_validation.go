@@ -0,0 +1,116 @@
+package cps
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CPS DV Validation
+//
+// Acknowledges the DV challenges of an enrollment once they are published, e.g. with
+// akamai_dns_record, and waits for CPS to validate the domains.
+//
+// https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#postacknowledgement
+func resourceCPSDVValidation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCPSDVValidationCreate,
+		Read:   resourceCPSDVValidationRead,
+		Delete: resourceCPSDVValidationDelete,
+		Schema: map[string]*schema.Schema{
+			"enrollment_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"sans": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "SANs of the enrollment, changing them triggers a new validation",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCPSDVValidationCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSDVValidationCreate")
+
+	id := d.Get("enrollment_id").(int)
+
+	location, status, err := findDVChange(id)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	d.SetId(strconv.Itoa(id))
+	if status == nil {
+		log.Info(fmt.Sprintf("enrollment %d has no DV challenges to acknowledge", id))
+		return resourceCPSDVValidationRead(d, meta)
+	}
+
+	log.Debug(fmt.Sprintf("acknowledging DV challenges of change %s", location))
+	if err := acknowledgeDVChallenges(status.input(inputLetsEncryptChallenges).Update); err != nil {
+		log.Error(fmt.Sprintf("DV acknowledgement failed: %s", err))
+		d.SetId("")
+		return apiclient.Error(err)
+	}
+
+	// the change leaves the challenges input once CPS has validated every domain
+	status, err = pollChange(log, location, func(status *changeStatus) bool {
+		return status.StatusInfo.Status == changeStatusComplete || status.input(inputLetsEncryptChallenges) == nil
+	})
+	if err != nil {
+		log.Warn(fmt.Sprintf("DV validation failed: %s", err))
+		d.SetId("")
+		return fmt.Errorf("domain validation of enrollment %d: %w", id, err)
+	}
+	log.Info(fmt.Sprintf("enrollment change %s validated, now [%s]", location, status.StatusInfo.Status))
+
+	return resourceCPSDVValidationRead(d, meta)
+}
+
+func resourceCPSDVValidationRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSDVValidationRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid enrollment ID %q", d.Id())
+	}
+
+	e, err := getEnrollment(id)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("enrollment %d not found, removing validation from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	status := changeStatusComplete
+	if len(e.PendingChanges) > 0 {
+		change, err := getChangeStatus(e.PendingChanges[len(e.PendingChanges)-1])
+		if err != nil {
+			return apiclient.Error(err)
+		}
+		status = change.StatusInfo.Status
+	}
+	return d.Set("status", status)
+}
+
+// Validation can't be undone, deleting only removes it from state
+func resourceCPSDVValidationDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
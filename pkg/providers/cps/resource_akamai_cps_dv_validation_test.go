@@ -0,0 +1,68 @@
+package cps
+
+import (
+	"errors"
+	"testing"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceCPSDVValidation(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	e := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, testEnrollmentConfig())
+	require.NoError(t, resourceCPSEnrollmentCreate(e, nil))
+
+	d := schema.TestResourceDataRaw(t, resourceCPSDVValidation().Schema, map[string]interface{}{
+		"enrollment_id": 10001,
+		"sans":          []interface{}{"www.example.com", "static.example.com"},
+	})
+	require.NoError(t, resourceCPSDVValidationCreate(d, nil))
+
+	assert.Equal(t, "10001", d.Id())
+	assert.Equal(t, 1, fake.acknowledged)
+	assert.Equal(t, "wait-review-pre-verification-safety-checks", d.Get("status"))
+
+	// nothing left to acknowledge
+	again := schema.TestResourceDataRaw(t, resourceCPSDVValidation().Schema, map[string]interface{}{
+		"enrollment_id": 10001,
+	})
+	require.NoError(t, resourceCPSDVValidationCreate(again, nil))
+	assert.Equal(t, 1, fake.acknowledged)
+
+	require.NoError(t, resourceCPSDVValidationDelete(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceCPSDVValidation_failed(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	e := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, testEnrollmentConfig())
+	require.NoError(t, resourceCPSEnrollmentCreate(e, nil))
+
+	// validation of the published challenges fails
+	fake.failValidation = true
+
+	d := schema.TestResourceDataRaw(t, resourceCPSDVValidation().Schema, map[string]interface{}{
+		"enrollment_id": 10001,
+	})
+	err := resourceCPSDVValidationCreate(d, nil)
+	assert.True(t, errors.Is(err, ErrChangeFailed), "unexpected error: %v", err)
+	assert.Equal(t, "", d.Id())
+}
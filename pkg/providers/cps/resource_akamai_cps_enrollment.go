@@ -0,0 +1,721 @@
+package cps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CPS Enrollment
+//
+// https://developer.akamai.com/api/core_features/certificate_provisioning_system/v2.html#enrollment
+func resourceCPSEnrollment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCPSEnrollmentCreate,
+		Read:   resourceCPSEnrollmentRead,
+		Update: resourceCPSEnrollmentUpdate,
+		Delete: resourceCPSEnrollmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceCPSEnrollmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"contract": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				StateFunc: func(v interface{}) string {
+					return strings.TrimPrefix(v.(string), "ctr_")
+				},
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"certificate_chain_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"certificate_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"san", "single", "wildcard", "wildcard-san", "third-party"}, false),
+			},
+			"change_management": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"enable_multi_stacked_certificates": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ra": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"lets-encrypt", "symantec", "third-party"}, false),
+			},
+			"signature_algorithm": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHA-256",
+				ValidateFunc: validation.StringInSlice([]string{"SHA-1", "SHA-256"}, false),
+			},
+			"validation_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"dv", "ov", "ev", "third-party"}, false),
+			},
+			"admin_contact": contactSchema(),
+			"tech_contact":  contactSchema(),
+			"csr": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cn": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"c": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"st": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"l": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"o": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ou": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sans": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"network_configuration": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"disallowed_tls_versions": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"dns_name_settings": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"clone_dns_names": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Default:  false,
+									},
+									"dns_names": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"geography": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"core", "china+core", "russia+core"}, false),
+						},
+						"must_have_ciphers": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "ak-akamai-default",
+						},
+						"network_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ocsp_stapling": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "on",
+							ValidateFunc: validation.StringInSlice([]string{"on", "off", "not-set"}, false),
+						},
+						"preferred_ciphers": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "ak-akamai-default",
+						},
+						"quic_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+						"secure_network": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"standard-tls", "enhanced-tls", "shared-cert"}, false),
+						},
+						"sni_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+			"org": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"phone": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address_line_one": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"address_line_two": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"city": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"postal_code": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"third_party": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"exclude_sans": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"wait_on_complete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"pending_changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"change_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// enrollmentAttributes are the updatable attributes sent to CPS
+var enrollmentAttributes = []string{
+	"certificate_chain_type",
+	"change_management",
+	"enable_multi_stacked_certificates",
+	"signature_algorithm",
+	"admin_contact",
+	"tech_contact",
+	"csr",
+	"network_configuration",
+	"org",
+	"third_party",
+}
+
+func contactSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"first_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"last_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"title": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"organization_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"email": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"phone": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"address_line_one": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"address_line_two": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"city": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"region": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"postal_code": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"country": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func resourceCPSEnrollmentCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSEnrollmentCreate")
+
+	e := expandEnrollment(d)
+	contract := d.Get("contract").(string)
+
+	log.Debug(fmt.Sprintf("creating enrollment for %q in contract %s", e.CSR.CN, contract))
+	status, err := createEnrollment(contract, e)
+	if err != nil {
+		log.Error(fmt.Sprintf("enrollment create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	id, err := enrollmentID(status.Enrollment)
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(id))
+
+	if err := waitForChanges(log, d, status.Changes); err != nil {
+		return err
+	}
+
+	return resourceCPSEnrollmentRead(d, meta)
+}
+
+func resourceCPSEnrollmentRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSEnrollmentRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid enrollment ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("reading enrollment %d", id))
+	e, err := getEnrollment(id)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("enrollment %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	if err := flattenEnrollment(d, e); err != nil {
+		return err
+	}
+
+	// only report the status of the most recent change, it is the one blocking deployment
+	changeStatus := ""
+	if len(e.PendingChanges) > 0 {
+		status, err := getChangeStatus(e.PendingChanges[len(e.PendingChanges)-1])
+		if err != nil {
+			return apiclient.Error(err)
+		}
+		changeStatus = status.StatusInfo.Status
+	}
+	return d.Set("change_status", changeStatus)
+}
+
+func resourceCPSEnrollmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSEnrollmentUpdate")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid enrollment ID %q", d.Id())
+	}
+
+	// wait_on_complete only changes provider behavior
+	if !d.HasChanges(enrollmentAttributes...) {
+		return resourceCPSEnrollmentRead(d, meta)
+	}
+
+	log.Debug(fmt.Sprintf("updating enrollment %d", id))
+	status, err := updateEnrollment(id, expandEnrollment(d))
+	if err != nil {
+		log.Error(fmt.Sprintf("enrollment update failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	if err := waitForChanges(log, d, status.Changes); err != nil {
+		return err
+	}
+
+	return resourceCPSEnrollmentRead(d, meta)
+}
+
+func resourceCPSEnrollmentDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("CPS", "resourceCPSEnrollmentDelete")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid enrollment ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting enrollment %d", id))
+	if _, err := deleteEnrollment(id); err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		log.Error(fmt.Sprintf("enrollment delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import an enrollment by "<enrollment_id>" or "<enrollment_id>:<contract_id>". The
+// enrollment API does not report the contract, so it can only be set from the ID.
+func resourceCPSEnrollmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		return nil, fmt.Errorf("invalid enrollment import ID %q, expected <enrollment_id>[:<contract_id>]", d.Id())
+	}
+
+	d.SetId(parts[0])
+	if len(parts) == 2 {
+		if err := d.Set("contract", strings.TrimPrefix(parts[1], "ctr_")); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.Set("wait_on_complete", true); err != nil {
+		return nil, err
+	}
+
+	if err := resourceCPSEnrollmentRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("enrollment %s not found", parts[0])
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// waitForChanges waits on the changes started by a create or update
+func waitForChanges(log hclog.Logger, d *schema.ResourceData, changes []string) error {
+	if !d.Get("wait_on_complete").(bool) {
+		return nil
+	}
+
+	for _, change := range changes {
+		status, err := waitForChange(log, change)
+		if err != nil {
+			log.Warn(fmt.Sprintf("enrollment change failed: %s", err))
+			return err
+		}
+		log.Info(fmt.Sprintf("enrollment change %s reached [%s]", change, status.StatusInfo.Status))
+	}
+	return nil
+}
+
+func expandEnrollment(d *schema.ResourceData) *enrollment {
+	e := &enrollment{
+		CertificateChainType:           d.Get("certificate_chain_type").(string),
+		CertificateType:                d.Get("certificate_type").(string),
+		ChangeManagement:               d.Get("change_management").(bool),
+		EnableMultiStackedCertificates: d.Get("enable_multi_stacked_certificates").(bool),
+		RA:                             d.Get("ra").(string),
+		SignatureAlgorithm:             d.Get("signature_algorithm").(string),
+		ValidationType:                 d.Get("validation_type").(string),
+		AdminContact:                   expandContact(d.Get("admin_contact").([]interface{})),
+		TechContact:                    expandContact(d.Get("tech_contact").([]interface{})),
+	}
+
+	if v := firstBlock(d.Get("csr")); v != nil {
+		e.CSR = &csr{
+			CN:   v["cn"].(string),
+			C:    v["c"].(string),
+			ST:   v["st"].(string),
+			L:    v["l"].(string),
+			O:    v["o"].(string),
+			OU:   v["ou"].(string),
+			SANs: expandStringSet(v["sans"]),
+		}
+	}
+
+	if v := firstBlock(d.Get("network_configuration")); v != nil {
+		nc := &networkConfiguration{
+			DisallowedTLSVersions: expandStringSet(v["disallowed_tls_versions"]),
+			Geography:             v["geography"].(string),
+			MustHaveCiphers:       v["must_have_ciphers"].(string),
+			NetworkType:           v["network_type"].(string),
+			OCSPStapling:          v["ocsp_stapling"].(string),
+			PreferredCiphers:      v["preferred_ciphers"].(string),
+			QUICEnabled:           v["quic_enabled"].(bool),
+			SecureNetwork:         v["secure_network"].(string),
+			SNIOnly:               v["sni_only"].(bool),
+		}
+		if dns := firstBlock(v["dns_name_settings"]); dns != nil {
+			nc.DNSNameSettings = &dnsNameSettings{
+				CloneDNSNames: dns["clone_dns_names"].(bool),
+				DNSNames:      expandStringSet(dns["dns_names"]),
+			}
+		}
+		e.NetworkConfiguration = nc
+	}
+
+	if v := firstBlock(d.Get("org")); v != nil {
+		e.Org = &organization{
+			Name:           v["name"].(string),
+			Phone:          v["phone"].(string),
+			AddressLineOne: v["address_line_one"].(string),
+			AddressLineTwo: v["address_line_two"].(string),
+			City:           v["city"].(string),
+			Region:         v["region"].(string),
+			PostalCode:     v["postal_code"].(string),
+			Country:        v["country"].(string),
+		}
+	}
+
+	if v := firstBlock(d.Get("third_party")); v != nil {
+		e.ThirdParty = &thirdParty{ExcludeSANs: v["exclude_sans"].(bool)}
+	}
+
+	return e
+}
+
+func expandContact(list []interface{}) *contact {
+	v := firstBlock(list)
+	if v == nil {
+		return nil
+	}
+
+	return &contact{
+		FirstName:        v["first_name"].(string),
+		LastName:         v["last_name"].(string),
+		Title:            v["title"].(string),
+		OrganizationName: v["organization_name"].(string),
+		Email:            v["email"].(string),
+		Phone:            v["phone"].(string),
+		AddressLineOne:   v["address_line_one"].(string),
+		AddressLineTwo:   v["address_line_two"].(string),
+		City:             v["city"].(string),
+		Region:           v["region"].(string),
+		PostalCode:       v["postal_code"].(string),
+		Country:          v["country"].(string),
+	}
+}
+
+// firstBlock returns the single element of a MaxItems: 1 block, or nil
+func firstBlock(v interface{}) map[string]interface{} {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 || list[0] == nil {
+		return nil
+	}
+	return list[0].(map[string]interface{})
+}
+
+func expandStringSet(v interface{}) []string {
+	s, ok := v.(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	out := make([]string, 0, s.Len())
+	for _, item := range s.List() {
+		out = append(out, item.(string))
+	}
+	return out
+}
+
+func flattenEnrollment(d *schema.ResourceData, e *enrollment) error {
+	attrs := map[string]interface{}{
+		"location":                          e.Location,
+		"certificate_chain_type":            e.CertificateChainType,
+		"certificate_type":                  e.CertificateType,
+		"change_management":                 e.ChangeManagement,
+		"enable_multi_stacked_certificates": e.EnableMultiStackedCertificates,
+		"ra":                                e.RA,
+		"signature_algorithm":               e.SignatureAlgorithm,
+		"validation_type":                   e.ValidationType,
+		"admin_contact":                     flattenContact(e.AdminContact),
+		"tech_contact":                      flattenContact(e.TechContact),
+		"csr":                               flattenCSR(e.CSR),
+		"network_configuration":             flattenNetworkConfiguration(e.NetworkConfiguration),
+		"org":                               flattenOrganization(e.Org),
+		"third_party":                       flattenThirdParty(e.ThirdParty),
+		"pending_changes":                   e.PendingChanges,
+	}
+
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return fmt.Errorf("%w: %s", err, k)
+		}
+	}
+	return nil
+}
+
+func flattenContact(c *contact) []interface{} {
+	if c == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"first_name":        c.FirstName,
+		"last_name":         c.LastName,
+		"title":             c.Title,
+		"organization_name": c.OrganizationName,
+		"email":             c.Email,
+		"phone":             c.Phone,
+		"address_line_one":  c.AddressLineOne,
+		"address_line_two":  c.AddressLineTwo,
+		"city":              c.City,
+		"region":            c.Region,
+		"postal_code":       c.PostalCode,
+		"country":           c.Country,
+	}}
+}
+
+func flattenCSR(c *csr) []interface{} {
+	if c == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"cn":   c.CN,
+		"c":    c.C,
+		"st":   c.ST,
+		"l":    c.L,
+		"o":    c.O,
+		"ou":   c.OU,
+		"sans": c.SANs,
+	}}
+}
+
+func flattenNetworkConfiguration(nc *networkConfiguration) []interface{} {
+	if nc == nil {
+		return nil
+	}
+
+	out := map[string]interface{}{
+		"disallowed_tls_versions": nc.DisallowedTLSVersions,
+		"geography":               nc.Geography,
+		"must_have_ciphers":       nc.MustHaveCiphers,
+		"network_type":            nc.NetworkType,
+		"ocsp_stapling":           nc.OCSPStapling,
+		"preferred_ciphers":       nc.PreferredCiphers,
+		"quic_enabled":            nc.QUICEnabled,
+		"secure_network":          nc.SecureNetwork,
+		"sni_only":                nc.SNIOnly,
+	}
+	if nc.DNSNameSettings != nil {
+		out["dns_name_settings"] = []interface{}{map[string]interface{}{
+			"clone_dns_names": nc.DNSNameSettings.CloneDNSNames,
+			"dns_names":       nc.DNSNameSettings.DNSNames,
+		}}
+	}
+	return []interface{}{out}
+}
+
+func flattenOrganization(o *organization) []interface{} {
+	if o == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{
+		"name":             o.Name,
+		"phone":            o.Phone,
+		"address_line_one": o.AddressLineOne,
+		"address_line_two": o.AddressLineTwo,
+		"city":             o.City,
+		"region":           o.Region,
+		"postal_code":      o.PostalCode,
+		"country":          o.Country,
+	}}
+}
+
+func flattenThirdParty(t *thirdParty) []interface{} {
+	if t == nil {
+		return nil
+	}
+
+	return []interface{}{map[string]interface{}{"exclude_sans": t.ExcludeSANs}}
+}
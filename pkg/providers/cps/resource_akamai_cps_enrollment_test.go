@@ -0,0 +1,433 @@
+package cps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cps "github.com/akamai/AkamaiOPEN-edgegrid-golang/cps-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiCPSEnrollment_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAkamaiCPSEnrollmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAkamaiCPSEnrollmentConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akamai_cps_enrollment.test", "location"),
+					resource.TestCheckResourceAttr("akamai_cps_enrollment.test", "validation_type", "dv"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAkamaiCPSEnrollmentConfig = `
+provider "akamai" {
+  cps_section = "cps"
+}
+
+data "akamai_contract" "contract" {
+}
+
+resource "akamai_cps_enrollment" "test" {
+  contract = data.akamai_contract.contract.id
+  certificate_type = "san"
+  ra = "lets-encrypt"
+  validation_type = "dv"
+  wait_on_complete = false
+
+  admin_contact {
+    first_name = "Terraform"
+    last_name = "Test"
+    email = "noreply@example.com"
+    phone = "+1 617 444 3000"
+  }
+
+  tech_contact {
+    first_name = "Terraform"
+    last_name = "Test"
+    email = "noreply@akamai.com"
+    phone = "+1 617 444 3000"
+  }
+
+  csr {
+    cn = "terraform-test.example.com"
+    c = "US"
+    st = "MA"
+    l = "Cambridge"
+    o = "Akamai Technologies"
+    sans = ["terraform-test.example.com"]
+  }
+
+  network_configuration {
+    geography = "core"
+    secure_network = "enhanced-tls"
+  }
+
+  org {
+    name = "Akamai Technologies"
+    country = "US"
+  }
+}
+`
+
+func testAccCheckAkamaiCPSEnrollmentDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "akamai_cps_enrollment" {
+			continue
+		}
+
+		id, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if _, err := getEnrollment(id); err == nil {
+			return fmt.Errorf("enrollment %d still exists", id)
+		}
+	}
+	return nil
+}
+
+// fakeCPS serves the enrollment endpoints used by the resource from the mock server
+type fakeCPS struct {
+	lock        sync.Mutex
+	enrollments map[int]map[string]interface{}
+	changes     map[string]string
+	nextID      int
+
+	acknowledged   int
+	failValidation bool
+}
+
+func newFakeCPS(srv *mockapi.Server) *fakeCPS {
+	f := &fakeCPS{
+		enrollments: make(map[int]map[string]interface{}),
+		changes:     make(map[string]string),
+		nextID:      10000,
+	}
+
+	srv.Handle("POST", `/cps/v2/enrollments`, f.create)
+	srv.Handle("GET", `/cps/v2/enrollments/(\d+)`, f.get)
+	srv.Handle("PUT", `/cps/v2/enrollments/(\d+)`, f.update)
+	srv.Handle("DELETE", `/cps/v2/enrollments/(\d+)`, f.delete)
+	srv.Handle("GET", `/cps/v2/enrollments/(\d+)/changes/(\d+)`, f.change)
+	srv.Handle("GET", `/cps/v2/enrollments/(\d+)/changes/(\d+)/input/info/lets-encrypt-challenges`, f.challenges)
+	srv.Handle("POST", `/cps/v2/enrollments/(\d+)/changes/(\d+)/input/update/lets-encrypt-challenges-completed`, f.acknowledge)
+
+	return f
+}
+
+func (f *fakeCPS) store(w http.ResponseWriter, r *http.Request, id int) {
+	var body map[string]interface{}
+	data, _ := ioutil.ReadAll(r.Body)
+	if err := json.Unmarshal(data, &body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.nextID++
+	change := fmt.Sprintf("/cps/v2/enrollments/%d/changes/%d", id, f.nextID)
+	f.changes[change] = "coordinate-domain-validation"
+	body["location"] = fmt.Sprintf("/cps/v2/enrollments/%d", id)
+	body["pendingChanges"] = []string{change}
+	f.enrollments[id] = body
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"enrollment": body["location"],
+		"changes":    []string{change},
+	})
+}
+
+func (f *fakeCPS) create(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.nextID++
+	f.store(w, r, f.nextID)
+}
+
+func (f *fakeCPS) get(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	e, ok := f.enrollments[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+func (f *fakeCPS) update(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	if _, ok := f.enrollments[id]; !ok || r.URL.Query().Get("allow-cancel-pending-changes") != "true" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	f.store(w, r, id)
+}
+
+func (f *fakeCPS) delete(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	delete(f.enrollments, id)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"enrollment": params[0], "changes": []string{}})
+}
+
+func (f *fakeCPS) change(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	status, ok := f.changes[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	state := changeStateAwaitingInput
+	if status == "error" {
+		state = changeStateError
+	}
+
+	input := []interface{}{}
+	if status == "coordinate-domain-validation" {
+		input = append(input, map[string]interface{}{
+			"type":              inputLetsEncryptChallenges,
+			"requiredToProceed": true,
+			"info":              r.URL.Path + "/input/info/lets-encrypt-challenges",
+			"update":            r.URL.Path + "/input/update/lets-encrypt-challenges-completed",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"statusInfo": map[string]interface{}{
+			"status":      status,
+			"state":       state,
+			"description": "Waiting for domain validation",
+		},
+		"allowedInput": input,
+	})
+}
+
+func (f *fakeCPS) challenges(w http.ResponseWriter, r *http.Request, params []string) {
+	if r.Header.Get("Accept") != dvChallengesType {
+		w.WriteHeader(http.StatusNotAcceptable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"dv": []interface{}{
+			map[string]interface{}{
+				"domain":           "www.example.com",
+				"validationStatus": "RESPONSE_GENERATED",
+				"challenges": []interface{}{
+					map[string]interface{}{
+						"type":         "dns-01",
+						"fullPath":     "_acme-challenge.www.example.com.",
+						"responseBody": "dns-token",
+						"status":       "pending",
+					},
+					map[string]interface{}{
+						"type":         "http-01",
+						"fullPath":     "http://www.example.com/.well-known/acme-challenge/token",
+						"responseBody": "http-token",
+						"status":       "pending",
+					},
+				},
+			},
+		},
+	})
+}
+
+func (f *fakeCPS) acknowledge(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var body map[string]string
+	data, _ := ioutil.ReadAll(r.Body)
+	if r.Header.Get("Content-Type") != acknowledgementType || json.Unmarshal(data, &body) != nil || body["acknowledgement"] != "acknowledge" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status := "wait-review-pre-verification-safety-checks"
+	if f.failValidation {
+		status = "error"
+	}
+	f.changes[fmt.Sprintf("/cps/v2/enrollments/%s/changes/%s", params[0], params[1])] = status
+	f.acknowledged++
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"change": r.URL.Path})
+}
+
+func testEnrollmentConfig() map[string]interface{} {
+	contact := []interface{}{map[string]interface{}{
+		"first_name": "Terraform",
+		"last_name":  "Test",
+		"email":      "noreply@example.com",
+		"phone":      "+1 617 444 3000",
+	}}
+
+	return map[string]interface{}{
+		"contract":         "ctr_1-MOCK",
+		"certificate_type": "san",
+		"ra":               "lets-encrypt",
+		"validation_type":  "dv",
+		"admin_contact":    contact,
+		"tech_contact":     contact,
+		"csr": []interface{}{map[string]interface{}{
+			"cn":   "www.example.com",
+			"sans": []interface{}{"www.example.com", "static.example.com"},
+		}},
+		"network_configuration": []interface{}{map[string]interface{}{
+			"geography":      "core",
+			"secure_network": "enhanced-tls",
+			"dns_name_settings": []interface{}{map[string]interface{}{
+				"clone_dns_names": true,
+				"dns_names":       []interface{}{"www.example.com"},
+			}},
+		}},
+	}
+}
+
+func TestResourceCPSEnrollment_lifecycle(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	d := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, testEnrollmentConfig())
+	require.NoError(t, resourceCPSEnrollmentCreate(d, nil))
+
+	assert.Equal(t, "10001", d.Id())
+	assert.Equal(t, "/cps/v2/enrollments/10001", d.Get("location"))
+	assert.Equal(t, "coordinate-domain-validation", d.Get("change_status"))
+	assert.Len(t, d.Get("pending_changes"), 1)
+	assert.Equal(t, 2, d.Get("csr.0.sans").(*schema.Set).Len())
+	assert.Equal(t, true, d.Get("network_configuration.0.dns_name_settings.0.clone_dns_names"))
+
+	sent := fake.enrollments[10001]
+	assert.Equal(t, "lets-encrypt", sent["ra"])
+	assert.Equal(t, "www.example.com", sent["csr"].(map[string]interface{})["cn"])
+
+	require.NoError(t, resourceCPSEnrollmentUpdate(d, nil))
+
+	require.NoError(t, resourceCPSEnrollmentDelete(d, nil))
+	assert.Equal(t, "", d.Id())
+	assert.Empty(t, fake.enrollments)
+}
+
+func TestResourceCPSEnrollment_import(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	d := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, testEnrollmentConfig())
+	d.Set("wait_on_complete", false)
+	require.NoError(t, resourceCPSEnrollmentCreate(d, nil))
+
+	imported := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, map[string]interface{}{})
+	imported.SetId(d.Id() + ":ctr_1-MOCK")
+	out, err := resourceCPSEnrollmentImport(imported, nil)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+
+	assert.Equal(t, d.Id(), imported.Id())
+	assert.Equal(t, "1-MOCK", imported.Get("contract"))
+	assert.Equal(t, "www.example.com", imported.Get("csr.0.cn"))
+
+	missing := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, map[string]interface{}{})
+	missing.SetId("99999")
+	_, err = resourceCPSEnrollmentImport(missing, nil)
+	assert.Error(t, err)
+
+	bad := schema.TestResourceDataRaw(t, resourceCPSEnrollment().Schema, map[string]interface{}{})
+	bad.SetId("not-an-id")
+	_, err = resourceCPSEnrollmentImport(bad, nil)
+	assert.Error(t, err)
+}
+
+func TestWaitForChange_error(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCPS(srv)
+
+	cps.Init(srv.Config())
+	changePollInterval = 0
+
+	fake.changes["/cps/v2/enrollments/1/changes/2"] = "error"
+	_, err := waitForChange(hclog.NewNullLogger(), "/cps/v2/enrollments/1/changes/2")
+	assert.True(t, errors.Is(err, ErrChangeFailed), "unexpected error: %v", err)
+
+	changePollTimeout = -time.Second
+	defer func() { changePollTimeout = 90 * time.Minute }()
+	fake.changes["/cps/v2/enrollments/1/changes/3"] = "coordinate-domain-validation"
+	_, err = pollChange(hclog.NewNullLogger(), "/cps/v2/enrollments/1/changes/3", func(*changeStatus) bool { return false })
+	assert.True(t, errors.Is(err, apiclient.ErrPollTimeout), "unexpected error: %v", err)
+}
+
+func TestEnrollmentID(t *testing.T) {
+	tests := map[string]struct {
+		location string
+		expected int
+		wantErr  bool
+	}{
+		"enrollment": {location: "/cps/v2/enrollments/10002", expected: 10002},
+		"change":     {location: "/cps/v2/enrollments/10002/changes/3", expected: 10002},
+		"invalid":    {location: "/papi/v1/properties/prp_1", wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := enrollmentID(test.location)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, id)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package datastream
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// activation statuses of a stream, besides the shared apiclient ones
+	statusActivating   = "ACTIVATING"
+	statusDeactivating = "DEACTIVATING"
+	statusInactive     = "INACTIVE"
+)
+
+var (
+	// api sends the requests to the DataStream API
+	api apiclient.Client
+
+	// activationPollInterval and activationPollTimeout bound waitForStatus, activations
+	// usually take about an hour
+	activationPollInterval = time.Minute
+	activationPollTimeout  = 120 * time.Minute
+)
+
+type (
+	streamConfig struct {
+		Delimiter        string    `json:"delimiter,omitempty"`
+		Format           string    `json:"format"`
+		Frequency        frequency `json:"frequency"`
+		UploadFilePrefix string    `json:"uploadFilePrefix,omitempty"`
+		UploadFileSuffix string    `json:"uploadFileSuffix,omitempty"`
+	}
+
+	frequency struct {
+		TimeInSec int `json:"timeInSec"`
+	}
+
+	// connector holds the fields of every connector type, secrets are never returned by the API
+	connector struct {
+		ConnectorType       string `json:"connectorType"`
+		ConnectorName       string `json:"connectorName"`
+		ConnectorID         int    `json:"connectorId,omitempty"`
+		Bucket              string `json:"bucket,omitempty"`
+		Path                string `json:"path,omitempty"`
+		Region              string `json:"region,omitempty"`
+		AccessKey           string `json:"accessKey,omitempty"`
+		SecretAccessKey     string `json:"secretAccessKey,omitempty"`
+		URL                 string `json:"url,omitempty"`
+		EventCollectorToken string `json:"eventCollectorToken,omitempty"`
+		AuthToken           string `json:"authToken,omitempty"`
+		Service             string `json:"service,omitempty"`
+		Source              string `json:"source,omitempty"`
+		Tags                string `json:"tags,omitempty"`
+		AuthenticationType  string `json:"authenticationType,omitempty"`
+		UserName            string `json:"userName,omitempty"`
+		Password            string `json:"password,omitempty"`
+		CompressLogs        bool   `json:"compressLogs"`
+	}
+
+	// streamRequest is the body of stream creations and updates
+	streamRequest struct {
+		StreamName      string       `json:"streamName"`
+		StreamType      string       `json:"streamType"`
+		TemplateName    string       `json:"templateName"`
+		GroupID         int          `json:"groupId"`
+		ContractID      string       `json:"contractId"`
+		ProductID       string       `json:"productId,omitempty"`
+		PropertyIDs     []int        `json:"propertyIds"`
+		DatasetFieldIDs []int        `json:"datasetFieldIds"`
+		EmailIDs        string       `json:"emailIds,omitempty"`
+		Config          streamConfig `json:"config"`
+		Connectors      []connector  `json:"connectors"`
+	}
+
+	streamVersionKey struct {
+		StreamID        int `json:"streamId"`
+		StreamVersionID int `json:"streamVersionId"`
+	}
+
+	streamResponse struct {
+		StreamVersionKey streamVersionKey `json:"streamVersionKey"`
+	}
+
+	streamProperty struct {
+		PropertyID   int    `json:"propertyId"`
+		PropertyName string `json:"propertyName"`
+	}
+
+	datasetField struct {
+		DatasetFieldID int `json:"datasetFieldId"`
+	}
+
+	dataset struct {
+		DatasetFields []datasetField `json:"datasetFields"`
+	}
+
+	// stream is a stream as returned by the API
+	stream struct {
+		StreamID         int              `json:"streamId"`
+		StreamVersionID  int              `json:"streamVersionId"`
+		StreamName       string           `json:"streamName"`
+		StreamType       string           `json:"streamType"`
+		TemplateName     string           `json:"templateName"`
+		GroupID          int              `json:"groupId"`
+		ContractID       string           `json:"contractId"`
+		ProductID        string           `json:"productId"`
+		Properties       []streamProperty `json:"properties"`
+		Datasets         []dataset        `json:"datasets"`
+		EmailIDs         string           `json:"emailIds"`
+		Config           streamConfig     `json:"config"`
+		Connectors       []connector      `json:"connectors"`
+		ActivationStatus string           `json:"activationStatus"`
+	}
+)
+
+func streamLocation(id int) string {
+	return fmt.Sprintf("/datastream-config-api/v1/log/streams/%d", id)
+}
+
+func getStream(id int) (*stream, error) {
+	var s stream
+	if err := api.DoRequest(http.MethodGet, streamLocation(id), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// waitForStatus polls the stream until it has the status
+func waitForStatus(log hclog.Logger, id int, status string) (*stream, error) {
+	var s *stream
+	err := apiclient.WaitForActivation(activationPollInterval, activationPollTimeout, func() (bool, error) {
+		var err error
+		if s, err = getStream(id); err != nil {
+			return false, err
+		}
+		log.Debug(fmt.Sprintf("stream %d status [%s]", id, s.ActivationStatus))
+		return s.ActivationStatus == status, nil
+	})
+	if errors.Is(err, apiclient.ErrActivationTimeout) {
+		return s, fmt.Errorf("%w: stream %d is %s, expected %s", err, id, s.ActivationStatus, status)
+	}
+	return s, err
+}
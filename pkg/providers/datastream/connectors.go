@@ -0,0 +1,225 @@
+package datastream
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// connector blocks by API connector type, a stream delivers to exactly one of them
+var connectorBlocks = map[string]string{
+	"S3":      "s3_connector",
+	"SPLUNK":  "splunk_connector",
+	"DATADOG": "datadog_connector",
+	"HTTPS":   "https_connector",
+}
+
+// connectorSecrets are the fields of each connector block the API never returns
+var connectorSecrets = map[string][]string{
+	"s3_connector":      {"access_key", "secret_access_key"},
+	"splunk_connector":  {"event_collector_token"},
+	"datadog_connector": {"auth_token"},
+	"https_connector":   {"password"},
+}
+
+func connectorNames() []string {
+	return []string{"s3_connector", "splunk_connector", "datadog_connector", "https_connector"}
+}
+
+func connectorBlock(attrs map[string]*schema.Schema) *schema.Schema {
+	attrs["connector_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	attrs["connector_id"] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		MaxItems:     1,
+		ExactlyOneOf: connectorNames(),
+		Elem:         &schema.Resource{Schema: attrs},
+	}
+}
+
+func sensitiveString() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Required:  true,
+		Sensitive: true,
+	}
+}
+
+func connectorSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"s3_connector": connectorBlock(map[string]*schema.Schema{
+			"bucket": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"access_key":        sensitiveString(),
+			"secret_access_key": sensitiveString(),
+		}),
+		"splunk_connector": connectorBlock(map[string]*schema.Schema{
+			"url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The raw event collector endpoint",
+			},
+			"event_collector_token": sensitiveString(),
+			"compress_logs": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		}),
+		"datadog_connector": connectorBlock(map[string]*schema.Schema{
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"auth_token": sensitiveString(),
+			"service": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"tags": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"compress_logs": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		}),
+		"https_connector": connectorBlock(map[string]*schema.Schema{
+			"url": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"authentication_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "NONE",
+				ValidateFunc: validation.StringInSlice([]string{"NONE", "BASIC"}, false),
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"compress_logs": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		}),
+	}
+}
+
+// connectorsFromResourceData returns the configured connector
+func connectorsFromResourceData(d *schema.ResourceData) []connector {
+	for apiType, block := range connectorBlocks {
+		list := d.Get(block).([]interface{})
+		if len(list) == 0 || list[0] == nil {
+			continue
+		}
+		m := list[0].(map[string]interface{})
+		str := func(k string) string {
+			v, _ := m[k].(string)
+			return v
+		}
+		compress, _ := m["compress_logs"].(bool)
+
+		return []connector{{
+			ConnectorType:       apiType,
+			ConnectorName:       str("connector_name"),
+			Bucket:              str("bucket"),
+			Path:                str("path"),
+			Region:              str("region"),
+			AccessKey:           str("access_key"),
+			SecretAccessKey:     str("secret_access_key"),
+			URL:                 str("url"),
+			EventCollectorToken: str("event_collector_token"),
+			AuthToken:           str("auth_token"),
+			Service:             str("service"),
+			Source:              str("source"),
+			Tags:                str("tags"),
+			AuthenticationType:  str("authentication_type"),
+			UserName:            str("user_name"),
+			Password:            str("password"),
+			CompressLogs:        compress,
+		}}
+	}
+	return nil
+}
+
+// setConnectors sets the connector blocks from the API, keeping the secrets of the state
+func setConnectors(d *schema.ResourceData, connectors []connector) error {
+	blocks := make(map[string][]interface{})
+	for _, c := range connectors {
+		block, ok := connectorBlocks[c.ConnectorType]
+		if !ok {
+			continue
+		}
+
+		var m map[string]interface{}
+		switch block {
+		case "s3_connector":
+			m = map[string]interface{}{
+				"bucket": c.Bucket,
+				"path":   c.Path,
+				"region": c.Region,
+			}
+		case "splunk_connector":
+			m = map[string]interface{}{
+				"url":           c.URL,
+				"compress_logs": c.CompressLogs,
+			}
+		case "datadog_connector":
+			m = map[string]interface{}{
+				"url":           c.URL,
+				"service":       c.Service,
+				"source":        c.Source,
+				"tags":          c.Tags,
+				"compress_logs": c.CompressLogs,
+			}
+		case "https_connector":
+			m = map[string]interface{}{
+				"url":                 c.URL,
+				"authentication_type": c.AuthenticationType,
+				"user_name":           c.UserName,
+				"compress_logs":       c.CompressLogs,
+			}
+		}
+		m["connector_name"] = c.ConnectorName
+		m["connector_id"] = c.ConnectorID
+		for _, secret := range connectorSecrets[block] {
+			m[secret] = d.Get(block + ".0." + secret)
+		}
+		blocks[block] = []interface{}{m}
+	}
+
+	for _, block := range connectorNames() {
+		if err := d.Set(block, blocks[block]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
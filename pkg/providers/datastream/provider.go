@@ -0,0 +1,140 @@
+package datastream
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"datastream_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("datastream_section"),
+			},
+			"datastream": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("datastream"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_datastream": resourceDataStream(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigDataStreamService(d resourceData) (*edgegrid.Config, error) {
+	var DataStreamConfig edgegrid.Config
+	if _, ok := d.GetOk("datastream"); ok {
+		log.Printf("[DEBUG] Setting datastream config via HCL")
+		config := d.Get("datastream").(set).List()[0].(map[string]interface{})
+
+		DataStreamConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(DataStreamConfig)
+		return &DataStreamConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("datastream_section"); ok && section != "default" {
+		DataStreamConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		DataStreamConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(DataStreamConfig)
+	return &DataStreamConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "datastream"
+}
+
+// DataStreamProviderVersion update version string anytime provider adds new features
+const DataStreamProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return DataStreamProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigDataStreamService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
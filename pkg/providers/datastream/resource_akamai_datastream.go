@@ -0,0 +1,409 @@
+package datastream
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DataStream log delivery stream
+//
+// https://developer.akamai.com/api/core_features/datastream/v1.html#stream
+func resourceDataStream() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"stream_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"stream_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "RAW_LOGS",
+			ValidateFunc: validation.StringInSlice([]string{"RAW_LOGS"}, false),
+		},
+		"template_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Default:  "EDGE_LOGS",
+		},
+		"group_id": {
+			Type:      schema.TypeString,
+			Required:  true,
+			ForceNew:  true,
+			StateFunc: trimPrefixStateFunc("grp_"),
+		},
+		"contract_id": {
+			Type:      schema.TypeString,
+			Required:  true,
+			ForceNew:  true,
+			StateFunc: trimPrefixStateFunc("ctr_"),
+		},
+		"product_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+		"property_ids": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The properties whose logs are streamed, with or without the prp_ prefix",
+		},
+		"dataset_fields": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			MinItems:    1,
+			Elem:        &schema.Schema{Type: schema.TypeInt},
+			Description: "IDs of the data set fields to log",
+		},
+		"email_ids": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Email addresses notified of stream activations and failures",
+		},
+		"config": {
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"format": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "STRUCTURED",
+						ValidateFunc: validation.StringInSlice([]string{"STRUCTURED", "JSON"}, false),
+					},
+					"delimiter": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The field delimiter of STRUCTURED logs, SPACE",
+					},
+					"frequency_in_seconds": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Default:      30,
+						ValidateFunc: validation.IntInSlice([]int{30, 60}),
+					},
+					"upload_file_prefix": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"upload_file_suffix": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Required:    true,
+			Description: "Whether the stream is activated, activations take up to an hour",
+		},
+		"stream_version_id": {
+			Type:     schema.TypeInt,
+			Computed: true,
+		},
+		"activation_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	for k, v := range connectorSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		Create: resourceDataStreamCreate,
+		Read:   resourceDataStreamRead,
+		Update: resourceDataStreamUpdate,
+		Delete: resourceDataStreamDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: s,
+	}
+}
+
+func resourceDataStreamCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("DATASTREAM", "resourceDataStreamCreate")
+
+	req, err := streamRequestFromResourceData(d)
+	if err != nil {
+		return err
+	}
+
+	active := d.Get("active").(bool)
+	log.Debug(fmt.Sprintf("creating stream %q", req.StreamName))
+	var res streamResponse
+	path := fmt.Sprintf("/datastream-config-api/v1/log/streams?activate=%t", active)
+	if err := api.DoRequest(http.MethodPost, path, req, &res); err != nil {
+		log.Error(fmt.Sprintf("stream create failed: %s", err))
+		return apiclient.Error(err)
+	}
+	d.SetId(strconv.Itoa(res.StreamVersionKey.StreamID))
+
+	if active {
+		if _, err := waitForStatus(log, res.StreamVersionKey.StreamID, apiclient.StatusActivated); err != nil {
+			return apiclient.Error(err)
+		}
+	}
+	return resourceDataStreamRead(d, meta)
+}
+
+func resourceDataStreamRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("DATASTREAM", "resourceDataStreamRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid stream ID %q", d.Id())
+	}
+
+	s, err := getStream(id)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("stream %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	// keep the prp_ prefix when the property IDs were configured with it
+	configured := make(map[string]string)
+	for _, v := range d.Get("property_ids").(*schema.Set).List() {
+		configured[strings.TrimPrefix(v.(string), "prp_")] = v.(string)
+	}
+	properties := make([]interface{}, 0, len(s.Properties))
+	for _, p := range s.Properties {
+		id := strconv.Itoa(p.PropertyID)
+		if v, ok := configured[id]; ok {
+			id = v
+		}
+		properties = append(properties, id)
+	}
+
+	fields := make([]interface{}, 0)
+	for _, ds := range s.Datasets {
+		for _, f := range ds.DatasetFields {
+			fields = append(fields, f.DatasetFieldID)
+		}
+	}
+
+	var emails []interface{}
+	for _, e := range strings.Split(s.EmailIDs, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			emails = append(emails, e)
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"stream_name":    s.StreamName,
+		"stream_type":    s.StreamType,
+		"template_name":  s.TemplateName,
+		"group_id":       strconv.Itoa(s.GroupID),
+		"contract_id":    strings.TrimPrefix(s.ContractID, "ctr_"),
+		"product_id":     s.ProductID,
+		"property_ids":   schema.NewSet(schema.HashString, properties),
+		"dataset_fields": schema.NewSet(schema.HashInt, fields),
+		"email_ids":      emails,
+		"config": []interface{}{map[string]interface{}{
+			"format":               s.Config.Format,
+			"delimiter":            s.Config.Delimiter,
+			"frequency_in_seconds": s.Config.Frequency.TimeInSec,
+			"upload_file_prefix":   s.Config.UploadFilePrefix,
+			"upload_file_suffix":   s.Config.UploadFileSuffix,
+		}},
+		"active":            s.ActivationStatus == apiclient.StatusActivated || s.ActivationStatus == statusActivating,
+		"stream_version_id": s.StreamVersionID,
+		"activation_status": s.ActivationStatus,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return setConnectors(d, s.Connectors)
+}
+
+func resourceDataStreamUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("DATASTREAM", "resourceDataStreamUpdate")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid stream ID %q", d.Id())
+	}
+
+	// compare with the status of the stream rather than the state, which may be stale after
+	// a failed apply
+	current, err := getStream(id)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+	wasActive := current.ActivationStatus == apiclient.StatusActivated || current.ActivationStatus == statusActivating
+	active := d.Get("active").(bool)
+
+	changed := []string{"stream_name", "property_ids", "dataset_fields", "email_ids", "config"}
+	changed = append(changed, connectorNames()...)
+	if d.HasChanges(changed...) {
+		req, err := streamRequestFromResourceData(d)
+		if err != nil {
+			return err
+		}
+		// updating an active stream activates the new version
+		log.Debug(fmt.Sprintf("updating stream %d", id))
+		path := fmt.Sprintf("%s?activate=%t", streamLocation(id), wasActive && active)
+		if err := api.DoRequest(http.MethodPut, path, req, nil); err != nil {
+			log.Error(fmt.Sprintf("stream update failed: %s", err))
+			return apiclient.Error(err)
+		}
+		if wasActive && active {
+			if _, err := waitForStatus(log, id, apiclient.StatusActivated); err != nil {
+				return apiclient.Error(err)
+			}
+		}
+	}
+
+	if active != wasActive {
+		if err := setActivation(id, active); err != nil {
+			return err
+		}
+	}
+
+	return resourceDataStreamRead(d, meta)
+}
+
+// Streams must be deactivated to be deleted
+func resourceDataStreamDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("DATASTREAM", "resourceDataStreamDelete")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid stream ID %q", d.Id())
+	}
+
+	s, err := getStream(id)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+	switch s.ActivationStatus {
+	case statusActivating:
+		if _, err := waitForStatus(log, id, apiclient.StatusActivated); err != nil {
+			return apiclient.Error(err)
+		}
+		fallthrough
+	case apiclient.StatusActivated:
+		if err := setActivation(id, false); err != nil {
+			return err
+		}
+	case statusDeactivating:
+		if _, err := waitForStatus(log, id, apiclient.StatusDeactivated); err != nil {
+			return apiclient.Error(err)
+		}
+	}
+
+	log.Debug(fmt.Sprintf("deleting stream %d", id))
+	if err := api.DoRequest(http.MethodDelete, streamLocation(id), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("stream delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// setActivation activates or deactivates the stream and waits for the new status
+func setActivation(id int, activate bool) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("DATASTREAM", "setActivation")
+
+	action, status := "activate", apiclient.StatusActivated
+	if !activate {
+		action, status = "deactivate", apiclient.StatusDeactivated
+	}
+
+	log.Debug(fmt.Sprintf("%s stream %d", action, id))
+	if err := api.DoRequest(http.MethodPut, streamLocation(id)+"/"+action, nil, nil); err != nil {
+		log.Error(fmt.Sprintf("stream %s failed: %s", action, err))
+		return apiclient.Error(err)
+	}
+	if _, err := waitForStatus(log, id, status); err != nil {
+		return apiclient.Error(err)
+	}
+	return nil
+}
+
+func streamRequestFromResourceData(d *schema.ResourceData) (*streamRequest, error) {
+	group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+	}
+
+	var properties []int
+	for _, v := range d.Get("property_ids").(*schema.Set).List() {
+		id, err := strconv.Atoi(strings.TrimPrefix(v.(string), "prp_"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid property ID %q", v)
+		}
+		properties = append(properties, id)
+	}
+	sort.Ints(properties)
+
+	var fields []int
+	for _, v := range d.Get("dataset_fields").(*schema.Set).List() {
+		fields = append(fields, v.(int))
+	}
+	sort.Ints(fields)
+
+	var emails []string
+	for _, v := range d.Get("email_ids").([]interface{}) {
+		emails = append(emails, v.(string))
+	}
+
+	cfg := d.Get("config").([]interface{})[0].(map[string]interface{})
+	return &streamRequest{
+		StreamName:      d.Get("stream_name").(string),
+		StreamType:      d.Get("stream_type").(string),
+		TemplateName:    d.Get("template_name").(string),
+		GroupID:         group,
+		ContractID:      strings.TrimPrefix(d.Get("contract_id").(string), "ctr_"),
+		ProductID:       d.Get("product_id").(string),
+		PropertyIDs:     properties,
+		DatasetFieldIDs: fields,
+		EmailIDs:        strings.Join(emails, ","),
+		Config: streamConfig{
+			Format:           cfg["format"].(string),
+			Delimiter:        cfg["delimiter"].(string),
+			Frequency:        frequency{TimeInSec: cfg["frequency_in_seconds"].(int)},
+			UploadFilePrefix: cfg["upload_file_prefix"].(string),
+			UploadFileSuffix: cfg["upload_file_suffix"].(string),
+		},
+		Connectors: connectorsFromResourceData(d),
+	}, nil
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
@@ -0,0 +1,256 @@
+package datastream
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDataStream serves the DataStream endpoints used by the provider from the mock server
+type fakeDataStream struct {
+	lock    sync.Mutex
+	nextID  int
+	streams map[int]*stream
+	// requests holds the last create or update request of each stream, with its secrets
+	requests map[int]streamRequest
+}
+
+func newFakeDataStream(srv *mockapi.Server) *fakeDataStream {
+	f := &fakeDataStream{
+		nextID:   7000,
+		streams:  make(map[int]*stream),
+		requests: make(map[int]streamRequest),
+	}
+
+	srv.Handle("POST", `/datastream-config-api/v1/log/streams`, f.createStream)
+	srv.Handle("GET", `/datastream-config-api/v1/log/streams/(\d+)`, f.getStream)
+	srv.Handle("PUT", `/datastream-config-api/v1/log/streams/(\d+)`, f.updateStream)
+	srv.Handle("DELETE", `/datastream-config-api/v1/log/streams/(\d+)`, f.deleteStream)
+	srv.Handle("PUT", `/datastream-config-api/v1/log/streams/(\d+)/(activate|deactivate)`, f.setActivation)
+
+	return f
+}
+
+// apply stores the request as the new version of the stream, without the secrets
+func (f *fakeDataStream) apply(s *stream, req streamRequest, activate bool) {
+	f.requests[s.StreamID] = req
+	s.StreamVersionID++
+	s.StreamName, s.StreamType, s.TemplateName = req.StreamName, req.StreamType, req.TemplateName
+	s.GroupID, s.ContractID, s.ProductID = req.GroupID, req.ContractID, "Download_Delivery"
+	s.EmailIDs, s.Config = req.EmailIDs, req.Config
+
+	s.Properties = nil
+	for _, id := range req.PropertyIDs {
+		s.Properties = append(s.Properties, streamProperty{PropertyID: id, PropertyName: "property-" + strconv.Itoa(id)})
+	}
+	var fields []datasetField
+	for _, id := range req.DatasetFieldIDs {
+		fields = append(fields, datasetField{DatasetFieldID: id})
+	}
+	s.Datasets = []dataset{{DatasetFields: fields}}
+
+	s.Connectors = nil
+	for i, c := range req.Connectors {
+		c.ConnectorID = i + 1
+		c.AccessKey, c.SecretAccessKey, c.EventCollectorToken, c.AuthToken, c.Password = "", "", "", "", ""
+		s.Connectors = append(s.Connectors, c)
+	}
+
+	if activate {
+		s.ActivationStatus = statusActivating
+	}
+}
+
+func (f *fakeDataStream) createStream(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var req streamRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if len(req.Connectors) != 1 || len(req.PropertyIDs) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	s := &stream{StreamID: f.nextID, ActivationStatus: statusInactive}
+	f.streams[s.StreamID] = s
+	f.apply(s, req, r.URL.Query().Get("activate") == "true")
+	mockapi.WriteJSON(w, http.StatusAccepted, streamResponse{StreamVersionKey: streamVersionKey{StreamID: s.StreamID, StreamVersionID: s.StreamVersionID}})
+}
+
+// getStream completes pending activations on the next poll
+func (f *fakeDataStream) getStream(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	s, ok := f.streams[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	out := *s
+	switch s.ActivationStatus {
+	case statusActivating:
+		s.ActivationStatus = apiclient.StatusActivated
+	case statusDeactivating:
+		s.ActivationStatus = apiclient.StatusDeactivated
+	}
+	mockapi.WriteJSON(w, http.StatusOK, out)
+}
+
+func (f *fakeDataStream) updateStream(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	s, ok := f.streams[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var req streamRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if len(req.Connectors) != 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.apply(s, req, r.URL.Query().Get("activate") == "true")
+	mockapi.WriteJSON(w, http.StatusAccepted, streamResponse{StreamVersionKey: streamVersionKey{StreamID: s.StreamID, StreamVersionID: s.StreamVersionID}})
+}
+
+func (f *fakeDataStream) deleteStream(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	s, ok := f.streams[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if s.ActivationStatus != apiclient.StatusDeactivated && s.ActivationStatus != statusInactive {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	delete(f.streams, id)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeDataStream) setActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	s, ok := f.streams[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if params[1] == "activate" {
+		s.ActivationStatus = statusActivating
+	} else {
+		s.ActivationStatus = statusDeactivating
+	}
+	mockapi.WriteJSON(w, http.StatusAccepted, streamResponse{StreamVersionKey: streamVersionKey{StreamID: s.StreamID, StreamVersionID: s.StreamVersionID}})
+}
+
+func testStreamConfig(active bool) map[string]interface{} {
+	return map[string]interface{}{
+		"stream_name":    "edge-logs",
+		"group_id":       "grp_42",
+		"contract_id":    "ctr_1-AB123",
+		"property_ids":   []interface{}{"prp_100", "200"},
+		"dataset_fields": []interface{}{1000, 1002},
+		"email_ids":      []interface{}{"ops@example.com", "sre@example.com"},
+		"config": []interface{}{
+			map[string]interface{}{"delimiter": "SPACE", "upload_file_prefix": "ak"},
+		},
+		"s3_connector": []interface{}{
+			map[string]interface{}{
+				"connector_name":    "logs",
+				"bucket":            "example-logs",
+				"path":              "edge",
+				"region":            "us-east-1",
+				"access_key":        "AKIAEXAMPLE",
+				"secret_access_key": "secret",
+			},
+		},
+		"active": active,
+	}
+}
+
+func TestResourceDataStream(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeDataStream(srv)
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	d := schema.TestResourceDataRaw(t, resourceDataStream().Schema, testStreamConfig(true))
+	require.NoError(t, resourceDataStreamCreate(d, nil))
+	assert.Equal(t, "7001", d.Id())
+	assert.Equal(t, apiclient.StatusActivated, d.Get("activation_status"))
+
+	req := fake.requests[7001]
+	assert.Equal(t, []int{100, 200}, req.PropertyIDs)
+	assert.Equal(t, "1-AB123", req.ContractID)
+	assert.Equal(t, "ops@example.com,sre@example.com", req.EmailIDs)
+	assert.Equal(t, streamConfig{Delimiter: "SPACE", Format: "STRUCTURED", Frequency: frequency{TimeInSec: 30}, UploadFilePrefix: "ak"}, req.Config)
+	assert.Equal(t, "S3", req.Connectors[0].ConnectorType)
+	assert.Equal(t, "secret", req.Connectors[0].SecretAccessKey)
+
+	// the secrets the API doesn't return and the configured property IDs are kept
+	assert.Equal(t, "secret", d.Get("s3_connector.0.secret_access_key"))
+	assert.Equal(t, 1, d.Get("s3_connector.0.connector_id"))
+	assert.True(t, d.Get("property_ids").(*schema.Set).Contains("prp_100"))
+	assert.Equal(t, "Download_Delivery", d.Get("product_id"))
+
+	// switching connectors of an active stream activates the new version
+	d.Set("s3_connector", nil)
+	d.Set("splunk_connector", []interface{}{
+		map[string]interface{}{
+			"connector_name":        "splunk",
+			"url":                   "https://splunk.example.com/services/collector/raw",
+			"event_collector_token": "token",
+			"compress_logs":         true,
+		},
+	})
+	require.NoError(t, resourceDataStreamUpdate(d, nil))
+	assert.Equal(t, "SPLUNK", fake.requests[7001].Connectors[0].ConnectorType)
+	assert.Equal(t, 2, d.Get("stream_version_id"))
+	assert.Equal(t, apiclient.StatusActivated, d.Get("activation_status"))
+	assert.Empty(t, d.Get("s3_connector"))
+	assert.Equal(t, "token", d.Get("splunk_connector.0.event_collector_token"))
+
+	d.Set("active", false)
+	require.NoError(t, resourceDataStreamUpdate(d, nil))
+	assert.Equal(t, apiclient.StatusDeactivated, fake.streams[7001].ActivationStatus)
+	assert.False(t, d.Get("active").(bool))
+
+	imported := schema.TestResourceDataRaw(t, resourceDataStream().Schema, map[string]interface{}{})
+	imported.SetId("7001")
+	require.NoError(t, resourceDataStreamRead(imported, nil))
+	assert.Equal(t, "edge-logs", imported.Get("stream_name"))
+	assert.True(t, imported.Get("property_ids").(*schema.Set).Contains("100"))
+	assert.Equal(t, "https://splunk.example.com/services/collector/raw", imported.Get("splunk_connector.0.url"))
+
+	// active streams are deactivated before they are deleted
+	other := schema.TestResourceDataRaw(t, resourceDataStream().Schema, testStreamConfig(true))
+	require.NoError(t, resourceDataStreamCreate(other, nil))
+	require.NoError(t, resourceDataStreamDelete(other, nil))
+	require.NoError(t, resourceDataStreamDelete(d, nil))
+	assert.Empty(t, fake.streams)
+}
@@ -0,0 +1,201 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+)
+
+// Changelist operations
+const (
+	changeAdd    = "ADD"
+	changeEdit   = "EDIT"
+	changeDelete = "DELETE"
+)
+
+// recordsetChange is a change added to a zone changelist
+type recordsetChange struct {
+	Name  string   `json:"name"`
+	Type  string   `json:"type"`
+	Op    string   `json:"op"`
+	TTL   int      `json:"ttl,omitempty"`
+	Rdata []string `json:"rdata,omitempty"`
+}
+
+// isApexRecordset reports whether the recordset is the SOA or an apex NS recordset,
+// which Edge DNS maintains for the zone
+func isApexRecordset(zone string, rs dnsv2.Recordset) bool {
+	return strings.EqualFold(rs.Type, RRTypeSoa) ||
+		strings.EqualFold(rs.Type, RRTypeNs) && strings.EqualFold(strings.TrimSuffix(rs.Name, "."), strings.TrimSuffix(zone, "."))
+}
+
+// listRecordsets returns every recordset of the zone in one request
+func listRecordsets(zone string) ([]dnsv2.Recordset, error) {
+	resp, err := dnsv2.GetRecordsets(zone, dnsv2.RecordsetQueryArgs{ShowAll: true})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Recordsets, nil
+}
+
+// normalizeRdata returns the rdata with single spaces between fields, lower case names
+// without the trailing dot and sorted, for comparison
+func normalizeRdata(rtype string, rdata []string) []string {
+	out := make([]string, 0, len(rdata))
+	for _, r := range rdata {
+		fields := strings.Fields(r)
+		for _, i := range nameFields[strings.ToUpper(rtype)] {
+			if i < len(fields) {
+				fields[i] = strings.ToLower(strings.TrimSuffix(fields[i], "."))
+			}
+		}
+		out = append(out, strings.Join(fields, " "))
+	}
+	sort.Strings(out)
+	return out
+}
+
+// equalRecordsets reports whether the recordsets have the same TTL and rdata
+func equalRecordsets(a, b dnsv2.Recordset) bool {
+	if a.TTL != b.TTL {
+		return false
+	}
+	ra, rb := normalizeRdata(a.Type, a.Rdata), normalizeRdata(b.Type, b.Rdata)
+	if len(ra) != len(rb) {
+		return false
+	}
+	for i := range ra {
+		if ra[i] != rb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRecordsetNames checks the recordsets are in the zone and that each name and
+// type appears once
+func checkRecordsetNames(zone string, recordsets []dnsv2.Recordset) error {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	seen := make(map[string]bool, len(recordsets))
+	for _, rs := range recordsets {
+		name := strings.ToLower(strings.TrimSuffix(rs.Name, "."))
+		if name != zone && !strings.HasSuffix(name, "."+zone) {
+			return fmt.Errorf("%s is outside of the zone", rs.Name)
+		}
+		key := recordsetKey(rs)
+		if seen[key] {
+			return fmt.Errorf("%s %s is defined more than once", rs.Name, rs.Type)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func recordsetKey(rs dnsv2.Recordset) string {
+	return strings.ToLower(strings.TrimSuffix(rs.Name, ".")) + " " + strings.ToUpper(rs.Type)
+}
+
+// diffRecordsets returns the changes turning the current recordsets of the zone into
+// the desired ones, leaving out the SOA and apex NS recordsets. Deletes come first so
+// a name can change type, e.g. from A to CNAME, in one changelist.
+func diffRecordsets(zone string, current, desired []dnsv2.Recordset) []recordsetChange {
+	existing := make(map[string]dnsv2.Recordset, len(current))
+	for _, rs := range current {
+		if !isApexRecordset(zone, rs) {
+			existing[recordsetKey(rs)] = rs
+		}
+	}
+	wanted := make(map[string]bool, len(desired))
+
+	var deletes, edits, adds []recordsetChange
+	for _, rs := range desired {
+		if isApexRecordset(zone, rs) {
+			continue
+		}
+		key := recordsetKey(rs)
+		wanted[key] = true
+		change := recordsetChange{Name: rs.Name, Type: strings.ToUpper(rs.Type), TTL: rs.TTL, Rdata: rs.Rdata}
+		old, ok := existing[key]
+		switch {
+		case !ok:
+			change.Op = changeAdd
+			adds = append(adds, change)
+		case !equalRecordsets(old, rs):
+			change.Op = changeEdit
+			edits = append(edits, change)
+		}
+	}
+	for key, rs := range existing {
+		if !wanted[key] {
+			deletes = append(deletes, recordsetChange{Name: rs.Name, Type: rs.Type, Op: changeDelete})
+		}
+	}
+
+	for _, changes := range [][]recordsetChange{deletes, edits, adds} {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Name != changes[j].Name {
+				return changes[i].Name < changes[j].Name
+			}
+			return changes[i].Type < changes[j].Type
+		})
+	}
+	return append(append(deletes, edits...), adds...)
+}
+
+// submitChangelist applies the changes to the zone as one changelist. The changelist
+// is discarded when adding a change or submitting it fails, so the zone is left untouched
+// and open to later changelists.
+func submitChangelist(zone string, changes []recordsetChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	w := getZoneWriter(zone)
+	w.Lock()
+	defer w.Unlock()
+
+	return writeChangelist(zone, changes)
+}
+
+// writeChangelist is submitChangelist for callers holding the writer of the zone.
+// Concurrency conflicts are returned as is, so they can be retried.
+func writeChangelist(zone string, changes []recordsetChange) error {
+	log.Printf("[DEBUG] [Akamai DNSv2] Creating changelist for zone [%s] with %d changes", zone, len(changes))
+	changelist := &dnsv2.ZoneCreate{Zone: zone}
+	if err := changelist.SaveChangelist(); err != nil {
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).ConcurrencyConflict() {
+			return err
+		}
+		return fmt.Errorf("creating changelist for zone %s: %s", zone, err)
+	}
+
+	for _, c := range changes {
+		if err := apiclient.DoRequest(dnsv2.Config, http.MethodPost, "/config-dns/v2/changelists/"+zone+"/recordsets/add-change", c, nil); err != nil {
+			discardChangelist(zone)
+			return fmt.Errorf("%s %s %s in zone %s: %w", strings.ToLower(c.Op), c.Name, c.Type, zone, err)
+		}
+	}
+
+	if err := changelist.SubmitChangelist(); err != nil {
+		discardChangelist(zone)
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).ConcurrencyConflict() {
+			return err
+		}
+		return fmt.Errorf("submitting changelist for zone %s: %s", zone, err)
+	}
+	return nil
+}
+
+// discardChangelist deletes the changelist of a zone, which would otherwise block any
+// later changelist of the zone
+func discardChangelist(zone string) {
+	log.Printf("[WARNING] [Akamai DNSv2] Discarding changelist for zone [%s]", zone)
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodDelete, "/config-dns/v2/changelists/"+zone, nil, nil); err != nil {
+		log.Printf("[ERROR] [Akamai DNSv2] Discarding changelist for zone [%s] failed: %s", zone, err)
+	}
+}
@@ -0,0 +1,32 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitChangelistDiscardsOnSubmitError(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	// deleting a missing recordset is only rejected on submit
+	err := submitChangelist("example.com", []recordsetChange{{Name: "missing.example.com", Type: "A", Op: changeDelete}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "submitting changelist for zone example.com")
+	assert.Contains(t, srv.Requests(), "DELETE /config-dns/v2/changelists/example.com")
+
+	// the zone is open to later changelists
+	require.NoError(t, submitChangelist("example.com", []recordsetChange{
+		{Name: "www.example.com", Type: "A", Op: changeAdd, TTL: 300, Rdata: []string{"10.0.0.1"}},
+	}))
+	rs, err := dnsv2.GetRecord("example.com", "www.example.com", "A")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, rs.Target)
+}
@@ -0,0 +1,48 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneAliases() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDNSZoneAliasesRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The primary zone the aliases point at",
+			},
+			"aliases": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneAliasesRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+
+	log.Printf("[DEBUG] [Akamai DNSv2] Listing aliases of zone [%s]", zone)
+
+	var resp struct {
+		Aliases []string `json:"aliases"`
+	}
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodGet, "/config-dns/v2/zones/"+url.PathEscape(zone)+"/aliases", nil, &resp); err != nil {
+		return fmt.Errorf("error listing aliases of zone %q: %w", zone, err)
+	}
+
+	d.Set("aliases", resp.Aliases)
+	d.SetId(zone)
+
+	return nil
+}
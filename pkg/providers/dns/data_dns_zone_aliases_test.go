@@ -0,0 +1,41 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceDNSZoneAliases(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+	testPrimaryZone(t, "example.net")
+	for _, name := range []string{"b.example.org", "a.example.org"} {
+		alias := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: name, Type: "ALIAS", Target: "example.com"})
+		require.NoError(t, alias.Save(dnsv2.ZoneQueryString{Contract: mockapi.ContractID}))
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceDNSZoneAliases().Schema, map[string]interface{}{"zone": "example.com"})
+	require.NoError(t, dataSourceDNSZoneAliasesRead(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+	assert.Equal(t, []interface{}{"a.example.org", "b.example.org"}, d.Get("aliases"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceDNSZoneAliases().Schema, map[string]interface{}{"zone": "example.net"})
+	require.NoError(t, dataSourceDNSZoneAliasesRead(d, nil))
+	assert.Empty(t, d.Get("aliases"))
+
+	d = schema.TestResourceDataRaw(t, dataSourceDNSZoneAliases().Schema, map[string]interface{}{"zone": "a.example.org"})
+	err := dataSourceDNSZoneAliasesRead(d, nil)
+	var apiErr client.APIError
+	require.True(t, errors.As(err, &apiErr), "the API error is kept")
+	assert.NotZero(t, apiErr.Status)
+}
@@ -0,0 +1,167 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneDNSSecStatus() *schema.Resource {
+	dnskeySchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key_tag": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"flags": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"algorithm": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"public_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+	recordsSchema := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"dnskey_record": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ds_record": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"expected_ttl": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_modified_date": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ksk": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dnskeySchema,
+			},
+			"zsk": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     dnskeySchema,
+			},
+			"ds_records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The SHA-256 DS records of the key signing keys, to hand to the registrar",
+			},
+		},
+	}
+
+	return &schema.Resource{
+		Read: dataSourceDNSZoneDNSSecStatusRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"alerts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"current_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     recordsSchema,
+			},
+			"new_records": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        recordsSchema,
+				Description: "The records of the keys replacing the current ones during a key rotation",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneDNSSecStatusRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+
+	log.Printf("[DEBUG] [Akamai DNSv2] Reading DNSSEC status of [%s]", zone)
+
+	status, err := getDNSSecStatus(zone)
+	if err != nil {
+		return fmt.Errorf("error reading DNSSEC status of %q: %s", zone, err)
+	}
+
+	current, err := flattenDNSSecRecords(zone, status.CurrentRecords)
+	if err != nil {
+		return fmt.Errorf("current DNSSEC records of %q: %s", zone, err)
+	}
+	pending, err := flattenDNSSecRecords(zone, status.NewRecords)
+	if err != nil {
+		return fmt.Errorf("new DNSSEC records of %q: %s", zone, err)
+	}
+
+	d.Set("alerts", status.Alerts)
+	d.Set("current_records", current)
+	d.Set("new_records", pending)
+	d.SetId(zone)
+
+	return nil
+}
+
+func flattenDNSSecRecords(zone string, records *dnssecRecords) ([]interface{}, error) {
+	if records == nil || records.DNSKeyRecord == "" {
+		return nil, nil
+	}
+
+	keys, err := parseDNSKeys(zone, records.DNSKeyRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	ksk := make([]interface{}, 0, 1)
+	zsk := make([]interface{}, 0, 1)
+	dsRecords := make([]interface{}, 0, 1)
+	for _, key := range keys {
+		tag, err := key.keyTag()
+		if err != nil {
+			return nil, err
+		}
+		v := map[string]interface{}{
+			"key_tag":    tag,
+			"flags":      key.Flags,
+			"algorithm":  key.Algorithm,
+			"public_key": key.PublicKey,
+		}
+		if key.Flags != dnskeyFlagsKSK {
+			zsk = append(zsk, v)
+			continue
+		}
+		ksk = append(ksk, v)
+		ds, err := key.dsRecord(zone, records.ExpectedTTL)
+		if err != nil {
+			return nil, err
+		}
+		dsRecords = append(dsRecords, ds)
+	}
+
+	return []interface{}{map[string]interface{}{
+		"dnskey_record":      records.DNSKeyRecord,
+		"ds_record":          records.DSRecord,
+		"expected_ttl":       records.ExpectedTTL,
+		"last_modified_date": records.LastModifiedDate,
+		"ksk":                ksk,
+		"zsk":                zsk,
+		"ds_records":         dsRecords,
+	}}, nil
+}
@@ -0,0 +1,101 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the example of RFC 4509, section 2.3
+func TestDNSKeyDSRecord(t *testing.T) {
+	keys, err := parseDNSKeys("dskey.example.com", `dskey.example.com. 86400 IN DNSKEY 256 3 5 ( AQOeiiR0GOMYkDshWoSKz9Xz
+	fwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9Xzc
+	nOf+EPbtG9DMBmADjFDc2w/rljwvFw== ) ; key id = 60485`)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, dnskey{Flags: 256, Protocol: 3, Algorithm: 5, PublicKey: "AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="}, keys[0])
+
+	tag, err := keys[0].keyTag()
+	require.NoError(t, err)
+	assert.Equal(t, 60485, tag)
+
+	ds, err := keys[0].dsRecord("dskey.example.com.", 86400)
+	require.NoError(t, err)
+	assert.Equal(t, "dskey.example.com.\t86400\tIN\tDS\t60485 5 2 D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", ds)
+
+	_, err = parseDNSKeys("example.com", "example.com. 300 IN DNSKEY 257 3 eight AwEAAQ==")
+	assert.Error(t, err)
+}
+
+func TestDataSourceDNSZoneDNSSecStatus(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+
+	zone := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: "example.com", Type: "PRIMARY", SignAndServe: true, SignAndServeAlgorithm: "ECDSA_P256_SHA256"})
+	require.NoError(t, zone.Save(dnsv2.ZoneQueryString{Contract: mockapi.ContractID}))
+
+	d := schema.TestResourceDataRaw(t, dataSourceDNSZoneDNSSecStatus().Schema, map[string]interface{}{"zone": "example.com"})
+	require.NoError(t, dataSourceDNSZoneDNSSecStatusRead(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+	assert.Equal(t, 1, d.Get("current_records.#"))
+	assert.Equal(t, 0, d.Get("new_records.#"))
+	assert.Equal(t, 1, d.Get("current_records.0.ksk.#"))
+	assert.Equal(t, 1, d.Get("current_records.0.zsk.#"))
+	assert.Equal(t, 13, d.Get("current_records.0.ksk.0.algorithm"))
+	assert.Equal(t, 257, d.Get("current_records.0.ksk.0.flags"))
+	assert.Equal(t, 256, d.Get("current_records.0.zsk.0.flags"))
+
+	// the computed DS record matches the one of the API
+	fields := func(record string) []string {
+		rs, err := parseZoneFile("example.com", record)
+		require.NoError(t, err)
+		require.Len(t, rs, 1)
+		return []string{rs[0].Name, rs[0].Type, rs[0].Rdata[0]}
+	}
+	assert.Equal(t, fields(d.Get("current_records.0.ds_record").(string)), fields(d.Get("current_records.0.ds_records.0").(string)))
+
+	d = schema.TestResourceDataRaw(t, dataSourceDNSZoneDNSSecStatus().Schema, map[string]interface{}{"zone": "missing.com"})
+	assert.Error(t, dataSourceDNSZoneDNSSecStatusRead(d, nil))
+}
+
+func TestResourceDNSv2Zone_forceKeyRotation(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+
+	zone := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: "example.com", Type: "PRIMARY", SignAndServe: true})
+	require.NoError(t, zone.Save(dnsv2.ZoneQueryString{Contract: mockapi.ContractID}))
+
+	d := schema.TestResourceDataRaw(t, resourceDNSv2Zone().Schema, map[string]interface{}{
+		"contract":           mockapi.ContractID,
+		"group":              "100",
+		"zone":               "example.com",
+		"type":               "PRIMARY",
+		"sign_and_serve":     true,
+		"force_key_rotation": "2020-10-01",
+	})
+	d.SetId("example.com")
+	require.NoError(t, resourceDNSv2ZoneUpdate(d, nil))
+
+	status, err := getDNSSecStatus("example.com")
+	require.NoError(t, err)
+	require.NotNil(t, status.NewRecords)
+	assert.NotEqual(t, status.CurrentRecords.DNSKeyRecord, status.NewRecords.DNSKeyRecord)
+
+	// rotation needs a signed zone
+	d = schema.TestResourceDataRaw(t, resourceDNSv2Zone().Schema, map[string]interface{}{
+		"contract":           mockapi.ContractID,
+		"group":              "100",
+		"zone":               "example.com",
+		"type":               "PRIMARY",
+		"force_key_rotation": "2020-10-01",
+	})
+	assert.EqualError(t, checkDNSv2Zone(d), "force_key_rotation requires sign_and_serve in zone example.com configuration")
+}
@@ -0,0 +1,41 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDNSZoneFileRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"zone_file": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+
+	log.Printf("[DEBUG] [Akamai DNSv2] Exporting zone file of [%s]", zone)
+
+	file, err := dnsv2.GetMasterZoneFile(zone)
+	if err != nil {
+		return fmt.Errorf("error exporting zone file of %q: %s", zone, err)
+	}
+
+	d.Set("zone_file", file)
+	d.SetId(zone)
+
+	return nil
+}
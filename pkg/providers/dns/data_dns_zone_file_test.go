@@ -0,0 +1,35 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceDNSZoneFile(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	www := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	require.NoError(t, www.Save("example.com"))
+
+	d := schema.TestResourceDataRaw(t, dataSourceDNSZoneFile().Schema, map[string]interface{}{"zone": "example.com"})
+	require.NoError(t, dataSourceDNSZoneFileRead(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+
+	// the export can be used as the zone file of another zone
+	recordsets, err := parseZoneFile("example.com", d.Get("zone_file").(string))
+	require.NoError(t, err)
+	assert.Len(t, recordsets, 3)
+	assert.Contains(t, recordsets, dnsv2.Recordset{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1"}})
+
+	d = schema.TestResourceDataRaw(t, dataSourceDNSZoneFile().Schema, map[string]interface{}{"zone": "missing.com"})
+	assert.Error(t, dataSourceDNSZoneFileRead(d, nil))
+}
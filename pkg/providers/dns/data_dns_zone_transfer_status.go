@@ -0,0 +1,115 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	zoneTransferStatusResponse struct {
+		Zones []zoneTransferStatus `json:"zones"`
+	}
+
+	zoneTransferStatus struct {
+		Zone    string                 `json:"zone"`
+		Masters []masterTransferStatus `json:"masters"`
+	}
+
+	masterTransferStatus struct {
+		Master                   string `json:"master"`
+		LastTransferDate         string `json:"lastTransferDate"`
+		LastTransferSerialNumber int64  `json:"lastTransferSerialNumber"`
+		LastTransferError        string `json:"lastTransferError"`
+	}
+)
+
+func dataSourceDNSZoneTransferStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDNSZoneTransferStatusRead,
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"masters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"master": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_transfer_date": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_transfer_serial": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The SOA serial number of the last successful transfer",
+						},
+						"last_transfer_error": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"failed_masters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The masters the last transfer failed from",
+			},
+		},
+	}
+}
+
+func dataSourceDNSZoneTransferStatusRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+
+	log.Printf("[DEBUG] [Akamai DNSv2] Reading zone transfer status of [%s]", zone)
+
+	var resp zoneTransferStatusResponse
+	body := map[string]interface{}{"zones": []string{zone}}
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodPost, "/config-dns/v2/zones/zone-transfer-status", body, &resp); err != nil {
+		return fmt.Errorf("error reading zone transfer status of %q: %w", zone, err)
+	}
+
+	var status *zoneTransferStatus
+	for i := range resp.Zones {
+		if strings.EqualFold(strings.TrimSuffix(resp.Zones[i].Zone, "."), strings.TrimSuffix(zone, ".")) {
+			status = &resp.Zones[i]
+		}
+	}
+	if status == nil {
+		return fmt.Errorf("no zone transfer status for zone %q", zone)
+	}
+
+	masters := make([]interface{}, 0, len(status.Masters))
+	failed := make([]interface{}, 0)
+	for _, m := range status.Masters {
+		masters = append(masters, map[string]interface{}{
+			"master":               m.Master,
+			"last_transfer_date":   m.LastTransferDate,
+			"last_transfer_serial": int(m.LastTransferSerialNumber),
+			"last_transfer_error":  m.LastTransferError,
+		})
+		if m.LastTransferError != "" {
+			failed = append(failed, m.Master)
+		}
+	}
+
+	d.Set("masters", masters)
+	d.Set("failed_masters", failed)
+	d.SetId(zone)
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceDNSZoneTransferStatus(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testSecondaryZone(t, "example.com", "10.0.0.1", "192.0.2.1")
+	testPrimaryZone(t, "example.net")
+
+	d := schema.TestResourceDataRaw(t, dataSourceDNSZoneTransferStatus().Schema, map[string]interface{}{"zone": "example.com"})
+	require.NoError(t, dataSourceDNSZoneTransferStatusRead(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+	assert.Equal(t, 2, d.Get("masters.#"))
+	assert.Equal(t, []interface{}{"192.0.2.1"}, d.Get("failed_masters"))
+
+	masters := make(map[string]map[string]interface{})
+	for _, m := range d.Get("masters").([]interface{}) {
+		masters[m.(map[string]interface{})["master"].(string)] = m.(map[string]interface{})
+	}
+	assert.Equal(t, mockapi.TransferSerial, masters["10.0.0.1"]["last_transfer_serial"])
+	assert.Empty(t, masters["10.0.0.1"]["last_transfer_error"])
+	assert.NotEmpty(t, masters["10.0.0.1"]["last_transfer_date"])
+	assert.Equal(t, "Connection to 192.0.2.1 timed out", masters["192.0.2.1"]["last_transfer_error"])
+
+	d = schema.TestResourceDataRaw(t, dataSourceDNSZoneTransferStatus().Schema, map[string]interface{}{"zone": "example.net"})
+	assert.Error(t, dataSourceDNSZoneTransferStatusRead(d, nil))
+}
@@ -0,0 +1,149 @@
+package dns
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+)
+
+// DNSSEC status and key rotation of Sign&Serve zones, which dnsv2 has no functions for
+
+const (
+	// dnskeyFlagsKSK are the DNSKEY flags of a key signing key, the zone key and SEP bits
+	dnskeyFlagsKSK = 257
+	// digestTypeSHA256 is the DS digest type of computed DS records
+	digestTypeSHA256 = 2
+)
+
+type (
+	dnssecStatusResponse struct {
+		DNSSecStatuses []dnssecStatus `json:"dnsSecStatuses"`
+	}
+
+	dnssecStatus struct {
+		Zone           string         `json:"zone"`
+		Alerts         []string       `json:"alerts"`
+		CurrentRecords *dnssecRecords `json:"currentRecords"`
+		NewRecords     *dnssecRecords `json:"newRecords"`
+	}
+
+	dnssecRecords struct {
+		DNSKeyRecord     string `json:"dnskeyRecord"`
+		DSRecord         string `json:"dsRecord"`
+		ExpectedTTL      int    `json:"expectedTtl"`
+		LastModifiedDate string `json:"lastModifiedDate"`
+	}
+
+	// dnskey is a DNSKEY record of a zone
+	dnskey struct {
+		Flags     int
+		Protocol  int
+		Algorithm int
+		PublicKey string
+	}
+)
+
+// getDNSSecStatus returns the DNSSEC status of a Sign&Serve zone
+func getDNSSecStatus(zone string) (*dnssecStatus, error) {
+	var resp dnssecStatusResponse
+	body := map[string]interface{}{"zones": []string{zone}}
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodPost, "/config-dns/v2/zones/dns-sec-status", body, &resp); err != nil {
+		return nil, err
+	}
+	for _, status := range resp.DNSSecStatuses {
+		if strings.EqualFold(strings.TrimSuffix(status.Zone, "."), strings.TrimSuffix(zone, ".")) {
+			return &status, nil
+		}
+	}
+	return nil, fmt.Errorf("no DNSSEC status for zone %s", zone)
+}
+
+// rotateZoneKeys requests new DNSSEC keys for a Sign&Serve zone. The new keys show in
+// the new records of the DNSSEC status until they replace the current ones.
+func rotateZoneKeys(zone string) error {
+	return apiclient.DoRequest(dnsv2.Config, http.MethodPost, "/config-dns/v2/zones/"+zone+"/key-rotation", nil, nil)
+}
+
+// parseDNSKeys parses DNSKEY records in zone file format
+func parseDNSKeys(zone, records string) ([]dnskey, error) {
+	recordsets, err := parseZoneFile(zone, records)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []dnskey
+	for _, rs := range recordsets {
+		if rs.Type != "DNSKEY" {
+			continue
+		}
+		for _, rdata := range rs.Rdata {
+			fields := strings.Fields(rdata)
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("malformed DNSKEY rdata %q", rdata)
+			}
+			var key dnskey
+			for i, v := range []*int{&key.Flags, &key.Protocol, &key.Algorithm} {
+				if *v, err = strconv.Atoi(fields[i]); err != nil {
+					return nil, fmt.Errorf("malformed DNSKEY rdata %q", rdata)
+				}
+			}
+			key.PublicKey = strings.Join(fields[3:], "")
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// rdata returns the DNSKEY rdata in wire format
+func (k dnskey) rdata() ([]byte, error) {
+	pub, err := base64.StdEncoding.DecodeString(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("malformed DNSKEY public key: %s", err)
+	}
+	return append([]byte{byte(k.Flags >> 8), byte(k.Flags), byte(k.Protocol), byte(k.Algorithm)}, pub...), nil
+}
+
+// keyTag computes the key tag of the key as in RFC 4034, appendix B
+func (k dnskey) keyTag() (int, error) {
+	rdata, err := k.rdata()
+	if err != nil {
+		return 0, err
+	}
+	var ac uint32
+	for i, b := range rdata {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xffff
+	return int(ac & 0xffff), nil
+}
+
+// dsRecord computes the SHA-256 DS record of the key of the zone as in RFC 4034, section 5
+func (k dnskey) dsRecord(zone string, ttl int) (string, error) {
+	rdata, err := k.rdata()
+	if err != nil {
+		return "", err
+	}
+	tag, err := k.keyTag()
+	if err != nil {
+		return "", err
+	}
+
+	var owner []byte
+	for _, label := range strings.Split(strings.ToLower(strings.TrimSuffix(zone, ".")), ".") {
+		owner = append(append(owner, byte(len(label))), label...)
+	}
+	digest := sha256.Sum256(append(append(owner, 0), rdata...))
+
+	return fmt.Sprintf("%s.\t%d\tIN\tDS\t%d %d %d %s", strings.TrimSuffix(zone, "."), ttl, tag, k.Algorithm, digestTypeSHA256, strings.ToUpper(hex.EncodeToString(digest[:]))), nil
+}
@@ -48,21 +48,48 @@ func Provider() *schema.Provider {
 			"dns": {
 				Optional: true,
 				Type:     schema.TypeSet,
-				Elem:     config.Options("dns"),
+				Elem:     dnsOptions(),
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_authorities_set": dataSourceAuthoritiesSet(),
-			"akamai_dns_record_set":  dataSourceDNSRecordSet(),
+			"akamai_authorities_set":          dataSourceAuthoritiesSet(),
+			"akamai_dns_record_set":           dataSourceDNSRecordSet(),
+			"akamai_dns_zone_file":            dataSourceDNSZoneFile(),
+			"akamai_dns_zone_dnssec_status":   dataSourceDNSZoneDNSSecStatus(),
+			"akamai_dns_zone_transfer_status": dataSourceDNSZoneTransferStatus(),
+			"akamai_dns_zone_aliases":         dataSourceDNSZoneAliases(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"akamai_dns_zone":   resourceDNSv2Zone(),
-			"akamai_dns_record": resourceDNSv2Record(),
+			"akamai_dns_zone":       resourceDNSv2Zone(),
+			"akamai_dns_record":     resourceDNSv2Record(),
+			"akamai_dns_zone_file":  resourceDNSZoneFile(),
+			"akamai_dns_recordsets": resourceDNSRecordsets(),
+			"akamai_dns_tsig_key":   resourceDNSTsigKey(),
 		},
 	}
 	return provider
 }
 
+// dnsOptions returns the provider dns block, with the retry policy next to the credentials.
+// The credentials default to empty, so the block can set the retry policy only while the
+// credentials come from the .edgerc file.
+func dnsOptions() *schema.Resource {
+	options := config.Options("dns")
+	for _, k := range []string{"host", "access_token", "client_token", "client_secret"} {
+		defaultFunc := options.Schema[k].DefaultFunc
+		options.Schema[k].DefaultFunc = func() (interface{}, error) {
+			if v, err := defaultFunc(); err == nil {
+				return v, nil
+			}
+			return "", nil
+		}
+	}
+	for k, v := range retrySchema() {
+		options.Schema[k] = v
+	}
+	return options
+}
+
 type resourceData interface {
 	GetOk(string) (interface{}, bool)
 	Get(string) interface{}
@@ -75,7 +102,7 @@ type set interface {
 func getConfigDNSV2Service(d resourceData) (*edgegrid.Config, error) {
 	var DNSv2Config edgegrid.Config
 	var err error
-	if _, ok := d.GetOk("dns"); ok {
+	if _, ok := d.GetOk("dns"); ok && d.Get("dns").(set).List()[0].(map[string]interface{})["host"] != "" {
 		config := d.Get("dns").(set).List()[0].(map[string]interface{})
 
 		DNSv2Config = edgegrid.Config{
@@ -131,6 +158,12 @@ func (p *provider) DataSources() map[string]*schema.Resource {
 func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	log.Named(p.Name()).Debug("START Configure")
 
+	retry, err := getRetryPolicy(d)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	dnsRetry = retry
+
 	cfg, err := getConfigDNSV2Service(d)
 	if err != nil {
 		return nil, nil
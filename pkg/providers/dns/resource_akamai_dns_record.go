@@ -11,7 +11,6 @@ import (
 	"sort"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
@@ -19,9 +18,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-// Retry count for save, update and delete
-const opRetryCount = 5
-
 func resourceDNSv2Record() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDNSRecordCreate,
@@ -275,6 +271,11 @@ func resourceDNSv2Record() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"write_retries": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of retries the last write of the record needed",
+			},
 			"refresh": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -468,87 +469,6 @@ func dnsRecordTargetSuppress(k, old, new string, d *schema.ResourceData) bool {
 
 }
 
-// Lock per record type
-var recordCreateLock = map[string]*sync.Mutex{
-	"A":          &sync.Mutex{},
-	"AAAA":       &sync.Mutex{},
-	"AFSDB":      &sync.Mutex{},
-	"AKAMAICDN":  &sync.Mutex{},
-	"AKAMAITLC":  &sync.Mutex{},
-	"CAA":        &sync.Mutex{},
-	"CERT":       &sync.Mutex{},
-	"CNAME":      &sync.Mutex{},
-	"HINFO":      &sync.Mutex{},
-	"LOC":        &sync.Mutex{},
-	"MX":         &sync.Mutex{},
-	"NAPTR":      &sync.Mutex{},
-	"NS":         &sync.Mutex{},
-	"PTR":        &sync.Mutex{},
-	"RP":         &sync.Mutex{},
-	"SOA":        &sync.Mutex{},
-	"SRV":        &sync.Mutex{},
-	"SPF":        &sync.Mutex{},
-	"SSHFP":      &sync.Mutex{},
-	"TLSA":       &sync.Mutex{},
-	"TXT":        &sync.Mutex{},
-	"DNSKEY":     &sync.Mutex{},
-	"DS":         &sync.Mutex{},
-	"NSEC3":      &sync.Mutex{},
-	"NSEC3PARAM": &sync.Mutex{},
-	"RRSIG":      &sync.Mutex{}}
-
-/*
-// Following  supports Lock by Recordset. Not clear dnsv2 API would be able to handle for very large zones
-
-var zoneRecordCreateLock = make(sync.Map[string]*sync.Mutex)
-
-
-var recordTypeMapLock = &sync.Map{
-        "A":            &sync.Map{},
-        "AAAA":         &sync.Map{},
-        "AFSDB":        &sync.Map{},
-        "AKAMAICDN":    &sync.Map{},
-        "AKAMAITLC":    &sync.Map{},
-        "CAA":          &sync.Map{},
-        "CERT":         &sync.Map{},
-        "CNAME":        &sync.Map{},
-        "HINFO":        &sync.Map{},
-        "LOC":          &sync.Map{},
-        "MX":           &sync.Map{},
-        "NAPTR":        &sync.Map{},
-        "NS":           &sync.Map{},
-        "PTR":          &sync.Map{},
-        "RP":           &sync.Map{},
-        "SOA":          &sync.Map{},
-        "SRV":          &sync.Map{},
-        "SPF":          &sync.Map{},
-        "SSHFP":        &sync.Map{},
-        "TLSA":         &sync.Map{},
-        "TXT":          &sync.Map{},
-        "DNSKEY":       &sync.Map{},
-        "DS":           &sync.Map{},
-        "NSEC3":        &sync.Map{},
-        "NSEC3PARAM":   &sync.Map{},
-        "RRSIG":        &sync.Map{}}
-
-// Retrieves record lock per recordset
-func getRecordLock(zone, host, recordtype string) *sync.Mutex {
-
-	typeMap, _ := recordTypeMapLock.LoadOrStore(recordtype, &sync.Map{})
-        lockindex := zone + "_" + host + "_" + recordtype
-        recordLock, _ := typeMap.(*sync.Map).LoadOrStore(lockindex, &sync.Mutex{})
-
-        return recordLock.(*sync.Mutex)
-}
-*/
-
-// Retrieves record lock per record type
-func getRecordLock(zone, host, recordtype string) *sync.Mutex {
-
-	return recordCreateLock[recordtype]
-
-}
-
 func bumpSoaSerial(name string, d *schema.ResourceData, zone string, host string) (recordFunction, error) {
 	// Get SOA Record
 	recordset, e := dnsv2.GetRecord(zone, host, "SOA")
@@ -563,9 +483,9 @@ func bumpSoaSerial(name string, d *schema.ResourceData, zone string, host string
 		return nil, err
 	}
 	if name == "CREATE" {
-		return newrecord.Save, nil
+		return recordWrite(zone, changeAdd, &newrecord), nil
 	} else if name == "UPDATE" {
-		return newrecord.Update, nil
+		return recordWrite(zone, changeEdit, &newrecord), nil
 	}
 
 	return nil, fmt.Errorf("Bad Function")
@@ -575,54 +495,62 @@ func bumpSoaSerial(name string, d *schema.ResourceData, zone string, host string
 // Record function signature
 type recordFunction func(string, ...bool) error
 
+// executeRecordFunction writes the record, retrying concurrency conflicts and SOA serial
+// numbers that weren't incremented as set by the retry policy of the provider
 func executeRecordFunction(name string, d *schema.ResourceData, fn recordFunction, zone string, host string, recordtype string, rlock bool) error {
 
 	// DNS API can have Concurrency issues
-	opRetry := opRetryCount
-	e := fn(zone, rlock)
-	for e != nil && opRetry > 0 {
-		if dnsv2.IsConfigDNSError(e) {
-			if e.(dnsv2.ConfigDNSError).ConcurrencyConflict() {
-				log.Printf("[WARNING] [Akamai DNSv2] Concurrency Conflict")
-				opRetry -= 1
-				time.Sleep(100 * time.Millisecond)
-				e = fn(zone, rlock)
-				continue
-			} else if (name == "CREATE" || name == "UPDATE") && strings.Contains(e.(*dnsv2.RecordError).Error(), "SOA serial number must be incremented") {
-				log.Printf("[WARNING] [Akamai DNSv2] SOA Serial Number needs incrementing")
-				opRetry -= 1
-				time.Sleep(5 * time.Second) // let things quiesce
-				fn, err := bumpSoaSerial(name, d, zone, host)
-				if err != nil {
-					return err
-				}
-				e = fn(zone, rlock)
-				continue
-			} else if name == "DELETE" && e.(dnsv2.ConfigDNSError).NotFound() == true {
-				// record doesn't exist
-				d.SetId("")
-				log.Printf("[DEBUG] [Akamai DNSv2] %s [WARNING] %s", name, "Record not found")
-				break
-			} else {
-				log.Printf("[DEBUG] [Akamai DNSv2] %s [ERROR] %s", name, e.Error())
-				return e
+	for retry := 0; ; retry++ {
+		e := fn(zone, rlock)
+		if e == nil {
+			if retry > 0 {
+				log.Printf("[DEBUG] [Akamai DNSv2] %s Record [%s] [%s] [%s] succeeded after %d retries", name, zone, host, recordtype, retry)
 			}
-		} else {
-			log.Printf("[DEBUG] [Akamai DNSv2] %s Record failed for record [%s] [%s] [%s] ", name, zone, host, recordtype)
-			return e
+			if d != nil && name != "DELETE" {
+				d.Set("write_retries", retry)
+			}
+			return nil
 		}
-		break
-	}
 
-	return nil
+		class := classifyError(e)
+		switch {
+		case name == "DELETE" && class == errorNotFound:
+			// record doesn't exist
+			d.SetId("")
+			log.Printf("[DEBUG] [Akamai DNSv2] %s [WARNING] %s", name, "Record not found")
+			return nil
+		case class == errorConcurrencyConflict:
+		case class == errorSOASerial && (name == "CREATE" || name == "UPDATE"):
+		default:
+			log.Printf("[DEBUG] [Akamai DNSv2] %s Record failed for record [%s] [%s] [%s]: %s", name, zone, host, recordtype, e.Error())
+			if retry > 0 {
+				return fmt.Errorf("%s after %d retries: %s", strings.ToLower(name), retry, e)
+			}
+			return e
+		}
 
+		if retry >= dnsRetry.Max {
+			return fmt.Errorf("%s gave up after %d retries on %s: %s", strings.ToLower(name), retry, class, e)
+		}
+		wait := dnsRetry.backoff(retry)
+		log.Printf("[WARNING] [Akamai DNSv2] %s Record [%s] [%s] [%s]: %s, retry %d of %d in %s", name, zone, host, recordtype, class, retry+1, dnsRetry.Max, wait)
+		time.Sleep(wait)
+
+		if class == errorSOASerial {
+			var err error
+			if fn, err = bumpSoaSerial(name, d, zone, host); err != nil {
+				return err
+			}
+		}
+	}
 }
 
 // Create a new DNS Record
 func resourceDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
-	// only allow one record per record type to be created at a time
+	// only allow one write per recordset at a time
 	// this prevents lost data if you are using a counter/dynamic variables
-	// in your config.tf which might overwrite each other
+	// in your config.tf which might overwrite each other. Writes to the same
+	// zone are batched into one changelist
 
 	var zone string
 	var host string
@@ -648,19 +576,10 @@ func resourceDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("DNS record validation failure on zone %v: %v", zone, err)
 	}
 
-	// serialize record creates of same type
+	// serialize record creates of same recordset
 	getRecordLock(zone, host, recordtype).Lock()
 	defer getRecordLock(zone, host, recordtype).Unlock()
 
-	/*
-		// works but serializes all recordset creates and updates per host
-		if recordtype != "SOA" {
-			// TF is multi threaded. Can't update SOA concurrently with other records
-			getRecordLock(zone, zone, "SOA").Lock()
-			defer getRecordLock(zone, zone, "SOA").Unlock()
-		}
-	*/
-
 	if recordtype == "SOA" {
 		// A default SOA is created automagically when the primary zone is created ...
 		err := resourceDNSRecordRead(d, meta)
@@ -700,20 +619,20 @@ func resourceDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[DEBUG] [Akamai DNSv2] [ERROR] %s", e.Error())
 		log.Printf("[DEBUG] [Akamai DNSv2] Creating new record")
 		// Save the zone to the API
-		e = executeRecordFunction("CREATE", d, recordcreate.Save, zone, host, recordtype, false)
+		e = executeRecordFunction("CREATE", d, recordWrite(zone, changeAdd, &recordcreate), zone, host, recordtype, false)
 		if e != nil {
 			return e
 		}
 	} else {
 		log.Printf("[DEBUG] [Akamai DNSv2] Updating record")
 		if len(rdata) > 0 {
-			e = executeRecordFunction("CREATE", d, recordcreate.Update, zone, host, recordtype, false)
+			e = executeRecordFunction("CREATE", d, recordWrite(zone, changeEdit, &recordcreate), zone, host, recordtype, false)
 			if e != nil {
 				return e
 			}
 		} else {
 			log.Printf("[DEBUG] [Akamai DNSv2] Saving record")
-			e = executeRecordFunction("CREATE", d, recordcreate.Save, zone, host, recordtype, false)
+			e = executeRecordFunction("CREATE", d, recordWrite(zone, changeAdd, &recordcreate), zone, host, recordtype, false)
 			if e != nil {
 				return e
 			}
@@ -735,9 +654,10 @@ func resourceDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
 
 // Update DNS Record
 func resourceDNSRecordUpdate(d *schema.ResourceData, meta interface{}) error {
-	// only allow one record per record type to be updated at a time
+	// only allow one write per recordset at a time
 	// this prevents lost data if you are using a counter/dynamic variables
-	// in your config.tf which might overwrite each other
+	// in your config.tf which might overwrite each other. Writes to the same
+	// zone are batched into one changelist
 
 	log.Printf("[INFO] [Akamai DNS] Record Update")
 
@@ -772,7 +692,7 @@ func resourceDNSRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("DNS record validation failure on zone %v: %v", zone, err)
 	}
 
-	// serialize record updates of same type
+	// serialize record updates of same recordset
 	getRecordLock(zone, host, recordtype).Lock()
 	defer getRecordLock(zone, host, recordtype).Unlock()
 
@@ -826,13 +746,13 @@ func resourceDNSRecordUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Printf("[DEBUG] [Akamai DNSv2] UPDATE Creating new record")
 			// Save the zone to the API
 			log.Printf("[DEBUG] [Akamai DNSv2] UPDATE Updating record")
-			e = executeRecordFunction("UPDATE", d, recordcreate.Save, zone, host, recordtype, false)
+			e = executeRecordFunction("UPDATE", d, recordWrite(zone, changeAdd, &recordcreate), zone, host, recordtype, false)
 			if e != nil {
 				return e
 			}
 		} else {
 			log.Printf("[DEBUG] [Akamai DNSv2] UPDATE Updating record")
-			e = executeRecordFunction("UPDATE", d, recordcreate.Update, zone, host, recordtype, false)
+			e = executeRecordFunction("UPDATE", d, recordWrite(zone, changeEdit, &recordcreate), zone, host, recordtype, false)
 			if e != nil {
 				return e
 			}
@@ -1094,7 +1014,7 @@ func resourceDNSRecordDelete(d *schema.ResourceData, meta interface{}) error {
 	recordtype := d.Get("recordtype").(string)
 	ttl := d.Get("ttl").(int)
 
-	// serialize record updates of same type
+	// serialize record updates of same recordset
 	getRecordLock(zone, host, recordtype).Lock()
 	defer getRecordLock(zone, host, recordtype).Unlock()
 
@@ -1109,7 +1029,7 @@ func resourceDNSRecordDelete(d *schema.ResourceData, meta interface{}) error {
 	recordcreate := dnsv2.RecordBody{Name: host, RecordType: recordtype, TTL: ttl, Target: records}
 
 	// Warning: Delete will expunge the ENTIRE Recordset regardless of whether user thought they were removing an instance
-	return executeRecordFunction("DELETE", d, recordcreate.Delete, zone, host, recordtype, false)
+	return executeRecordFunction("DELETE", d, recordWrite(zone, changeDelete, &recordcreate), zone, host, recordtype, false)
 }
 
 func resourceDNSRecordExists(d *schema.ResourceData, meta interface{}) (bool, error) {
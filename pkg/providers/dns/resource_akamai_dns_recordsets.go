@@ -0,0 +1,232 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Every recordset of a zone but the SOA and apex NS recordsets. Recordsets created
+// outside of Terraform show as drift and are deleted on apply. The zone is read and
+// written through the bulk recordsets endpoints.
+func resourceDNSRecordsets() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSRecordsetsCreate,
+		Read:   resourceDNSRecordsetsRead,
+		Update: resourceDNSRecordsetsUpdate,
+		Delete: resourceDNSRecordsetsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"recordsets": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Set:      hashRecordset,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"rdata": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDNSRecordsetsCreate(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+	log.Printf("[INFO] [Akamai DNSv2] Recordsets Create for zone [%s]", zone)
+
+	if err := replaceRecordsets(zone, expandRecordsets(d)); err != nil {
+		return err
+	}
+
+	d.SetId(zone)
+	return resourceDNSRecordsetsRead(d, meta)
+}
+
+func resourceDNSRecordsetsRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Id()
+	log.Printf("[DEBUG] [Akamai DNSv2] Recordsets Read for zone [%s]", zone)
+
+	current, err := listRecordsets(zone)
+	if err != nil {
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+			log.Printf("[WARNING] [Akamai DNSv2] Zone [%s] not found, removing from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	// recordsets equivalent to the ones in state are kept as written, so the order and
+	// formatting of rdata don't show as changes
+	known := make(map[int]interface{})
+	for _, v := range d.Get("recordsets").(*schema.Set).List() {
+		known[hashRecordset(v)] = v
+	}
+
+	recordsets := make([]interface{}, 0, len(current))
+	for _, rs := range current {
+		if isApexRecordset(zone, rs) {
+			continue
+		}
+		rdata := make([]interface{}, 0, len(rs.Rdata))
+		for _, r := range rs.Rdata {
+			rdata = append(rdata, r)
+		}
+		var v interface{} = map[string]interface{}{
+			"name":  rs.Name,
+			"type":  rs.Type,
+			"ttl":   rs.TTL,
+			"rdata": rdata,
+		}
+		if k, ok := known[hashRecordset(v)]; ok {
+			v = k
+		}
+		recordsets = append(recordsets, v)
+	}
+
+	if err := d.Set("zone", zone); err != nil {
+		return err
+	}
+	return d.Set("recordsets", recordsets)
+}
+
+func resourceDNSRecordsetsUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] [Akamai DNSv2] Recordsets Update for zone [%s]", d.Id())
+
+	if err := replaceRecordsets(d.Id(), expandRecordsets(d)); err != nil {
+		return err
+	}
+	return resourceDNSRecordsetsRead(d, meta)
+}
+
+// Deletes every recordset of the zone but the SOA and apex NS recordsets
+func resourceDNSRecordsetsDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] [Akamai DNSv2] Recordsets Delete for zone [%s]", d.Id())
+
+	err := replaceRecordsets(d.Id(), nil)
+	if err != nil && !(dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound()) {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func expandRecordsets(d *schema.ResourceData) []dnsv2.Recordset {
+	list := d.Get("recordsets").(*schema.Set).List()
+	recordsets := make([]dnsv2.Recordset, 0, len(list))
+	for _, v := range list {
+		m := v.(map[string]interface{})
+		rs := dnsv2.Recordset{
+			Name: strings.TrimSuffix(m["name"].(string), "."),
+			Type: strings.ToUpper(m["type"].(string)),
+			TTL:  m["ttl"].(int),
+		}
+		for _, r := range m["rdata"].([]interface{}) {
+			rs.Rdata = append(rs.Rdata, r.(string))
+		}
+		recordsets = append(recordsets, rs)
+	}
+	return recordsets
+}
+
+// replaceRecordsets replaces every recordset of the zone but the SOA and apex NS ones
+// in one request. The current SOA and apex NS recordsets are sent along, as the
+// endpoint requires, with the SOA serial incremented.
+func replaceRecordsets(zone string, desired []dnsv2.Recordset) error {
+	if err := checkRecordsetNames(zone, desired); err != nil {
+		return fmt.Errorf("recordsets of %s: %s", zone, err)
+	}
+	for _, rs := range desired {
+		if isApexRecordset(zone, rs) {
+			return fmt.Errorf("recordsets of %s: the %s %s recordset is maintained by Edge DNS", zone, rs.Name, rs.Type)
+		}
+	}
+
+	w := getZoneWriter(zone)
+	w.Lock()
+	defer w.Unlock()
+
+	current, err := listRecordsets(zone)
+	if err != nil {
+		return err
+	}
+	changes := diffRecordsets(zone, current, desired)
+	if len(changes) == 0 {
+		return nil
+	}
+	log.Printf("[DEBUG] [Akamai DNSv2] Recordsets of [%s] have %d changes", zone, len(changes))
+
+	body := &dnsv2.Recordsets{Recordsets: make([]dnsv2.Recordset, 0, len(desired)+2)}
+	for _, rs := range current {
+		if !isApexRecordset(zone, rs) {
+			continue
+		}
+		if strings.EqualFold(rs.Type, RRTypeSoa) {
+			if rs.Rdata, err = incrementSoaSerial(rs.Rdata); err != nil {
+				return fmt.Errorf("SOA record of %s: %s", zone, err)
+			}
+		}
+		body.Recordsets = append(body.Recordsets, rs)
+	}
+	body.Recordsets = append(body.Recordsets, desired...)
+
+	return body.Update(zone)
+}
+
+// incrementSoaSerial returns the SOA rdata with the serial number incremented
+func incrementSoaSerial(rdata []string) ([]string, error) {
+	if len(rdata) != 1 {
+		return nil, fmt.Errorf("expected one rdata value, got %d", len(rdata))
+	}
+	fields := strings.Fields(rdata[0])
+	if len(fields) != 7 {
+		return nil, fmt.Errorf("malformed rdata %q", rdata[0])
+	}
+	serial, err := strconv.ParseUint(fields[2], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("malformed serial number %q", fields[2])
+	}
+	fields[2] = strconv.FormatUint((serial+1)%(1<<32), 10)
+	return []string{strings.Join(fields, " ")}, nil
+}
+
+// hashRecordset ignores the case and trailing dot of names and the formatting and order
+// of rdata, so only real changes show in plans
+func hashRecordset(v interface{}) int {
+	m := v.(map[string]interface{})
+	rs := dnsv2.Recordset{Name: m["name"].(string), Type: m["type"].(string)}
+	for _, r := range m["rdata"].([]interface{}) {
+		rs.Rdata = append(rs.Rdata, r.(string))
+	}
+	return schema.HashString(fmt.Sprintf("%s %d %s", recordsetKey(rs), m["ttl"].(int), strings.Join(normalizeRdata(rs.Type, rs.Rdata), "\n")))
+}
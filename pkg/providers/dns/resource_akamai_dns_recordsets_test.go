@@ -0,0 +1,120 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecordset(name, rtype string, ttl int, rdata ...string) map[string]interface{} {
+	values := make([]interface{}, 0, len(rdata))
+	for _, r := range rdata {
+		values = append(values, r)
+	}
+	return map[string]interface{}{"name": name, "type": rtype, "ttl": ttl, "rdata": values}
+}
+
+func TestResourceDNSRecordsets(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	soa, err := dnsv2.GetRecord("example.com", "example.com", "SOA")
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, resourceDNSRecordsets().Schema, map[string]interface{}{
+		"zone": "example.com",
+		"recordsets": []interface{}{
+			testRecordset("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2"),
+			testRecordset("mail.example.com", "MX", 300, "10 mx1.example.com."),
+		},
+	})
+	require.NoError(t, resourceDNSRecordsetsCreate(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+	assert.Equal(t, 2, d.Get("recordsets").(*schema.Set).Len())
+
+	// the SOA and apex NS records are kept, with the serial number incremented
+	recordsets, err := listRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, recordsets, 4)
+	bumped, err := dnsv2.GetRecord("example.com", "example.com", "SOA")
+	require.NoError(t, err)
+	assert.Equal(t, dnsv2.ParseRData("SOA", soa.Target)["serial"].(int)+1, dnsv2.ParseRData("SOA", bumped.Target)["serial"])
+
+	// records created outside terraform show as drift
+	extra := &dnsv2.RecordBody{Name: "extra.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.9"}}
+	require.NoError(t, extra.Save("example.com"))
+	require.NoError(t, resourceDNSRecordsetsRead(d, nil))
+	assert.Equal(t, 3, d.Get("recordsets").(*schema.Set).Len())
+	assert.True(t, d.Get("recordsets").(*schema.Set).Contains(testRecordset("extra.example.com", "A", 300, "10.0.0.9")))
+
+	// and are deleted on apply
+	d.Set("recordsets", []interface{}{
+		testRecordset("www.example.com", "A", 300, "10.0.0.2", "10.0.0.1"),
+		testRecordset("mail.example.com", "MX", 600, "10 mx1.example.com."),
+	})
+	require.NoError(t, resourceDNSRecordsetsUpdate(d, nil))
+	_, err = dnsv2.GetRecord("example.com", "extra.example.com", "A")
+	assert.Error(t, err)
+	mail, err := dnsv2.GetRecord("example.com", "mail.example.com", "MX")
+	require.NoError(t, err)
+	assert.Equal(t, 600, mail.TTL)
+
+	require.NoError(t, resourceDNSRecordsetsDelete(d, nil))
+	recordsets, err = listRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, recordsets, 2)
+}
+
+func TestResourceDNSRecordsets_invalid(t *testing.T) {
+	tests := map[string]struct {
+		recordsets []interface{}
+		err        string
+	}{
+		"outside zone": {
+			[]interface{}{testRecordset("www.example.net", "A", 300, "10.0.0.1")},
+			"www.example.net is outside of the zone",
+		},
+		"duplicate": {
+			[]interface{}{testRecordset("www.example.com", "A", 300, "10.0.0.1"), testRecordset("WWW.example.com.", "A", 300, "10.0.0.2")},
+			"is defined more than once",
+		},
+		"apex ns": {
+			[]interface{}{testRecordset("example.com", "NS", 300, "ns1.example.net.")},
+			"the example.com NS recordset is maintained by Edge DNS",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDNSRecordsets().Schema, map[string]interface{}{
+				"zone":       "example.com",
+				"recordsets": test.recordsets,
+			})
+			err := resourceDNSRecordsetsCreate(d, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestIncrementSoaSerial(t *testing.T) {
+	rdata, err := incrementSoaSerial([]string{"a1-1.akam.net. hostmaster.example.com. 4294967295 3600 600 604800 300"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1-1.akam.net. hostmaster.example.com. 0 3600 600 604800 300"}, rdata)
+
+	_, err = incrementSoaSerial([]string{"a1-1.akam.net. hostmaster.example.com."})
+	assert.Error(t, err)
+}
+
+func TestHashRecordset(t *testing.T) {
+	a := testRecordset("WWW.example.com.", "a", 300, "10.0.0.2", "10.0.0.1")
+	b := testRecordset("www.example.com", "A", 300, "10.0.0.1", "10.0.0.2")
+	assert.Equal(t, hashRecordset(a), hashRecordset(b))
+	assert.NotEqual(t, hashRecordset(a), hashRecordset(testRecordset("www.example.com", "A", 60, "10.0.0.1", "10.0.0.2")))
+}
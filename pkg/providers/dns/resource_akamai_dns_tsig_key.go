@@ -0,0 +1,207 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A TSIG key shared by secondary zones. The zones are updated together with one bulk
+// request, so changing the secret rotates the key in every zone at once. Zones using
+// a different key, or the key with another secret, show as drift. Zones using the key
+// without being configured are left alone.
+func resourceDNSTsigKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSTsigKeyCreate,
+		Read:   resourceDNSTsigKeyRead,
+		Update: resourceDNSTsigKeyUpdate,
+		Delete: resourceDNSTsigKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceDNSTsigKeyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"algorithm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"secret": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+			"zones": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "The secondary zones using the key",
+			},
+		},
+	}
+}
+
+func expandTsigKey(d *schema.ResourceData) *dnsv2.TSIGKey {
+	return &dnsv2.TSIGKey{
+		Name:      d.Get("name").(string),
+		Algorithm: d.Get("algorithm").(string),
+		Secret:    d.Get("secret").(string),
+	}
+}
+
+func setToStrings(s *schema.Set) []string {
+	list := make([]string, 0, s.Len())
+	for _, v := range s.List() {
+		list = append(list, v.(string))
+	}
+	sort.Strings(list)
+	return list
+}
+
+func resourceDNSTsigKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	key := expandTsigKey(d)
+	zones := setToStrings(d.Get("zones").(*schema.Set))
+	log.Printf("[INFO] [Akamai DNSv2] TSIG key Create [%s] for zones %v", key.Name, zones)
+
+	bulk := &dnsv2.TSIGKeyBulkPost{Key: key, Zones: zones}
+	if err := bulk.BulkUpdate(); err != nil {
+		return fmt.Errorf("setting TSIG key %s of zones %s: %s", key.Name, strings.Join(zones, ", "), err)
+	}
+
+	d.SetId(key.Name)
+	return resourceDNSTsigKeyRead(d, meta)
+}
+
+func resourceDNSTsigKeyRead(d *schema.ResourceData, meta interface{}) error {
+	name := d.Id()
+	log.Printf("[DEBUG] [Akamai DNSv2] TSIG key Read [%s]", name)
+
+	key := expandTsigKey(d)
+	key.Name = name
+
+	// only the configured zones are read, but for an import, which takes every zone
+	// using the key
+	candidates := setToStrings(d.Get("zones").(*schema.Set))
+	if len(candidates) == 0 {
+		used, err := key.GetZones()
+		if err != nil {
+			return fmt.Errorf("listing zones of TSIG key %s: %s", name, err)
+		}
+		candidates = used.Zones
+	}
+
+	// only zones with the key as configured are kept, so zones with another secret are
+	// updated on the next apply
+	zones := make([]interface{}, 0, len(candidates))
+	for _, zone := range candidates {
+		current, err := dnsv2.GetZoneKey(zone)
+		if err != nil {
+			if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+				continue
+			}
+			return fmt.Errorf("reading TSIG key of zone %s: %s", zone, err)
+		}
+		if current.Name != name {
+			continue
+		}
+		if key.Algorithm == "" && key.Secret == "" {
+			// imported
+			key.Algorithm, key.Secret = current.Algorithm, current.Secret
+		}
+		if current.Algorithm == key.Algorithm && current.Secret == key.Secret {
+			zones = append(zones, zone)
+		}
+	}
+
+	if len(zones) == 0 {
+		log.Printf("[WARNING] [Akamai DNSv2] TSIG key [%s] not used by any zone, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	attrs := map[string]interface{}{
+		"name":      name,
+		"algorithm": key.Algorithm,
+		"secret":    key.Secret,
+		"zones":     zones,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDNSTsigKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	key := expandTsigKey(d)
+	zones := setToStrings(d.Get("zones").(*schema.Set))
+	log.Printf("[INFO] [Akamai DNSv2] TSIG key Update [%s] for zones %v", d.Id(), zones)
+
+	// the key is set on every zone, which rotates it where the secret changed, then
+	// removed from the zones no longer listed
+	bulk := &dnsv2.TSIGKeyBulkPost{Key: key, Zones: zones}
+	if err := bulk.BulkUpdate(); err != nil {
+		return fmt.Errorf("setting TSIG key %s of zones %s: %s", key.Name, strings.Join(zones, ", "), err)
+	}
+
+	old, new := d.GetChange("zones")
+	removed := setToStrings(old.(*schema.Set).Difference(new.(*schema.Set)))
+	if err := removeTsigKey(d.Id(), removed); err != nil {
+		return err
+	}
+
+	return resourceDNSTsigKeyRead(d, meta)
+}
+
+func resourceDNSTsigKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] [Akamai DNSv2] TSIG key Delete [%s]", d.Id())
+
+	if err := removeTsigKey(d.Id(), setToStrings(d.Get("zones").(*schema.Set))); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by key name. The algorithm and secret are read from the zones using the key.
+func resourceDNSTsigKeyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name := d.Id()
+	if err := resourceDNSTsigKeyRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("TSIG key %s is not used by any zone", name)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// removeTsigKey removes the key from the zones still using it
+func removeTsigKey(name string, zones []string) error {
+	for _, zone := range zones {
+		current, err := dnsv2.GetZoneKey(zone)
+		if err != nil {
+			if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+				continue
+			}
+			return fmt.Errorf("reading TSIG key of zone %s: %s", zone, err)
+		}
+		if current.Name != name {
+			continue
+		}
+		if err := dnsv2.DeleteZoneKey(zone); err != nil {
+			return fmt.Errorf("removing TSIG key %s from zone %s: %s", name, zone, err)
+		}
+	}
+	return nil
+}
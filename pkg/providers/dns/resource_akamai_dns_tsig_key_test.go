@@ -0,0 +1,107 @@
+package dns
+
+import (
+	"context"
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSecondaryZone(t *testing.T, name string, masters ...string) {
+	zone := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: name, Type: "SECONDARY", Masters: masters})
+	require.NoError(t, zone.Save(dnsv2.ZoneQueryString{Contract: mockapi.ContractID}))
+}
+
+func TestResourceDNSTsigKey(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	for _, zone := range []string{"a.example.com", "b.example.com", "c.example.com", "unmanaged.example.com"} {
+		testSecondaryZone(t, zone, "10.0.0.1")
+	}
+	unmanaged := &dnsv2.TSIGKey{Name: "transfer.key", Algorithm: "hmac-sha256", Secret: "c2VjcmV0LTE="}
+	require.NoError(t, unmanaged.Update("unmanaged.example.com"))
+
+	d := schema.TestResourceDataRaw(t, resourceDNSTsigKey().Schema, map[string]interface{}{
+		"name":      "transfer.key",
+		"algorithm": "hmac-sha256",
+		"secret":    "c2VjcmV0LTE=",
+		"zones":     []interface{}{"a.example.com", "b.example.com"},
+	})
+	require.NoError(t, resourceDNSTsigKeyCreate(d, nil))
+	assert.Equal(t, "transfer.key", d.Id())
+	for _, zone := range []string{"a.example.com", "b.example.com"} {
+		key, err := dnsv2.GetZoneKey(zone)
+		require.NoError(t, err)
+		assert.Equal(t, "c2VjcmV0LTE=", key.Secret)
+	}
+
+	// a zone with another secret shows as drift, zones using the key without being
+	// configured are left out
+	require.NoError(t, (&dnsv2.TSIGKey{Name: "transfer.key", Algorithm: "hmac-sha256", Secret: "b3RoZXI="}).Update("b.example.com"))
+	require.NoError(t, resourceDNSTsigKeyRead(d, nil))
+	assert.Equal(t, []string{"a.example.com"}, setToStrings(d.Get("zones").(*schema.Set)))
+
+	// rotating the secret updates every zone, and removed zones lose the key
+	r := resourceDNSTsigKey()
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":      "transfer.key",
+		"algorithm": "hmac-sha256",
+		"secret":    "c2VjcmV0LTI=",
+		"zones":     []interface{}{"b.example.com", "c.example.com"},
+	}), nil)
+	require.NoError(t, err)
+	d, err = schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	require.NoError(t, resourceDNSTsigKeyUpdate(d, nil))
+	assert.Equal(t, []string{"b.example.com", "c.example.com"}, setToStrings(d.Get("zones").(*schema.Set)))
+	for _, zone := range []string{"b.example.com", "c.example.com"} {
+		key, err := dnsv2.GetZoneKey(zone)
+		require.NoError(t, err)
+		assert.Equal(t, "c2VjcmV0LTI=", key.Secret)
+	}
+	_, err = dnsv2.GetZoneKey("a.example.com")
+	assert.Error(t, err)
+
+	// renaming the key replaces it
+	state = d.State()
+	diff, err = r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":      "renamed.key",
+		"algorithm": "hmac-sha256",
+		"secret":    "c2VjcmV0LTI=",
+		"zones":     []interface{}{"b.example.com", "c.example.com"},
+	}), nil)
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+
+	// the key of unmanaged zones is never touched
+	key, err := dnsv2.GetZoneKey("unmanaged.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0LTE=", key.Secret)
+
+	// import reads the algorithm and secret from the zones, and takes the zones using the key
+	// with that secret
+	imported := resourceDNSTsigKey().Data(nil)
+	imported.SetId("transfer.key")
+	_, err = resourceDNSTsigKeyImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "c2VjcmV0LTI=", imported.Get("secret"))
+	assert.Equal(t, []string{"b.example.com", "c.example.com"}, setToStrings(imported.Get("zones").(*schema.Set)))
+
+	require.NoError(t, resourceDNSTsigKeyDelete(d, nil))
+	for _, zone := range []string{"b.example.com", "c.example.com"} {
+		_, err := dnsv2.GetZoneKey(zone)
+		assert.Error(t, err)
+	}
+	_, err = dnsv2.GetZoneKey("unmanaged.example.com")
+	assert.NoError(t, err)
+	require.NoError(t, resourceDNSTsigKeyRead(d, nil))
+	assert.Empty(t, d.Id())
+}
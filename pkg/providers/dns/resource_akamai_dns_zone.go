@@ -5,15 +5,12 @@ import (
 	"fmt"
 	"log"
 	"strings"
-	"sync"
 	"time"
 
 	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var dnsWriteLock sync.Mutex
-
 func resourceDNSv2Zone() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceDNSv2ZoneCreate,
@@ -24,6 +21,9 @@ func resourceDNSv2Zone() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceDNSv2ZoneImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"contract": {
 				Type:     schema.TypeString,
@@ -65,6 +65,11 @@ func resourceDNSv2Zone() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"force_key_rotation": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing the value rotates the DNSSEC keys of a Sign&Serve zone",
+			},
 			"end_customer_id": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -94,6 +99,18 @@ func resourceDNSv2Zone() *schema.Resource {
 					},
 				},
 			},
+			"allow_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether destroying the resource deletes the zone, instead of only removing it from the state",
+			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to delete the zone even if it holds records other than the SOA and apex NS records",
+			},
 			"version_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -270,6 +287,14 @@ func resourceDNSv2ZoneUpdate(d *schema.ResourceData, meta interface{}) error {
 		return e
 	}
 
+	// new Sign&Serve zones start with fresh keys, so keys are only rotated on changes
+	if d.HasChange("force_key_rotation") && d.Get("force_key_rotation").(string) != "" {
+		log.Printf("[INFO] [Akamai DNSv2] Rotating DNSSEC keys of zone [%s]", hostname)
+		if e = rotateZoneKeys(hostname); e != nil {
+			return fmt.Errorf("rotating DNSSEC keys of zone %s: %s", hostname, e)
+		}
+	}
+
 	// Give terraform the ID
 	if strings.Contains(d.Id(), "#") {
 		d.SetId(fmt.Sprintf("%s#%s#%s", zone.VersionId, zone.Zone, hostname))
@@ -299,12 +324,22 @@ func resourceDNSv2ZoneImport(d *schema.ResourceData, meta interface{}) ([]*schem
 	return []*schema.ResourceData{d}, nil
 }
 
+// Zones are only deleted when allow_delete is set, otherwise they are only removed from the state
 func resourceDNSv2ZoneDelete(d *schema.ResourceData, meta interface{}) error {
+	hostname := d.Get("zone").(string)
 	log.Printf("[DEBUG] Deleting DNS Zone")
 
-	// No ZONE delete operation permitted.
+	if !d.Get("allow_delete").(bool) {
+		log.Printf("[WARNING] [Akamai DNSv2] Zone [%s] removed from state only, set allow_delete to delete it", hostname)
+		return schema.Noop(d, meta)
+	}
+
+	if err := deleteZones([]string{hostname}, d.Get("force_destroy").(bool), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return err
+	}
 
-	return schema.Noop(d, meta)
+	d.SetId("")
+	return nil
 }
 
 func resourceDNSv2ZoneExists(d *schema.ResourceData, meta interface{}) (bool, error) {
@@ -412,6 +447,26 @@ func populateDNSv2ZoneObject(d *schema.ResourceData, zone *dnsv2.ZoneCreate) {
 	}
 }
 
+// checkAliasTarget verifies the target of an alias zone is a primary zone in the contract.
+// Targets that can't be read are left to the API to check.
+func checkAliasTarget(zone, target, contract string) error {
+	tzone, err := dnsv2.GetZone(target)
+	if err != nil {
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+			return fmt.Errorf("target %s of Alias zone %s does not exist", target, zone)
+		}
+		log.Printf("[WARNING] [Akamai DNSv2] Reading target [%s] of Alias zone [%s] failed, not checking it: %s", target, zone, err)
+		return nil
+	}
+	if !strings.EqualFold(tzone.Type, "PRIMARY") {
+		return fmt.Errorf("target %s of Alias zone %s is a %s zone, not a PRIMARY one", target, zone, strings.ToUpper(tzone.Type))
+	}
+	if strings.TrimPrefix(tzone.ContractId, "ctr_") != strings.TrimPrefix(contract, "ctr_") {
+		return fmt.Errorf("target %s of Alias zone %s is not in contract %s", target, zone, contract)
+	}
+	return nil
+}
+
 // utility method to verify zone config fields based on type. not worrying about required fields ....
 func checkDNSv2Zone(d *schema.ResourceData) error {
 
@@ -434,9 +489,17 @@ func checkDNSv2Zone(d *schema.ResourceData) error {
 	if ztype != "ALIAS" && target != "" {
 		return fmt.Errorf("target can not be populated in %s zone %s configuration", ztype, zone)
 	}
+	if ztype == "ALIAS" && d.HasChange("target") {
+		if err := checkAliasTarget(zone, target, d.Get("contract").(string)); err != nil {
+			return err
+		}
+	}
 	if signandserve && ztype == "ALIAS" {
 		return fmt.Errorf("sign_and_serve is not valid in %s zone %s configuration", ztype, zone)
 	}
+	if !signandserve && d.Get("force_key_rotation").(string) != "" {
+		return fmt.Errorf("force_key_rotation requires sign_and_serve in zone %s configuration", zone)
+	}
 	if ztype != "SECONDARY" && len(tsig) > 0 {
 		return fmt.Errorf("tsig_key can not be populated in %s zone %s configuration", ztype, zone)
 	}
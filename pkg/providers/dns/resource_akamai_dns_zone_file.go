@@ -0,0 +1,159 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The recordsets of a zone from a master zone file in BIND format. The file is diffed
+// against the recordsets of the zone and the result is applied as one changelist, so
+// large zones are migrated in a single apply. The SOA and apex NS recordsets are left
+// to Edge DNS.
+func resourceDNSZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDNSZoneFileCreate,
+		Read:   resourceDNSZoneFileRead,
+		Update: resourceDNSZoneFileUpdate,
+		Delete: resourceDNSZoneFileDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"zone_file": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentZoneFiles,
+				Description:      "The master zone file in BIND format",
+			},
+			"recordset_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of recordsets in the zone file, without the SOA and apex NS recordsets",
+			},
+		},
+	}
+}
+
+func resourceDNSZoneFileCreate(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Get("zone").(string)
+	log.Printf("[INFO] [Akamai DNSv2] Zone file Create for zone [%s]", zone)
+
+	if err := applyZoneFile(zone, d.Get("zone_file").(string)); err != nil {
+		return err
+	}
+
+	d.SetId(zone)
+	return resourceDNSZoneFileRead(d, meta)
+}
+
+func resourceDNSZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Id()
+	log.Printf("[DEBUG] [Akamai DNSv2] Zone file Read for zone [%s]", zone)
+
+	current, err := listRecordsets(zone)
+	if err != nil {
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+			log.Printf("[WARNING] [Akamai DNSv2] Zone [%s] not found, removing from state", zone)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	managed := make([]dnsv2.Recordset, 0, len(current))
+	for _, rs := range current {
+		if !isApexRecordset(zone, rs) {
+			managed = append(managed, rs)
+		}
+	}
+
+	// keep the zone file as written while it matches the zone, so only drift shows in plans
+	file := d.Get("zone_file").(string)
+	if desired, err := parseZoneFile(zone, file); err != nil || len(diffRecordsets(zone, current, desired)) > 0 {
+		file = formatZoneFile(managed)
+	}
+
+	attrs := map[string]interface{}{
+		"zone":            zone,
+		"zone_file":       file,
+		"recordset_count": len(managed),
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceDNSZoneFileUpdate(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] [Akamai DNSv2] Zone file Update for zone [%s]", d.Id())
+
+	if err := applyZoneFile(d.Id(), d.Get("zone_file").(string)); err != nil {
+		return err
+	}
+	return resourceDNSZoneFileRead(d, meta)
+}
+
+// Deletes every recordset of the zone but the SOA and apex NS recordsets
+func resourceDNSZoneFileDelete(d *schema.ResourceData, meta interface{}) error {
+	zone := d.Id()
+	log.Printf("[INFO] [Akamai DNSv2] Zone file Delete for zone [%s]", zone)
+
+	current, err := listRecordsets(zone)
+	if err != nil {
+		if dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound() {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	if err := submitChangelist(zone, diffRecordsets(zone, current, nil)); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyZoneFile brings the recordsets of the zone in line with the zone file
+func applyZoneFile(zone, file string) error {
+	desired, err := parseZoneFile(zone, file)
+	if err != nil {
+		return fmt.Errorf("zone file of %s: %s", zone, err)
+	}
+	if err := checkRecordsetNames(zone, desired); err != nil {
+		return fmt.Errorf("zone file of %s: %s", zone, err)
+	}
+
+	current, err := listRecordsets(zone)
+	if err != nil {
+		return err
+	}
+	changes := diffRecordsets(zone, current, desired)
+	log.Printf("[DEBUG] [Akamai DNSv2] Zone file of [%s] has %d changes", zone, len(changes))
+	return submitChangelist(zone, changes)
+}
+
+// suppressEquivalentZoneFiles ignores formatting, ordering and relative names, and the
+// SOA and apex NS recordsets which aren't managed through the zone file
+func suppressEquivalentZoneFiles(k, old, new string, d *schema.ResourceData) bool {
+	zone := d.Get("zone").(string)
+	a, err := parseZoneFile(zone, old)
+	if err != nil {
+		return false
+	}
+	b, err := parseZoneFile(zone, new)
+	if err != nil {
+		return false
+	}
+	return len(diffRecordsets(zone, a, b)) == 0
+}
@@ -0,0 +1,95 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPrimaryZone creates an active primary zone with its SOA and NS records on the mock server
+func testPrimaryZone(t *testing.T, name string) {
+	zone := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: name, Type: "PRIMARY"})
+	require.NoError(t, zone.Save(dnsv2.ZoneQueryString{Contract: mockapi.ContractID}))
+	require.NoError(t, zone.SaveChangelist())
+	require.NoError(t, zone.SubmitChangelist())
+}
+
+const testZoneFile = `$ORIGIN example.com.
+$TTL 300
+@     IN NS    ns1.other.net.
+www   IN A     10.0.0.1
+      IN A     10.0.0.2
+mail  IN MX    10 mx1
+alias IN CNAME www
+`
+
+func TestResourceDNSZoneFile(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	d := schema.TestResourceDataRaw(t, resourceDNSZoneFile().Schema, map[string]interface{}{
+		"zone":      "example.com",
+		"zone_file": testZoneFile,
+	})
+	require.NoError(t, resourceDNSZoneFileCreate(d, nil))
+	assert.Equal(t, "example.com", d.Id())
+	assert.Equal(t, 3, d.Get("recordset_count"))
+	assert.Equal(t, testZoneFile, d.Get("zone_file"))
+
+	recordsets, err := listRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, recordsets, 5)
+	www, err := dnsv2.GetRecord("example.com", "www.example.com", "A")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"10.0.0.1", "10.0.0.2"}, www.Target)
+
+	// records created outside the zone file show as drift
+	extra := &dnsv2.RecordBody{Name: "extra.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.9"}}
+	require.NoError(t, extra.Save("example.com"))
+	require.NoError(t, resourceDNSZoneFileRead(d, nil))
+	assert.Equal(t, 4, d.Get("recordset_count"))
+	assert.Contains(t, d.Get("zone_file"), "extra.example.com.\t300\tIN\tA\t10.0.0.9")
+	assert.False(t, suppressEquivalentZoneFiles("zone_file", d.Get("zone_file").(string), testZoneFile, d))
+
+	// and are deleted on the next apply, in one changelist with the other changes
+	d.Set("zone_file", testZoneFile+"api   IN A     10.0.0.5\n")
+	require.NoError(t, resourceDNSZoneFileUpdate(d, nil))
+	assert.Equal(t, 4, d.Get("recordset_count"))
+	_, err = dnsv2.GetRecord("example.com", "extra.example.com", "A")
+	assert.Error(t, err)
+
+	imported := schema.TestResourceDataRaw(t, resourceDNSZoneFile().Schema, map[string]interface{}{})
+	imported.SetId("example.com")
+	require.NoError(t, resourceDNSZoneFileRead(imported, nil))
+	assert.True(t, suppressEquivalentZoneFiles("zone_file", imported.Get("zone_file").(string), d.Get("zone_file").(string), d))
+
+	// the SOA and apex NS records are left to Edge DNS
+	require.NoError(t, resourceDNSZoneFileDelete(d, nil))
+	recordsets, err = listRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, recordsets, 2)
+}
+
+func TestResourceDNSZoneFile_rejected(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	d := schema.TestResourceDataRaw(t, resourceDNSZoneFile().Schema, map[string]interface{}{
+		"zone":      "example.com",
+		"zone_file": "$TTL 300\nwww.example.net. IN A 10.0.0.1\n",
+	})
+	err := resourceDNSZoneFileCreate(d, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "www.example.net is outside of the zone")
+	assert.Empty(t, d.Id())
+}
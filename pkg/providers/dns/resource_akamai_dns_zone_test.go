@@ -1,14 +1,20 @@
 package dns
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"testing"
+	"time"
 
 	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccAkamaiDNSPrimaryZoneConfig = fmt.Sprintf(`
@@ -202,3 +208,111 @@ func TestValidateZoneType(t *testing.T) {
 		}
 	}
 }
+
+func TestResourceDNSv2ZoneDelete(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	deleteRequestPollInterval = 0
+	testPrimaryZone(t, "example.com")
+
+	www := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	require.NoError(t, www.Save("example.com"))
+
+	zone := func(allowDelete, forceDestroy bool) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceDNSv2Zone().Schema, map[string]interface{}{
+			"contract":      mockapi.ContractID,
+			"group":         "100",
+			"zone":          "example.com",
+			"type":          "PRIMARY",
+			"allow_delete":  allowDelete,
+			"force_destroy": forceDestroy,
+		})
+		d.SetId("example.com")
+		return d
+	}
+
+	// without allow_delete the zone is only removed from the state
+	require.NoError(t, resourceDNSv2ZoneDelete(zone(false, false), nil))
+	_, err := dnsv2.GetZone("example.com")
+	require.NoError(t, err)
+
+	// the safety checks keep zones with records
+	err = resourceDNSv2ZoneDelete(zone(true, false), nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "example.com: ZONE_HAS_RECORDS")
+
+	d := zone(true, true)
+	require.NoError(t, resourceDNSv2ZoneDelete(d, nil))
+	assert.Empty(t, d.Id())
+	_, err = dnsv2.GetZone("example.com")
+	assert.True(t, dnsv2.IsConfigDNSError(err) && err.(dnsv2.ConfigDNSError).NotFound())
+}
+
+func TestDeleteZones_failures(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	deleteRequestPollInterval = 0
+	testPrimaryZone(t, "example.com")
+	testPrimaryZone(t, "example.net")
+
+	err := deleteZones([]string{"example.com", "missing.com", "example.net", "other.com"}, false, time.Minute)
+	require.Error(t, err)
+	assert.Equal(t, "failed to delete 2 of 4 zones:\nmissing.com: ZONE_NOT_FOUND\nother.com: ZONE_NOT_FOUND", err.Error())
+
+	_, err = dnsv2.GetZone("example.net")
+	assert.Error(t, err)
+}
+
+func TestResourceDNSv2ZoneAlias(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+	testSecondaryZone(t, "example.org", "10.0.0.1")
+	other := dnsv2.NewZone(dnsv2.ZoneCreate{Zone: "example.net", Type: "PRIMARY"})
+	require.NoError(t, other.Save(dnsv2.ZoneQueryString{Contract: "ctr_2-OTHER"}))
+
+	alias := func(target string) *schema.ResourceData {
+		return schema.TestResourceDataRaw(t, resourceDNSv2Zone().Schema, map[string]interface{}{
+			"contract": mockapi.ContractID,
+			"group":    "100",
+			"zone":     "alias.com",
+			"type":     "ALIAS",
+			"target":   target,
+		})
+	}
+
+	assert.EqualError(t, checkDNSv2Zone(alias("missing.com")), "target missing.com of Alias zone alias.com does not exist")
+	assert.EqualError(t, checkDNSv2Zone(alias("example.org")), "target example.org of Alias zone alias.com is a SECONDARY zone, not a PRIMARY one")
+	assert.EqualError(t, checkDNSv2Zone(alias("example.net")), "target example.net of Alias zone alias.com is not in contract "+mockapi.ContractID)
+
+	// the target is only checked when it changes
+	r := resourceDNSv2Zone()
+	missing := alias("missing.com")
+	missing.SetId("alias.com")
+	state := missing.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"contract": mockapi.ContractID,
+		"group":    "100",
+		"zone":     "alias.com",
+		"type":     "ALIAS",
+		"target":   "missing.com",
+		"comment":  "updated",
+	}), nil)
+	require.NoError(t, err)
+	unchanged, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	assert.NoError(t, checkDNSv2Zone(unchanged))
+
+	d := alias("example.com")
+	require.NoError(t, resourceDNSv2ZoneCreate(d, nil))
+	assert.Equal(t, "example.com", d.Get("target"))
+	target, err := dnsv2.GetZone("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), target.AliasCount)
+}
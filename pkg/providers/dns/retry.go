@@ -0,0 +1,172 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Retry policy defaults, used when the provider dns block doesn't set them
+const (
+	defaultRetryMax     = 5
+	defaultRetryWaitMin = "1s"
+	defaultRetryWaitMax = "30s"
+)
+
+// soaSerialErrorCode is the last segment of the problem type of the 400 returned when a
+// write doesn't increment the SOA serial number
+const soaSerialErrorCode = "soa-serial-number-not-incremented"
+
+type (
+	// retryPolicy is how record writes failing on transient errors are retried, with
+	// exponential backoff and jitter between the attempts
+	retryPolicy struct {
+		Max     int
+		WaitMin time.Duration
+		WaitMax time.Duration
+	}
+
+	// errorClass is how a failed write is handled
+	errorClass int
+)
+
+// Error classes
+const (
+	errorPermanent errorClass = iota
+	errorConcurrencyConflict
+	errorSOASerial
+	errorNotFound
+)
+
+// dnsRetry is the retry policy of the provider
+var dnsRetry = retryPolicy{Max: defaultRetryMax, WaitMin: time.Second, WaitMax: 30 * time.Second}
+
+func (c errorClass) String() string {
+	switch c {
+	case errorConcurrencyConflict:
+		return "concurrency conflict"
+	case errorSOASerial:
+		return "SOA serial number not incremented"
+	case errorNotFound:
+		return "not found"
+	}
+	return "permanent error"
+}
+
+// classifyError returns the class of an error returned by a write, from the status and
+// problem type of API errors
+func classifyError(err error) errorClass {
+	var apiErr client.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Status == http.StatusConflict:
+			return errorConcurrencyConflict
+		case apiErr.Status == http.StatusNotFound:
+			return errorNotFound
+		case apiErr.Status == http.StatusBadRequest && path.Base(apiErr.Type) == soaSerialErrorCode:
+			return errorSOASerial
+		}
+		return errorPermanent
+	}
+
+	e, ok := err.(dnsv2.ConfigDNSError)
+	switch {
+	case !ok:
+		return errorPermanent
+	case e.ConcurrencyConflict():
+		return errorConcurrencyConflict
+	case e.NotFound():
+		return errorNotFound
+	}
+	return errorPermanent
+}
+
+// backoff returns the wait before the retry with the index, starting at 0. The wait is
+// random between the minimum wait and a maximum doubling with each retry, up to WaitMax.
+func (p retryPolicy) backoff(retry int) time.Duration {
+	max := p.WaitMax
+	if retry < 32 && p.WaitMin<<uint(retry) < max {
+		max = p.WaitMin << uint(retry)
+	}
+	if max <= p.WaitMin {
+		return p.WaitMin
+	}
+	return p.WaitMin + time.Duration(rand.Int63n(int64(max-p.WaitMin)+1))
+}
+
+// retrySchema returns the retry settings of the provider dns block
+func retrySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"retry_max": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      defaultRetryMax,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The maximum number of retries of a write failing on a concurrency conflict or SOA serial number",
+		},
+		"retry_wait_min": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      defaultRetryWaitMin,
+			ValidateFunc: validateDuration,
+			Description:  "The minimum wait before a retry",
+		},
+		"retry_wait_max": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      defaultRetryWaitMax,
+			ValidateFunc: validateDuration,
+			Description:  "The maximum wait before a retry",
+		},
+	}
+}
+
+// getRetryPolicy returns the retry policy of the provider dns block, or the default one
+func getRetryPolicy(d resourceData) (retryPolicy, error) {
+	settings := map[string]interface{}{
+		"retry_max":      defaultRetryMax,
+		"retry_wait_min": defaultRetryWaitMin,
+		"retry_wait_max": defaultRetryWaitMax,
+	}
+	if v, ok := d.GetOk("dns"); ok {
+		if list := v.(set).List(); len(list) > 0 {
+			for k, v := range list[0].(map[string]interface{}) {
+				if _, ok := settings[k]; ok && v != nil {
+					settings[k] = v
+				}
+			}
+		}
+	}
+
+	p := retryPolicy{Max: settings["retry_max"].(int)}
+	var err error
+	if p.WaitMin, err = time.ParseDuration(settings["retry_wait_min"].(string)); err != nil {
+		return p, fmt.Errorf("retry_wait_min: %s", err)
+	}
+	if p.WaitMax, err = time.ParseDuration(settings["retry_wait_max"].(string)); err != nil {
+		return p, fmt.Errorf("retry_wait_max: %s", err)
+	}
+	if p.WaitMin > p.WaitMax {
+		return p, fmt.Errorf("retry_wait_min %s is greater than retry_wait_max %s", p.WaitMin, p.WaitMax)
+	}
+	return p, nil
+}
+
+func validateDuration(v interface{}, k string) (ws []string, es []error) {
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s: %s", k, err)}
+	}
+	if d < 0 {
+		return nil, []error{fmt.Errorf("%s: must not be negative", k)}
+	}
+	return nil, nil
+}
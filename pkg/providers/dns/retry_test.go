@@ -0,0 +1,93 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	changelist := &dnsv2.ZoneCreate{Zone: "example.com"}
+	require.NoError(t, changelist.SaveChangelist())
+	assert.Equal(t, errorConcurrencyConflict, classifyError(changelist.SaveChangelist()))
+
+	_, err := dnsv2.GetRecord("example.com", "missing.example.com", "A")
+	assert.Equal(t, errorNotFound, classifyError(err))
+
+	soa, err := dnsv2.GetRecord("example.com", "example.com", "SOA")
+	require.NoError(t, err)
+	assert.Equal(t, errorSOASerial, classifyError(recordWrite("example.com", changeEdit, soa)("example.com")))
+	assert.Equal(t, errorPermanent, classifyError(soa.Update("example.com")), "dnsv2 record errors don't keep the problem type")
+
+	soa.Target = []string{"malformed"}
+	assert.Equal(t, errorPermanent, classifyError(recordWrite("example.com", changeEdit, soa)("example.com")))
+
+	assert.Equal(t, errorPermanent, classifyError(errors.New("bad request")))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := retryPolicy{Max: 10, WaitMin: 100 * time.Millisecond, WaitMax: time.Second}
+	for retry := 0; retry < 40; retry++ {
+		max := p.WaitMax
+		if retry < 4 {
+			max = p.WaitMin << uint(retry)
+		}
+		for i := 0; i < 20; i++ {
+			wait := p.backoff(retry)
+			assert.True(t, wait >= p.WaitMin && wait <= max, "retry %d waits %s", retry, wait)
+		}
+	}
+
+	p = retryPolicy{Max: 1, WaitMin: time.Second, WaitMax: time.Second}
+	assert.Equal(t, time.Second, p.backoff(3))
+}
+
+func TestGetRetryPolicy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{})
+	p, err := getRetryPolicy(d)
+	require.NoError(t, err)
+	assert.Equal(t, retryPolicy{Max: 5, WaitMin: time.Second, WaitMax: 30 * time.Second}, p)
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"dns": []interface{}{map[string]interface{}{"retry_max": 2, "retry_wait_min": "10ms", "retry_wait_max": "2s"}},
+	})
+	p, err = getRetryPolicy(d)
+	require.NoError(t, err)
+	assert.Equal(t, retryPolicy{Max: 2, WaitMin: 10 * time.Millisecond, WaitMax: 2 * time.Second}, p)
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"dns": []interface{}{map[string]interface{}{"retry_wait_min": "1m"}},
+	})
+	_, err = getRetryPolicy(d)
+	assert.EqualError(t, err, "retry_wait_min 1m0s is greater than retry_wait_max 30s")
+}
+
+func TestExecuteRecordFunction_retries(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+	defer func(p retryPolicy) { dnsRetry = p }(dnsRetry)
+	dnsRetry = retryPolicy{Max: 2, WaitMin: time.Millisecond, WaitMax: 2 * time.Millisecond}
+
+	// a changelist left open by another client
+	require.NoError(t, (&dnsv2.ZoneCreate{Zone: "example.com"}).SaveChangelist())
+
+	record := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	err := executeRecordFunction("CREATE", nil, recordWrite("example.com", changeAdd, record), "example.com", "www.example.com", "A", false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "create gave up after 2 retries on concurrency conflict")
+}
@@ -0,0 +1,90 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+)
+
+// Bulk zone delete requests, which dnsv2 has no functions for
+
+var (
+	// deleteRequestPollInterval is the time between two status requests of a bulk zone delete
+	deleteRequestPollInterval = 10 * time.Second
+)
+
+type (
+	deleteRequestResponse struct {
+		RequestID      string `json:"requestId"`
+		ExpirationDate string `json:"expirationDate"`
+	}
+
+	deleteRequestStatus struct {
+		RequestID      string `json:"requestId"`
+		ZonesSubmitted int    `json:"zonesSubmitted"`
+		SuccessCount   int    `json:"successCount"`
+		FailureCount   int    `json:"failureCount"`
+		IsComplete     bool   `json:"isComplete"`
+	}
+
+	deleteRequestResult struct {
+		RequestID                string   `json:"requestId"`
+		SuccessfullyDeletedZones []string `json:"successfullyDeletedZones"`
+		FailedZones              []struct {
+			Zone          string `json:"zone"`
+			FailureReason string `json:"failureReason"`
+		} `json:"failedZones"`
+	}
+)
+
+// deleteZones deletes the zones with one bulk delete request and waits for it to
+// complete. Zones holding records other than the SOA and apex NS ones are only deleted
+// when bypassSafetyChecks is set. Every zone that failed is reported in the error.
+func deleteZones(zones []string, bypassSafetyChecks bool, timeout time.Duration) error {
+	body := map[string]interface{}{"zones": zones}
+	path := "/config-dns/v2/zones/delete-requests?bypassSafetyChecks=" + strconv.FormatBool(bypassSafetyChecks)
+
+	log.Printf("[INFO] [Akamai DNSv2] Submitting delete request for zones %v", zones)
+	var req deleteRequestResponse
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodPost, path, body, &req); err != nil {
+		return fmt.Errorf("submitting delete request for zones %s: %w", strings.Join(zones, ", "), err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		var status deleteRequestStatus
+		if err := apiclient.DoRequest(dnsv2.Config, http.MethodGet, "/config-dns/v2/zones/delete-requests/"+req.RequestID, nil, &status); err != nil {
+			return fmt.Errorf("delete request %s status: %w", req.RequestID, err)
+		}
+		log.Printf("[DEBUG] [Akamai DNSv2] Delete request [%s]: %d of %d zones deleted, %d failed", req.RequestID, status.SuccessCount, status.ZonesSubmitted, status.FailureCount)
+		if status.IsComplete {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for delete request %s of zones %s", req.RequestID, strings.Join(zones, ", "))
+		}
+		time.Sleep(deleteRequestPollInterval)
+	}
+
+	var result deleteRequestResult
+	if err := apiclient.DoRequest(dnsv2.Config, http.MethodGet, "/config-dns/v2/zones/delete-requests/"+req.RequestID+"/result", nil, &result); err != nil {
+		return fmt.Errorf("delete request %s result: %w", req.RequestID, err)
+	}
+	if len(result.FailedZones) == 0 {
+		return nil
+	}
+
+	failures := make([]string, 0, len(result.FailedZones))
+	for _, f := range result.FailedZones {
+		failures = append(failures, fmt.Sprintf("%s: %s", f.Zone, f.FailureReason))
+	}
+	sort.Strings(failures)
+	return fmt.Errorf("failed to delete %d of %d zones:\n%s", len(failures), len(zones), strings.Join(failures, "\n"))
+}
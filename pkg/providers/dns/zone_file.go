@@ -0,0 +1,254 @@
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+)
+
+// nameFields are the rdata fields holding domain names, by record type. Relative names
+// in them are qualified with the origin when a zone file is parsed.
+var nameFields = map[string][]int{
+	RRTypeCname: {0},
+	RRTypeNs:    {0},
+	RRTypePtr:   {0},
+	RRTypeMx:    {1},
+	RRTypeAfsdb: {1},
+	RRTypeSrv:   {3},
+	RRTypeRp:    {0, 1},
+	RRTypeSoa:   {0, 1},
+}
+
+// zoneFileLine is a logical line of a zone file, with parentheses joined
+type zoneFileLine struct {
+	number int
+	owner  bool
+	tokens []string
+}
+
+// parseZoneFile parses a master zone file in BIND format into the recordsets of the
+// zone. Names are returned fully qualified without the trailing dot, as used by the
+// Config DNS v2 API. Errors report the line of the zone file.
+func parseZoneFile(zone, data string) ([]dnsv2.Recordset, error) {
+	lines, err := zoneFileLines(data)
+	if err != nil {
+		return nil, err
+	}
+
+	origin := strings.TrimSuffix(strings.ToLower(zone), ".")
+	ttl := -1
+	owner := ""
+	index := make(map[string]int)
+	var recordsets []dnsv2.Recordset
+
+	for _, line := range lines {
+		tokens := line.tokens
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $ORIGIN takes one domain name", line.number)
+			}
+			origin = qualifyName(tokens[1], origin)
+			continue
+		case "$TTL":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("line %d: $TTL takes one value", line.number)
+			}
+			if ttl, err = parseTTL(tokens[1]); err != nil {
+				return nil, fmt.Errorf("line %d: %s", line.number, err)
+			}
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("line %d: %s is not supported", line.number, tokens[0])
+		}
+
+		if line.owner {
+			owner = qualifyName(tokens[0], origin)
+			tokens = tokens[1:]
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: record without an owner name", line.number)
+		}
+
+		// the TTL and class are optional and come in any order before the type
+		recordTTL := ttl
+		for len(tokens) > 0 {
+			if strings.EqualFold(tokens[0], "IN") {
+				tokens = tokens[1:]
+				continue
+			}
+			if v, err := parseTTL(tokens[0]); err == nil {
+				recordTTL = v
+				tokens = tokens[1:]
+				continue
+			}
+			break
+		}
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("line %d: expected a record type and data", line.number)
+		}
+		// only $TTL sets the default TTL, a record TTL applies to the record alone
+		if recordTTL < 0 {
+			return nil, fmt.Errorf("line %d: no TTL for the record and no $TTL before it", line.number)
+		}
+
+		rtype := strings.ToUpper(tokens[0])
+		fields := tokens[1:]
+		for _, i := range nameFields[rtype] {
+			if i < len(fields) {
+				fields[i] = qualifyName(fields[i], origin) + "."
+			}
+		}
+		rdata := strings.Join(fields, " ")
+
+		key := owner + " " + rtype
+		if i, ok := index[key]; ok {
+			recordsets[i].Rdata = append(recordsets[i].Rdata, rdata)
+			continue
+		}
+		index[key] = len(recordsets)
+		recordsets = append(recordsets, dnsv2.Recordset{Name: owner, Type: rtype, TTL: recordTTL, Rdata: []string{rdata}})
+	}
+
+	return recordsets, nil
+}
+
+// zoneFileLines strips comments and splits a zone file into logical lines of tokens.
+// Quoted strings are kept as one token, quotes included.
+func zoneFileLines(data string) ([]zoneFileLine, error) {
+	var lines []zoneFileLine
+	var current *zoneFileLine
+	depth := 0
+
+	for n, text := range strings.Split(data, "\n") {
+		number := n + 1
+		text = strings.TrimRight(text, "\r")
+		if current == nil {
+			current = &zoneFileLine{number: number, owner: text != "" && text[0] != ' ' && text[0] != '\t'}
+		}
+
+		var token strings.Builder
+		quoted := false
+		flush := func() {
+			if token.Len() > 0 {
+				current.tokens = append(current.tokens, token.String())
+				token.Reset()
+			}
+		}
+	scan:
+		for i := 0; i < len(text); i++ {
+			c := text[i]
+			switch {
+			case quoted:
+				token.WriteByte(c)
+				if c == '\\' && i+1 < len(text) {
+					i++
+					token.WriteByte(text[i])
+				} else if c == '"' {
+					quoted = false
+				}
+			case c == '"':
+				token.WriteByte(c)
+				quoted = true
+			case c == ';':
+				break scan
+			case c == '(':
+				flush()
+				depth++
+			case c == ')':
+				flush()
+				if depth == 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parentheses", number)
+				}
+				depth--
+			case c == ' ' || c == '\t':
+				flush()
+			default:
+				token.WriteByte(c)
+			}
+		}
+		if quoted {
+			return nil, fmt.Errorf("line %d: unterminated quoted string", number)
+		}
+		flush()
+
+		if depth > 0 {
+			continue
+		}
+		if len(current.tokens) > 0 {
+			lines = append(lines, *current)
+		}
+		current = nil
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", current.number)
+	}
+
+	return lines, nil
+}
+
+// qualifyName returns name as a fully qualified lower case name without the trailing dot
+func qualifyName(name, origin string) string {
+	switch {
+	case name == "@":
+		return origin
+	case strings.HasSuffix(name, "."):
+		return strings.ToLower(strings.TrimSuffix(name, "."))
+	case origin == "":
+		return strings.ToLower(name)
+	}
+	return strings.ToLower(name + "." + origin)
+}
+
+// parseTTL parses a TTL in seconds or with BIND units, e.g. 1h30m
+func parseTTL(s string) (int, error) {
+	if v, err := strconv.Atoi(s); err == nil && v >= 0 {
+		return v, nil
+	}
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, digits := 0, ""
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= '0' && c <= '9' {
+			digits += string(c)
+			continue
+		}
+		unit, ok := units[c|0x20]
+		if !ok || digits == "" {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		v, _ := strconv.Atoi(digits)
+		total += v * unit
+		digits = ""
+	}
+	if digits != "" || s == "" {
+		return 0, fmt.Errorf("invalid TTL %q", s)
+	}
+	return total, nil
+}
+
+// formatZoneFile writes recordsets as a zone file with fully qualified names, sorted
+// by name and type
+func formatZoneFile(recordsets []dnsv2.Recordset) string {
+	sorted := append([]dnsv2.Recordset(nil), recordsets...)
+	sortRecordsets(sorted)
+
+	var b strings.Builder
+	for _, rs := range sorted {
+		for _, rdata := range rs.Rdata {
+			fmt.Fprintf(&b, "%s.\t%d\tIN\t%s\t%s\n", rs.Name, rs.TTL, rs.Type, rdata)
+		}
+	}
+	return b.String()
+}
+
+func sortRecordsets(recordsets []dnsv2.Recordset) {
+	sort.Slice(recordsets, func(i, j int) bool {
+		if recordsets[i].Name != recordsets[j].Name {
+			return recordsets[i].Name < recordsets[j].Name
+		}
+		return recordsets[i].Type < recordsets[j].Type
+	})
+}
@@ -0,0 +1,87 @@
+package dns
+
+import (
+	"testing"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZoneFile(t *testing.T) {
+	file := `$ORIGIN example.com.
+$TTL 1h
+@       IN  SOA  a1-1.akam.net. hostmaster.example.com. (
+                 2021010101 ; serial
+                 3600 600 604800 300 )
+        IN  NS   a1-1.akam.net.
+www     300 IN A 10.0.0.1
+        300 IN A 10.0.0.2
+mail    IN  MX   10 mx1
+        IN  MX   20 mx2.example.net.
+txt     IN  TXT  "v=spf1 include:example.net ~all" ; policy
+alias   IN  CNAME www
+$ORIGIN sub.example.com.
+host    60  A    10.0.1.1
+cached  0s  A    10.0.1.2
+`
+	recordsets, err := parseZoneFile("example.com", file)
+	require.NoError(t, err)
+
+	assert.Equal(t, []dnsv2.Recordset{
+		{Name: "example.com", Type: "SOA", TTL: 3600, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 2021010101 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"a1-1.akam.net."}},
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 3600, Rdata: []string{"10 mx1.example.com.", "20 mx2.example.net."}},
+		{Name: "txt.example.com", Type: "TXT", TTL: 3600, Rdata: []string{`"v=spf1 include:example.net ~all"`}},
+		{Name: "alias.example.com", Type: "CNAME", TTL: 3600, Rdata: []string{"www.example.com."}},
+		{Name: "host.sub.example.com", Type: "A", TTL: 60, Rdata: []string{"10.0.1.1"}},
+		{Name: "cached.sub.example.com", Type: "A", TTL: 0, Rdata: []string{"10.0.1.2"}},
+	}, recordsets)
+}
+
+func TestParseZoneFile_errors(t *testing.T) {
+	tests := map[string]struct {
+		file string
+		err  string
+	}{
+		"no ttl":      {"www IN A 10.0.0.1\n", "line 1: no TTL"},
+		"no owner":    {"$TTL 300\n  IN A 10.0.0.1\n", "line 2: record without an owner name"},
+		"no data":     {"$TTL 300\n\nwww IN A\n", "line 3: expected a record type and data"},
+		"parentheses": {"$TTL 300\n@ SOA a b ( 1 2\n", "line 2: unbalanced parentheses"},
+		"quote":       {"$TTL 300\ntxt TXT \"open\n", "line 2: unterminated quoted string"},
+		"include":     {"$INCLUDE other.zone\n", "line 1: $INCLUDE is not supported"},
+		"invalid ttl": {"$TTL 1x\n", `line 1: invalid TTL "1x"`},
+		"record ttl":  {"www 300 IN A 10.0.0.1\nmail IN MX 10 mx1\n", "line 2: no TTL"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseZoneFile("example.com", test.file)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.err)
+		})
+	}
+}
+
+func TestDiffRecordsets(t *testing.T) {
+	current := []dnsv2.Recordset{
+		{Name: "example.com", Type: "SOA", TTL: 86400, Rdata: []string{"a1-1.akam.net. hostmaster.example.com. 1 3600 600 604800 300"}},
+		{Name: "example.com", Type: "NS", TTL: 86400, Rdata: []string{"a1-1.akam.net."}},
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.2", "10.0.0.1"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 300, Rdata: []string{"10 MX1.example.com"}},
+		{Name: "old.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.9"}},
+	}
+	desired := []dnsv2.Recordset{
+		{Name: "example.com", Type: "NS", TTL: 3600, Rdata: []string{"ns1.other.net."}},
+		{Name: "www.example.com", Type: "A", TTL: 300, Rdata: []string{"10.0.0.1", "10.0.0.2"}},
+		{Name: "mail.example.com", Type: "MX", TTL: 300, Rdata: []string{"10  mx1.example.com."}},
+		{Name: "old.example.com", Type: "CNAME", TTL: 300, Rdata: []string{"www.example.com."}},
+		{Name: "new.example.com", Type: "A", TTL: 60, Rdata: []string{"10.0.0.3"}},
+	}
+
+	assert.Equal(t, []recordsetChange{
+		{Name: "old.example.com", Type: "A", Op: changeDelete},
+		{Name: "new.example.com", Type: "A", Op: changeAdd, TTL: 60, Rdata: []string{"10.0.0.3"}},
+		{Name: "old.example.com", Type: "CNAME", Op: changeAdd, TTL: 300, Rdata: []string{"www.example.com."}},
+	}, diffRecordsets("example.com", current, desired))
+}
@@ -0,0 +1,188 @@
+package dns
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+)
+
+var (
+	// recordBatchWindow is how long a record write waits for concurrent writes to the
+	// same zone to join its changelist, when other writes to the zone are in progress
+	recordBatchWindow = 200 * time.Millisecond
+
+	// zoneWriters holds a *zoneWriter per zone
+	zoneWriters sync.Map
+
+	// recordLocks holds a *sync.Mutex per recordset
+	recordLocks sync.Map
+)
+
+type (
+	// zoneWriter serializes the writes to a zone. Record changes queued while a write is
+	// in progress are written together as one changelist by the next writer.
+	zoneWriter struct {
+		sync.Mutex
+
+		queueLock sync.Mutex
+		queue     []*queuedChange
+		// writes is the number of writes queued or in progress
+		writes int
+	}
+
+	queuedChange struct {
+		change recordsetChange
+		done   chan error
+	}
+)
+
+// getZoneWriter returns the writer of the zone
+func getZoneWriter(zone string) *zoneWriter {
+	w, _ := zoneWriters.LoadOrStore(strings.ToLower(strings.TrimSuffix(zone, ".")), &zoneWriter{})
+	return w.(*zoneWriter)
+}
+
+// getRecordLock returns the lock of a recordset, held while the recordset is read and written
+func getRecordLock(zone, host, recordtype string) *sync.Mutex {
+	key := strings.ToLower(strings.TrimSuffix(zone, ".")) + " " + recordsetKey(dnsv2.Recordset{Name: host, Type: recordtype})
+	lock, _ := recordLocks.LoadOrStore(key, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// recordWrite returns the function writing the record to the zone with the operation. SOA
+// records are written on their own, other records are batched with the concurrent writes
+// to the zone.
+func recordWrite(zone, op string, record *dnsv2.RecordBody) recordFunction {
+	w := getZoneWriter(zone)
+
+	if strings.EqualFold(record.RecordType, RRTypeSoa) {
+		method := http.MethodDelete
+		switch op {
+		case changeAdd:
+			method = http.MethodPost
+		case changeEdit:
+			method = http.MethodPut
+		}
+		path := fmt.Sprintf("/config-dns/v2/zones/%s/names/%s/types/SOA", zone, record.Name)
+		var body interface{}
+		if op != changeDelete {
+			body = dnsv2.Recordset{Name: record.Name, Type: RRTypeSoa, TTL: record.TTL, Rdata: record.Target}
+		}
+		// written through apiclient rather than dnsv2, which drops the API error
+		// classifyError needs
+		return func(zone string, _ ...bool) error {
+			w.Lock()
+			defer w.Unlock()
+			return apiclient.DoRequest(dnsv2.Config, method, path, body, nil)
+		}
+	}
+
+	c := recordsetChange{Name: record.Name, Type: strings.ToUpper(record.RecordType), Op: op}
+	if op != changeDelete {
+		c.TTL = record.TTL
+		c.Rdata = record.Target
+	}
+	return func(zone string, _ ...bool) error {
+		return w.write(zone, c)
+	}
+}
+
+// write queues the change and waits for it to be written, either by an earlier write
+// holding the zone or in a changelist with the changes queued until it gets the zone
+func (w *zoneWriter) write(zone string, c recordsetChange) error {
+	q := &queuedChange{change: c, done: make(chan error, 1)}
+	w.queueLock.Lock()
+	w.queue = append(w.queue, q)
+	busy := w.writes > 0
+	w.writes++
+	w.queueLock.Unlock()
+
+	defer func() {
+		w.queueLock.Lock()
+		w.writes--
+		w.queueLock.Unlock()
+	}()
+
+	// a lone write goes right away, concurrent ones wait for more to join without
+	// holding the zone
+	if busy {
+		time.Sleep(recordBatchWindow)
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	select {
+	case err := <-q.done:
+		return err
+	default:
+	}
+
+	w.queueLock.Lock()
+	batch := w.queue
+	w.queue = nil
+	w.queueLock.Unlock()
+
+	writeBatch(zone, batch)
+	return <-q.done
+}
+
+// writeBatch writes the queued changes as one changelist and reports the result to each.
+// Changes are turned into adds or edits depending on whether the recordset exists, and
+// deletes of missing recordsets succeed. When the changelist fails, each change is
+// written on its own so only the failing ones report an error.
+func writeBatch(zone string, batch []*queuedChange) {
+	current, err := listRecordsets(zone)
+	if err != nil {
+		for _, q := range batch {
+			q.done <- err
+		}
+		return
+	}
+	existing := make(map[string]bool, len(current))
+	for _, rs := range current {
+		existing[recordsetKey(rs)] = true
+	}
+
+	changes := make([]recordsetChange, 0, len(batch))
+	pending := make([]*queuedChange, 0, len(batch))
+	for _, q := range batch {
+		c := q.change
+		exists := existing[recordsetKey(dnsv2.Recordset{Name: c.Name, Type: c.Type})]
+		switch {
+		case c.Op == changeDelete && !exists:
+			log.Printf("[DEBUG] [Akamai DNSv2] Record [%s] [%s] already deleted from zone [%s]", c.Name, c.Type, zone)
+			q.done <- nil
+			continue
+		case c.Op != changeDelete && exists:
+			c.Op = changeEdit
+		case c.Op != changeDelete:
+			c.Op = changeAdd
+		}
+		changes = append(changes, c)
+		pending = append(pending, q)
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	log.Printf("[DEBUG] [Akamai DNSv2] Writing %d record changes to zone [%s]", len(changes), zone)
+	err = writeChangelist(zone, changes)
+	if err == nil || len(changes) == 1 {
+		for _, q := range pending {
+			q.done <- err
+		}
+		return
+	}
+
+	log.Printf("[WARNING] [Akamai DNSv2] Writing record changes to zone [%s] one at a time: %s", zone, err)
+	for i, q := range pending {
+		q.done <- writeChangelist(zone, changes[i:i+1])
+	}
+}
@@ -0,0 +1,125 @@
+package dns
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	dnsv2 "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecordLock(t *testing.T) {
+	assert.True(t, getRecordLock("example.com", "www.example.com", "A") == getRecordLock("Example.com.", "WWW.example.com", "a"))
+	assert.False(t, getRecordLock("example.com", "www.example.com", "A") == getRecordLock("example.net", "www.example.com", "A"))
+	assert.False(t, getRecordLock("example.com", "www.example.com", "A") == getRecordLock("example.com", "www.example.com", "AAAA"))
+}
+
+func TestRecordWrite(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+	testPrimaryZone(t, "example.net")
+
+	submits := func() int {
+		n := 0
+		for _, r := range srv.Requests() {
+			if r == "POST /config-dns/v2/changelists/example.com/submit" {
+				n++
+			}
+		}
+		return n
+	}
+	before := submits()
+
+	// concurrent writes to a zone are batched into a few changelists
+	var wg sync.WaitGroup
+	errs := make([]error, 20)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			record := &dnsv2.RecordBody{Name: fmt.Sprintf("host%d.example.com", i), RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+			errs[i] = recordWrite("example.com", changeAdd, record)("example.com", false)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.True(t, submits()-before < len(errs), "%d changelists for %d writes", submits()-before, len(errs))
+
+	current, err := listRecordsets("example.com")
+	require.NoError(t, err)
+	assert.Len(t, current, len(errs)+2)
+
+	// adds of existing recordsets are edits, deletes of missing recordsets succeed
+	record := &dnsv2.RecordBody{Name: "host0.example.com", RecordType: "A", TTL: 600, Target: []string{"10.0.0.2"}}
+	require.NoError(t, recordWrite("example.com", changeAdd, record)("example.com", false))
+	rs, err := dnsv2.GetRecord("example.com", "host0.example.com", "A")
+	require.NoError(t, err)
+	assert.Equal(t, 600, rs.TTL)
+	missing := &dnsv2.RecordBody{Name: "missing.example.com", RecordType: "A"}
+	assert.NoError(t, recordWrite("example.com", changeDelete, missing)("example.com", false))
+
+	// a rejected change doesn't fail the changes batched with it
+	good := &dnsv2.RecordBody{Name: "good.example.net", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	bad := &dnsv2.RecordBody{Name: "bad.other.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	w := getZoneWriter("example.net")
+	goodDone := &queuedChange{change: recordsetChange{Name: good.Name, Type: "A", Op: changeAdd, TTL: 300, Rdata: good.Target}, done: make(chan error, 1)}
+	badDone := &queuedChange{change: recordsetChange{Name: bad.Name, Type: "A", Op: changeAdd, TTL: 300, Rdata: bad.Target}, done: make(chan error, 1)}
+	w.Lock()
+	writeBatch("example.net", []*queuedChange{goodDone, badDone})
+	w.Unlock()
+	assert.NoError(t, <-goodDone.done)
+	assert.Error(t, <-badDone.done)
+	_, err = dnsv2.GetRecord("example.net", "good.example.net", "A")
+	assert.NoError(t, err)
+}
+
+func TestRecordWrite_alone(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+
+	window := recordBatchWindow
+	recordBatchWindow = time.Minute
+	defer func() { recordBatchWindow = window }()
+
+	// a write with no other write to the zone in progress doesn't wait for the batch window
+	start := time.Now()
+	record := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	require.NoError(t, recordWrite("example.com", changeAdd, record)("example.com", false))
+	assert.True(t, time.Since(start) < 10*time.Second)
+}
+
+func TestExecuteRecordFunction_concurrencyConflict(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	dnsv2.Init(srv.Config())
+	testPrimaryZone(t, "example.com")
+	defer func(p retryPolicy) { dnsRetry = p }(dnsRetry)
+	dnsRetry = retryPolicy{Max: 10, WaitMin: 50 * time.Millisecond, WaitMax: 100 * time.Millisecond}
+
+	// a changelist left open by another client conflicts until it is discarded
+	require.NoError(t, (&dnsv2.ZoneCreate{Zone: "example.com"}).SaveChangelist())
+	go func() {
+		time.Sleep(250 * time.Millisecond)
+		_ = apiclient.DoRequest(dnsv2.Config, http.MethodDelete, "/config-dns/v2/changelists/example.com", nil, nil)
+	}()
+
+	record := &dnsv2.RecordBody{Name: "www.example.com", RecordType: "A", TTL: 300, Target: []string{"10.0.0.1"}}
+	require.NoError(t, executeRecordFunction("CREATE", nil, recordWrite("example.com", changeAdd, record), "example.com", "www.example.com", "A", false))
+	_, err := dnsv2.GetRecord("example.com", "www.example.com", "A")
+	assert.NoError(t, err)
+}
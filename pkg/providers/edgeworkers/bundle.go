@@ -0,0 +1,111 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	manifestFile = "bundle.json"
+	mainFile     = "main.js"
+)
+
+// bundleManifest is the bundle.json of an EdgeWorker code bundle
+type bundleManifest struct {
+	EdgeWorkerVersion string `json:"edgeworker-version"`
+	BundleVersion     int    `json:"bundle-version,omitempty"`
+	APIVersion        string `json:"api-version,omitempty"`
+	Description       string `json:"description,omitempty"`
+}
+
+// readManifest validates the code bundle in dir and returns its bundle.json
+func readManifest(dir string) (*bundleManifest, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, mainFile)); err != nil {
+		return nil, fmt.Errorf("code bundle %s has no %s", dir, mainFile)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, manifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("code bundle %s has no %s", dir, manifestFile)
+	}
+
+	var m bundleManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", filepath.Join(dir, manifestFile), err)
+	}
+	if m.EdgeWorkerVersion == "" {
+		return nil, fmt.Errorf("%s must set \"edgeworker-version\"", filepath.Join(dir, manifestFile))
+	}
+	return &m, nil
+}
+
+// buildBundle packs the regular files of dir into a tgz code bundle. Timestamps and
+// owners are left out so the same files always produce the same bundle and hash.
+func buildBundle(dir string) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		hdr := &tar.Header{
+			Name:     filepath.ToSlash(name),
+			Mode:     0644,
+			Size:     int64(len(data)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bundleHash returns the SHA-256 of a code bundle
+func bundleHash(bundle []byte) string {
+	sum := sha256.Sum256(bundle)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,139 @@
+package edgeworkers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBundle creates a code bundle directory holding files
+func writeBundle(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "edgeworker")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+// bundleFiles lists the files of a tgz code bundle
+func bundleFiles(t *testing.T, bundle []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(bundle))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(data)
+	}
+}
+
+// extractBundle unpacks a tgz code bundle into dir
+func extractBundle(bundle []byte, dir string) error {
+	gz, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir, filepath.FromSlash(hdr.Name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, data, 0644); err != nil {
+			return err
+		}
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	dir := writeBundle(t, map[string]string{
+		"main.js":     "export function onClientRequest(request) {}",
+		"bundle.json": `{"edgeworker-version": "1.0", "description": "hello"}`,
+	})
+	m, err := readManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", m.EdgeWorkerVersion)
+
+	tests := map[string]map[string]string{
+		"missing main.js":     {"bundle.json": `{"edgeworker-version": "1.0"}`},
+		"missing bundle.json": {"main.js": ""},
+		"invalid JSON":        {"main.js": "", "bundle.json": `{"edgeworker-version": `},
+		"missing version":     {"main.js": "", "bundle.json": `{"description": "hello"}`},
+	}
+	for name, files := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := readManifest(writeBundle(t, files))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = readManifest(filepath.Join(dir, "main.js"))
+	assert.Error(t, err, "bundle must be a directory")
+}
+
+func TestBuildBundle(t *testing.T) {
+	files := map[string]string{
+		"main.js":        "import { greet } from 'lib/greet.js';",
+		"bundle.json":    `{"edgeworker-version": "1.0"}`,
+		"lib/greet.js":   "export function greet() {}",
+		"lib/empty.json": "",
+	}
+	dir := writeBundle(t, files)
+
+	bundle, err := buildBundle(dir)
+	require.NoError(t, err)
+	assert.Equal(t, files, bundleFiles(t, bundle))
+
+	// the same files give the same hash regardless of modification times
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "main.js"), later, later))
+	again, err := buildBundle(dir)
+	require.NoError(t, err)
+	assert.Equal(t, bundleHash(bundle), bundleHash(again))
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.js"), []byte("// changed"), 0644))
+	changed, err := buildBundle(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, bundleHash(bundle), bundleHash(changed))
+
+	names := make([]string, 0)
+	for name := range bundleFiles(t, changed) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"bundle.json", "lib/empty.json", "lib/greet.js", "main.js"}, names)
+}
@@ -0,0 +1,136 @@
+package edgeworkers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	// activation and deactivation statuses
+	statusComplete = "COMPLETE"
+	statusAborted  = "ABORTED"
+)
+
+var (
+	// api sends the requests to the EdgeWorkers API
+	api apiclient.Client
+
+	// activationPollInterval and activationPollTimeout bound waitForActivation
+	activationPollInterval = 30 * time.Second
+	activationPollTimeout  = 60 * time.Minute
+)
+
+type (
+	edgeWorkerID struct {
+		EdgeWorkerID   int    `json:"edgeWorkerId,omitempty"`
+		Name           string `json:"name"`
+		GroupID        int    `json:"groupId"`
+		ResourceTierID int    `json:"resourceTierId,omitempty"`
+	}
+
+	version struct {
+		EdgeWorkerID int    `json:"edgeWorkerId"`
+		Version      string `json:"version"`
+		Checksum     string `json:"checksum"`
+		CreatedTime  string `json:"createdTime"`
+	}
+
+	validationMessage struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}
+
+	bundleValidation struct {
+		Errors   []validationMessage `json:"errors"`
+		Warnings []validationMessage `json:"warnings"`
+	}
+
+	activationRequest struct {
+		Network string `json:"network"`
+		Version string `json:"version"`
+	}
+
+	// activation holds both activations and deactivations
+	activation struct {
+		EdgeWorkerID   int    `json:"edgeWorkerId"`
+		ActivationID   int    `json:"activationId,omitempty"`
+		DeactivationID int    `json:"deactivationId,omitempty"`
+		Network        string `json:"network"`
+		Version        string `json:"version"`
+		Status         string `json:"status"`
+		CreatedTime    string `json:"createdTime"`
+	}
+
+	activationsResponse struct {
+		Activations []activation `json:"activations"`
+	}
+)
+
+// doBundleRequest sends a tgz code bundle
+func doBundleRequest(path string, bundle []byte, out interface{}) error {
+	req, err := client.NewRequest(api.Config, http.MethodPost, path, bytes.NewReader(bundle))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/gzip")
+	req.Header.Set("Accept", "application/json")
+	return api.Do(req, out)
+}
+
+func idLocation(id int) string {
+	return fmt.Sprintf("/edgeworkers/v1/ids/%d", id)
+}
+
+// validateBundle has the API check a code bundle before it is uploaded
+func validateBundle(bundle []byte) (*bundleValidation, error) {
+	var v bundleValidation
+	if err := doBundleRequest("/edgeworkers/v1/validations", bundle, &v); err != nil {
+		return nil, err
+	}
+	if len(v.Errors) > 0 {
+		msg := v.Errors[0].Message
+		for _, e := range v.Errors[1:] {
+			msg += "; " + e.Message
+		}
+		return &v, fmt.Errorf("invalid code bundle: %s", msg)
+	}
+	return &v, nil
+}
+
+// waitForActivation polls an activation, or a deactivation when deactivation is set,
+// until it completes or is aborted
+func waitForActivation(log hclog.Logger, edgeWorker, id int, deactivation bool) (*activation, error) {
+	path := fmt.Sprintf("%s/activations/%d", idLocation(edgeWorker), id)
+	if deactivation {
+		path = fmt.Sprintf("%s/deactivations/%d", idLocation(edgeWorker), id)
+	}
+
+	var a *activation
+	err := apiclient.WaitForActivation(activationPollInterval, activationPollTimeout, func() (bool, error) {
+		a = &activation{}
+		if err := api.DoRequest(http.MethodGet, path, nil, a); err != nil {
+			a = nil
+			return false, err
+		}
+		log.Debug(fmt.Sprintf("edgeworker %d %s status [%s]", edgeWorker, path, a.Status))
+
+		switch a.Status {
+		case statusComplete:
+			return true, nil
+		case statusAborted:
+			return true, fmt.Errorf("%w: edgeworker %d version %s on %s", apiclient.ErrActivationFailed, edgeWorker, a.Version, a.Network)
+		}
+		return false, nil
+	})
+	if errors.Is(err, apiclient.ErrActivationTimeout) {
+		return a, fmt.Errorf("%w: edgeworker %d, last status %q", err, edgeWorker, a.Status)
+	}
+	return a, err
+}
@@ -0,0 +1,57 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceEdgeKVGroupItem() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceEdgeKVGroupItemRead,
+		Schema: map[string]*schema.Schema{
+			"namespace_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{edgeKVStaging, edgeKVProduction}, true),
+			},
+			"group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"item_key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceEdgeKVGroupItemRead(d *schema.ResourceData, meta interface{}) error {
+	ns := d.Get("namespace_name").(string)
+	network := strings.ToLower(d.Get("network").(string))
+	group := d.Get("group_name").(string)
+	key := d.Get("item_key").(string)
+
+	value, err := getItem(network, ns, group, key)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			return fmt.Errorf("item %s not found in group %s of namespace %s on %s", key, group, ns, network)
+		}
+		return apiclient.Error(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s:%s", ns, network, group, key))
+	return d.Set("value", value)
+}
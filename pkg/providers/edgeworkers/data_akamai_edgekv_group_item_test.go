@@ -0,0 +1,33 @@
+package edgeworkers
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceEdgeKVGroupItem(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeKV(srv)
+	api.Init(srv.Config())
+
+	fake.items["production:marketing:banners"] = map[string]string{"en": `{"text": "Hello"}`}
+
+	d := schema.TestResourceDataRaw(t, dataSourceEdgeKVGroupItem().Schema, map[string]interface{}{
+		"namespace_name": "marketing",
+		"network":        "PRODUCTION",
+		"group_name":     "banners",
+		"item_key":       "en",
+	})
+	require.NoError(t, dataSourceEdgeKVGroupItemRead(d, nil))
+	assert.Equal(t, "marketing:production:banners:en", d.Id())
+	assert.Equal(t, `{"text": "Hello"}`, d.Get("value"))
+
+	d.Set("item_key", "fr")
+	assert.Error(t, dataSourceEdgeKVGroupItemRead(d, nil))
+}
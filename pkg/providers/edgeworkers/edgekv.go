@@ -0,0 +1,137 @@
+package edgeworkers
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/client-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+// EdgeKV is managed through the EdgeKV v1 API with the EdgeWorkers credentials.
+//
+// https://developer.akamai.com/api/web_performance/edgekv/v1.html
+
+const (
+	edgeKVStaging    = "staging"
+	edgeKVProduction = "production"
+
+	// account statuses of the EdgeKV database
+	edgeKVInitialized   = "INITIALIZED"
+	edgeKVUninitialized = "UNINITIALIZED"
+)
+
+var (
+	// initializePollInterval and initializePollTimeout bound initializeEdgeKV
+	initializePollInterval = 30 * time.Second
+	initializePollTimeout  = 30 * time.Minute
+)
+
+type (
+	edgeKVStatus struct {
+		AccountStatus    string `json:"accountStatus"`
+		CPCode           string `json:"cpcode"`
+		ProductionStatus string `json:"productionStatus"`
+		StagingStatus    string `json:"stagingStatus"`
+	}
+
+	namespace struct {
+		Name        string `json:"namespace"`
+		Retention   int    `json:"retentionInSeconds"`
+		GeoLocation string `json:"geoLocation,omitempty"`
+	}
+)
+
+func namespaceLocation(network, name string) string {
+	return fmt.Sprintf("/edgekv/v1/networks/%s/namespaces/%s", network, url.PathEscape(name))
+}
+
+func itemLocation(network, ns, group, key string) string {
+	return fmt.Sprintf("%s/groups/%s/items/%s", namespaceLocation(network, ns), url.PathEscape(group), url.PathEscape(key))
+}
+
+// initializeEdgeKV initializes the EdgeKV database of the account unless it already
+// is, and waits for it to be ready
+func initializeEdgeKV(log hclog.Logger) error {
+	var status edgeKVStatus
+	if err := api.DoRequest(http.MethodGet, "/edgekv/v1/initialize", nil, &status); err != nil && !apiclient.IsNotFound(err) {
+		return err
+	}
+	if status.AccountStatus == edgeKVInitialized {
+		return nil
+	}
+
+	if status.AccountStatus == "" || status.AccountStatus == edgeKVUninitialized {
+		log.Debug("initializing the EdgeKV database")
+		if err := api.DoRequest(http.MethodPut, "/edgekv/v1/initialize", nil, &status); err != nil {
+			return err
+		}
+	}
+	if status.AccountStatus == edgeKVInitialized {
+		return nil
+	}
+
+	err := apiclient.Poll(initializePollInterval, initializePollTimeout, func() (bool, error) {
+		if err := api.DoRequest(http.MethodGet, "/edgekv/v1/initialize", nil, &status); err != nil {
+			return false, err
+		}
+		log.Debug(fmt.Sprintf("EdgeKV database status [%s]", status.AccountStatus))
+		return status.AccountStatus == edgeKVInitialized, nil
+	})
+	if errors.Is(err, apiclient.ErrPollTimeout) {
+		return fmt.Errorf("%w: EdgeKV initialization, last status %q", err, status.AccountStatus)
+	}
+	return err
+}
+
+// getItem returns the value of an EdgeKV item
+func getItem(network, ns, group, key string) (string, error) {
+	req, err := client.NewRequest(api.Config, http.MethodGet, itemLocation(network, ns, group, key), nil)
+	if err != nil {
+		return "", err
+	}
+	res, err := client.Do(api.Config, req)
+	if err != nil {
+		return "", err
+	}
+	if client.IsError(res) {
+		return "", client.NewAPIError(res)
+	}
+
+	defer res.Body.Close()
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// putItem creates or replaces an EdgeKV item, values are stored as text
+func putItem(network, ns, group, key, value string) error {
+	req, err := client.NewRequest(api.Config, http.MethodPut, itemLocation(network, ns, group, key), strings.NewReader(value))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	return api.Do(req, nil)
+}
+
+func deleteItem(network, ns, group, key string) error {
+	return api.DoRequest(http.MethodDelete, itemLocation(network, ns, group, key), nil, nil)
+}
+
+// listItems returns the keys of the items of a group
+func listItems(network, ns, group string) ([]string, error) {
+	var keys []string
+	path := fmt.Sprintf("%s/groups/%s", namespaceLocation(network, ns), url.PathEscape(group))
+	if err := api.DoRequest(http.MethodGet, path, nil, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
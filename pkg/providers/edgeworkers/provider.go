@@ -0,0 +1,145 @@
+package edgeworkers
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"edgeworkers_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("edgeworkers_section"),
+			},
+			"edgeworkers": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("edgeworkers"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_edgekv_group_item": dataSourceEdgeKVGroupItem(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_edgekv":                 resourceEdgeKV(),
+			"akamai_edgekv_group_items":     resourceEdgeKVGroupItems(),
+			"akamai_edgeworker":             resourceEdgeWorker(),
+			"akamai_edgeworkers_activation": resourceEdgeWorkersActivation(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigEdgeWorkersService(d resourceData) (*edgegrid.Config, error) {
+	var EdgeWorkersConfig edgegrid.Config
+	if _, ok := d.GetOk("edgeworkers"); ok {
+		log.Printf("[DEBUG] Setting edgeworkers config via HCL")
+		config := d.Get("edgeworkers").(set).List()[0].(map[string]interface{})
+
+		EdgeWorkersConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(EdgeWorkersConfig)
+		return &EdgeWorkersConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("edgeworkers_section"); ok && section != "default" {
+		EdgeWorkersConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		EdgeWorkersConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(EdgeWorkersConfig)
+	return &EdgeWorkersConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "edgeworkers"
+}
+
+// EdgeWorkersProviderVersion update version string anytime provider adds new features
+const EdgeWorkersProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return EdgeWorkersProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigEdgeWorkersService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,178 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// EdgeKV namespace
+//
+// https://developer.akamai.com/api/web_performance/edgekv/v1.html#namespace
+func resourceEdgeKV() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEdgeKVCreate,
+		Read:   resourceEdgeKVRead,
+		Update: resourceEdgeKVUpdate,
+		Delete: resourceEdgeKVDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceEdgeKVImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"namespace_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    lowerStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{edgeKVStaging, edgeKVProduction}, true),
+			},
+			"retention_in_seconds": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How long items are kept, 0 keeps them indefinitely",
+			},
+			"geo_location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "US",
+			},
+		},
+	}
+}
+
+func resourceEdgeKVCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVCreate")
+
+	if err := initializeEdgeKV(log); err != nil {
+		return apiclient.Error(err)
+	}
+
+	network := strings.ToLower(d.Get("network").(string))
+	req := namespace{
+		Name:        d.Get("namespace_name").(string),
+		Retention:   d.Get("retention_in_seconds").(int),
+		GeoLocation: d.Get("geo_location").(string),
+	}
+
+	log.Debug(fmt.Sprintf("creating namespace %q on %s", req.Name, network))
+	if err := api.DoRequest(http.MethodPost, fmt.Sprintf("/edgekv/v1/networks/%s/namespaces", network), req, nil); err != nil {
+		log.Error(fmt.Sprintf("namespace create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", req.Name, network))
+	return resourceEdgeKVRead(d, meta)
+}
+
+func resourceEdgeKVRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVRead")
+
+	name, network, err := parseNamespaceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	var ns namespace
+	if err := api.DoRequest(http.MethodGet, namespaceLocation(network, name), nil, &ns); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("namespace %s not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	attrs := map[string]interface{}{
+		"namespace_name":       ns.Name,
+		"network":              network,
+		"retention_in_seconds": ns.Retention,
+		"geo_location":         ns.GeoLocation,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceEdgeKVUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVUpdate")
+
+	name, network, err := parseNamespaceID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	req := namespace{
+		Name:        name,
+		Retention:   d.Get("retention_in_seconds").(int),
+		GeoLocation: d.Get("geo_location").(string),
+	}
+
+	log.Debug(fmt.Sprintf("updating namespace %s", d.Id()))
+	if err := api.DoRequest(http.MethodPut, namespaceLocation(network, name), req, nil); err != nil {
+		log.Error(fmt.Sprintf("namespace update failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return resourceEdgeKVRead(d, meta)
+}
+
+// EdgeKV namespaces can't be deleted, destroying the resource only removes it from the state
+func resourceEdgeKVDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVDelete")
+
+	log.Warn(fmt.Sprintf("namespace %s can't be deleted, removing it from state only", d.Id()))
+	d.SetId("")
+	return nil
+}
+
+// Import by "<namespace_name>:<network>"
+func resourceEdgeKVImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	name, network, err := parseNamespaceID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%s:%s", name, network))
+
+	if err := resourceEdgeKVRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("namespace %s not found on %s", name, network)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseNamespaceID splits a "<namespace_name>:<network>" resource ID
+func parseNamespaceID(id string) (string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", fmt.Errorf("invalid ID %q, expected <namespace_name>:<network>", id)
+	}
+	network := strings.ToLower(parts[1])
+	if network != edgeKVStaging && network != edgeKVProduction {
+		return "", "", fmt.Errorf("invalid ID %q, network must be %s or %s", id, edgeKVStaging, edgeKVProduction)
+	}
+	return parts[0], network, nil
+}
+
+func lowerStateFunc(v interface{}) string {
+	return strings.ToLower(v.(string))
+}
@@ -0,0 +1,215 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// EdgeKV items of a group
+//
+// https://developer.akamai.com/api/web_performance/edgekv/v1.html#item
+func resourceEdgeKVGroupItems() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEdgeKVGroupItemsCreate,
+		Read:   resourceEdgeKVGroupItemsRead,
+		Update: resourceEdgeKVGroupItemsUpdate,
+		Delete: resourceEdgeKVGroupItemsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceEdgeKVGroupItemsImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"namespace_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    lowerStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{edgeKVStaging, edgeKVProduction}, true),
+			},
+			"group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"items": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The items of the group by key, items of the group missing from the map are deleted",
+			},
+		},
+	}
+}
+
+func resourceEdgeKVGroupItemsCreate(d *schema.ResourceData, meta interface{}) error {
+	network := strings.ToLower(d.Get("network").(string))
+	d.SetId(fmt.Sprintf("%s:%s:%s", d.Get("namespace_name").(string), network, d.Get("group_name").(string)))
+
+	return resourceEdgeKVGroupItemsUpdate(d, meta)
+}
+
+func resourceEdgeKVGroupItemsRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVGroupItemsRead")
+
+	ns, network, group, err := parseGroupItemsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	keys, err := listItems(network, ns, group)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("group %s not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	items := make(map[string]interface{}, len(keys))
+	for _, key := range keys {
+		value, err := getItem(network, ns, group, key)
+		if err != nil {
+			if apiclient.IsNotFound(err) {
+				continue
+			}
+			return apiclient.Error(err)
+		}
+		items[key] = value
+	}
+
+	attrs := map[string]interface{}{
+		"namespace_name": ns,
+		"network":        network,
+		"group_name":     group,
+		"items":          items,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceEdgeKVGroupItemsUpdate writes the configured items and deletes the other
+// items of the group
+func resourceEdgeKVGroupItemsUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVGroupItemsUpdate")
+
+	ns, network, group, err := parseGroupItemsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]string)
+	keys, err := listItems(network, ns, group)
+	if err != nil && !apiclient.IsNotFound(err) {
+		return apiclient.Error(err)
+	}
+	for _, key := range keys {
+		value, err := getItem(network, ns, group, key)
+		if err != nil && !apiclient.IsNotFound(err) {
+			return apiclient.Error(err)
+		}
+		current[key] = value
+	}
+
+	items := d.Get("items").(map[string]interface{})
+	for _, key := range sortedKeys(items) {
+		value := items[key].(string)
+		if v, ok := current[key]; ok && v == value {
+			continue
+		}
+		log.Debug(fmt.Sprintf("writing item %s of group %s", key, d.Id()))
+		if err := putItem(network, ns, group, key, value); err != nil {
+			log.Error(fmt.Sprintf("item %s write failed: %s", key, err))
+			return apiclient.Error(err)
+		}
+	}
+
+	for key := range current {
+		if _, ok := items[key]; ok {
+			continue
+		}
+		log.Debug(fmt.Sprintf("deleting item %s of group %s", key, d.Id()))
+		if err := deleteItem(network, ns, group, key); err != nil && !apiclient.IsNotFound(err) {
+			log.Error(fmt.Sprintf("item %s delete failed: %s", key, err))
+			return apiclient.Error(err)
+		}
+	}
+
+	return resourceEdgeKVGroupItemsRead(d, meta)
+}
+
+func resourceEdgeKVGroupItemsDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEKV", "resourceEdgeKVGroupItemsDelete")
+
+	ns, network, group, err := parseGroupItemsID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	for _, key := range sortedKeys(d.Get("items").(map[string]interface{})) {
+		log.Debug(fmt.Sprintf("deleting item %s of group %s", key, d.Id()))
+		if err := deleteItem(network, ns, group, key); err != nil && !apiclient.IsNotFound(err) {
+			log.Error(fmt.Sprintf("item %s delete failed: %s", key, err))
+			return apiclient.Error(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<namespace_name>:<network>:<group_name>"
+func resourceEdgeKVGroupItemsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	ns, network, group, err := parseGroupItemsID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%s:%s:%s", ns, network, group))
+
+	if err := resourceEdgeKVGroupItemsRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("group %s not found in namespace %s on %s", group, ns, network)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseGroupItemsID splits a "<namespace_name>:<network>:<group_name>" resource ID
+func parseGroupItemsID(id string) (string, string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 || parts[2] == "" {
+		return "", "", "", fmt.Errorf("invalid ID %q, expected <namespace_name>:<network>:<group_name>", id)
+	}
+	ns, network, err := parseNamespaceID(parts[0] + ":" + parts[1])
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid ID %q, expected <namespace_name>:<network>:<group_name>", id)
+	}
+	return ns, network, parts[2], nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
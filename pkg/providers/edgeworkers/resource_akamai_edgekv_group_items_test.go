@@ -0,0 +1,56 @@
+package edgeworkers
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceEdgeKVGroupItems(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeKV(srv)
+	api.Init(srv.Config())
+
+	fake.status = edgeKVInitialized
+	fake.namespaces["staging:marketing"] = &namespace{Name: "marketing", GeoLocation: "US"}
+	fake.items["staging:marketing:banners"] = map[string]string{"stale": "remove me"}
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeKVGroupItems().Schema, map[string]interface{}{
+		"namespace_name": "marketing",
+		"network":        "staging",
+		"group_name":     "banners",
+		"items": map[string]interface{}{
+			"en": `{"text": "Hello"}`,
+			"fr": "Bonjour",
+		},
+	})
+	require.NoError(t, resourceEdgeKVGroupItemsCreate(d, nil))
+	assert.Equal(t, "marketing:staging:banners", d.Id())
+	assert.Equal(t, map[string]string{"en": `{"text": "Hello"}`, "fr": "Bonjour"}, fake.items["staging:marketing:banners"])
+
+	// items changed outside of terraform are reported as drift
+	fake.items["staging:marketing:banners"]["fr"] = "Salut"
+	require.NoError(t, resourceEdgeKVGroupItemsRead(d, nil))
+	assert.Equal(t, "Salut", d.Get("items").(map[string]interface{})["fr"])
+
+	d.Set("items", map[string]interface{}{"fr": "Bonjour", "de": "Hallo"})
+	require.NoError(t, resourceEdgeKVGroupItemsUpdate(d, nil))
+	assert.Equal(t, map[string]string{"fr": "Bonjour", "de": "Hallo"}, fake.items["staging:marketing:banners"])
+
+	require.NoError(t, resourceEdgeKVGroupItemsDelete(d, nil))
+	assert.Empty(t, fake.items["staging:marketing:banners"])
+
+	// writing to a missing namespace fails
+	missing := schema.TestResourceDataRaw(t, resourceEdgeKVGroupItems().Schema, map[string]interface{}{
+		"namespace_name": "missing",
+		"network":        "staging",
+		"group_name":     "banners",
+		"items":          map[string]interface{}{"en": "Hello"},
+	})
+	assert.Error(t, resourceEdgeKVGroupItemsCreate(missing, nil))
+}
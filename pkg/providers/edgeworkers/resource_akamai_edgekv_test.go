@@ -0,0 +1,233 @@
+package edgeworkers
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeEdgeKV serves the EdgeKV endpoints used by the provider from the mock server
+type fakeEdgeKV struct {
+	lock        sync.Mutex
+	status      string
+	initialized int
+	namespaces  map[string]*namespace
+	// items by "<network>:<namespace>:<group>" and key
+	items map[string]map[string]string
+}
+
+func newFakeEdgeKV(srv *mockapi.Server) *fakeEdgeKV {
+	f := &fakeEdgeKV{
+		status:     edgeKVUninitialized,
+		namespaces: make(map[string]*namespace),
+		items:      make(map[string]map[string]string),
+	}
+
+	srv.Handle("GET", `/edgekv/v1/initialize`, f.getStatus)
+	srv.Handle("PUT", `/edgekv/v1/initialize`, f.initialize)
+	srv.Handle("POST", `/edgekv/v1/networks/(staging|production)/namespaces`, f.createNamespace)
+	srv.Handle("GET", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)`, f.getNamespace)
+	srv.Handle("PUT", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)`, f.updateNamespace)
+	srv.Handle("GET", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)/groups/([^/]+)`, f.listItems)
+	srv.Handle("GET", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)/groups/([^/]+)/items/([^/]+)`, f.getItem)
+	srv.Handle("PUT", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)/groups/([^/]+)/items/([^/]+)`, f.putItem)
+	srv.Handle("DELETE", `/edgekv/v1/networks/(staging|production)/namespaces/([^/]+)/groups/([^/]+)/items/([^/]+)`, f.deleteItem)
+
+	return f
+}
+
+// getStatus reports a pending initialization once before the database is ready
+func (f *fakeEdgeKV) getStatus(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	status := f.status
+	if f.status == "PENDING" {
+		f.status = edgeKVInitialized
+	}
+	mockapi.WriteJSON(w, http.StatusOK, edgeKVStatus{AccountStatus: status})
+}
+
+func (f *fakeEdgeKV) initialize(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.initialized++
+	f.status = "PENDING"
+	mockapi.WriteJSON(w, http.StatusCreated, edgeKVStatus{AccountStatus: f.status})
+}
+
+func (f *fakeEdgeKV) createNamespace(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var ns namespace
+	if !mockapi.ReadJSON(w, r, &ns) {
+		return
+	}
+	if ns.Name == "" || f.status != edgeKVInitialized {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if _, ok := f.namespaces[params[0]+":"+ns.Name]; ok {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	f.namespaces[params[0]+":"+ns.Name] = &ns
+	mockapi.WriteJSON(w, http.StatusCreated, ns)
+}
+
+func (f *fakeEdgeKV) getNamespace(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ns, ok := f.namespaces[params[0]+":"+params[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, ns)
+}
+
+func (f *fakeEdgeKV) updateNamespace(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	current, ok := f.namespaces[params[0]+":"+params[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var ns namespace
+	if !mockapi.ReadJSON(w, r, &ns) {
+		return
+	}
+	if ns.GeoLocation != current.GeoLocation {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	current.Retention = ns.Retention
+	mockapi.WriteJSON(w, http.StatusOK, current)
+}
+
+func (f *fakeEdgeKV) group(params []string) string {
+	return params[0] + ":" + params[1] + ":" + params[2]
+}
+
+func (f *fakeEdgeKV) listItems(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	items, ok := f.items[f.group(params)]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	mockapi.WriteJSON(w, http.StatusOK, keys)
+}
+
+func (f *fakeEdgeKV) getItem(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	value, ok := f.items[f.group(params)][params[3]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(value))
+}
+
+func (f *fakeEdgeKV) putItem(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.namespaces[params[0]+":"+params[1]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	data, _ := ioutil.ReadAll(r.Body)
+	if f.items[f.group(params)] == nil {
+		f.items[f.group(params)] = make(map[string]string)
+	}
+	f.items[f.group(params)][params[3]] = string(data)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeEdgeKV) deleteItem(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.items[f.group(params)][params[3]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.items[f.group(params)], params[3])
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestResourceEdgeKV(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeKV(srv)
+	api.Init(srv.Config())
+	initializePollInterval = 0
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeKV().Schema, map[string]interface{}{
+		"namespace_name":       "marketing",
+		"network":              "STAGING",
+		"retention_in_seconds": 86400,
+	})
+	require.NoError(t, resourceEdgeKVCreate(d, nil))
+	assert.Equal(t, "marketing:staging", d.Id())
+	assert.Equal(t, 1, fake.initialized)
+	assert.Equal(t, edgeKVInitialized, fake.status)
+	assert.Equal(t, "US", fake.namespaces["staging:marketing"].GeoLocation)
+
+	// the database is only initialized once
+	other := schema.TestResourceDataRaw(t, resourceEdgeKV().Schema, map[string]interface{}{
+		"namespace_name":       "marketing",
+		"network":              "production",
+		"retention_in_seconds": 0,
+		"geo_location":         "EU",
+	})
+	require.NoError(t, resourceEdgeKVCreate(other, nil))
+	assert.Equal(t, 1, fake.initialized)
+	assert.Len(t, fake.namespaces, 2)
+
+	d.Set("retention_in_seconds", 3600)
+	require.NoError(t, resourceEdgeKVUpdate(d, nil))
+	assert.Equal(t, 3600, fake.namespaces["staging:marketing"].Retention)
+
+	require.NoError(t, resourceEdgeKVDelete(d, nil))
+	assert.Equal(t, "", d.Id())
+	assert.Len(t, fake.namespaces, 2, "namespaces can't be deleted")
+
+	imported := schema.TestResourceDataRaw(t, resourceEdgeKV().Schema, map[string]interface{}{})
+	imported.SetId("marketing:PRODUCTION")
+	_, err := resourceEdgeKVImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "marketing:production", imported.Id())
+	assert.Equal(t, "EU", imported.Get("geo_location"))
+
+	for _, id := range []string{"marketing", "marketing:qa", "missing:staging"} {
+		invalid := schema.TestResourceDataRaw(t, resourceEdgeKV().Schema, map[string]interface{}{})
+		invalid.SetId(id)
+		_, err := resourceEdgeKVImport(invalid, nil)
+		assert.Error(t, err, id)
+	}
+}
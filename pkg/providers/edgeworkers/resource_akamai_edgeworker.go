@@ -0,0 +1,300 @@
+package edgeworkers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// EdgeWorker ID and code bundle
+//
+// https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#edgeworkerid
+func resourceEdgeWorker() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceEdgeWorkerCreate,
+		Read:          resourceEdgeWorkerRead,
+		Update:        resourceEdgeWorkerUpdate,
+		Delete:        resourceEdgeWorkerDelete,
+		CustomizeDiff: resourceEdgeWorkerCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: resourceEdgeWorkerImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				StateFunc: trimPrefixStateFunc("grp_"),
+			},
+			"resource_tier_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"local_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Directory holding the code bundle, including main.js and bundle.json",
+			},
+			"local_bundle_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 of the code bundle built from local_bundle",
+			},
+			"edgeworker_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edgeworker-version of the latest code bundle",
+			},
+			"warnings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceEdgeWorkerCustomizeDiff validates bundle.json at plan time and plans a new
+// version whenever the content of the local bundle changes
+func resourceEdgeWorkerCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	dir := d.Get("local_bundle").(string)
+	if !d.NewValueKnown("local_bundle") || dir == "" {
+		return nil
+	}
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	bundle, err := buildBundle(dir)
+	if err != nil {
+		return err
+	}
+
+	hash := bundleHash(bundle)
+	if hash == d.Get("local_bundle_hash").(string) && manifest.EdgeWorkerVersion == d.Get("version").(string) {
+		return nil
+	}
+	// versions are immutable, a changed bundle can only be uploaded as a new one
+	if d.Id() != "" && d.Get("local_bundle_hash").(string) != "" && manifest.EdgeWorkerVersion == d.Get("version").(string) {
+		return fmt.Errorf("the code bundle in %s changed, but version %s of EdgeWorker %s was already uploaded: change edgeworker-version in %s", dir, manifest.EdgeWorkerVersion, d.Id(), manifestFile)
+	}
+	if err := d.SetNew("local_bundle_hash", hash); err != nil {
+		return err
+	}
+	if err := d.SetNew("version", manifest.EdgeWorkerVersion); err != nil {
+		return err
+	}
+	return d.SetNewComputed("warnings")
+}
+
+func resourceEdgeWorkerCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkerCreate")
+
+	group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+	if err != nil {
+		return fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+	}
+
+	req := edgeWorkerID{
+		Name:           d.Get("name").(string),
+		GroupID:        group,
+		ResourceTierID: d.Get("resource_tier_id").(int),
+	}
+
+	log.Debug(fmt.Sprintf("creating edgeworker %q", req.Name))
+	var res edgeWorkerID
+	if err := api.DoRequest(http.MethodPost, "/edgeworkers/v1/ids", req, &res); err != nil {
+		log.Error(fmt.Sprintf("edgeworker create failed: %s", err))
+		return apiclient.Error(err)
+	}
+	d.SetId(strconv.Itoa(res.EdgeWorkerID))
+
+	if err := uploadBundle(log, d, res.EdgeWorkerID); err != nil {
+		return err
+	}
+	return resourceEdgeWorkerRead(d, meta)
+}
+
+func resourceEdgeWorkerRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkerRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid edgeworker ID %q", d.Id())
+	}
+
+	var ew edgeWorkerID
+	if err := api.DoRequest(http.MethodGet, idLocation(id), nil, &ew); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("edgeworker %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	var versions struct {
+		Versions []version `json:"versions"`
+	}
+	if err := api.DoRequest(http.MethodGet, idLocation(id)+"/versions", nil, &versions); err != nil {
+		return apiclient.Error(err)
+	}
+
+	attrs := map[string]interface{}{
+		"name":             ew.Name,
+		"group_id":         strconv.Itoa(ew.GroupID),
+		"resource_tier_id": ew.ResourceTierID,
+		"edgeworker_id":    ew.EdgeWorkerID,
+	}
+
+	// versions uploaded outside of terraform show up as a change of version, which
+	// uploads the local bundle again
+	var latest *version
+	for i, v := range versions.Versions {
+		if latest == nil || v.CreatedTime >= latest.CreatedTime {
+			latest = &versions.Versions[i]
+		}
+	}
+	if latest != nil {
+		attrs["version"] = latest.Version
+	}
+
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceEdgeWorkerUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkerUpdate")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid edgeworker ID %q", d.Id())
+	}
+
+	if d.HasChanges("name", "group_id") {
+		group, err := strconv.Atoi(strings.TrimPrefix(d.Get("group_id").(string), "grp_"))
+		if err != nil {
+			return fmt.Errorf("invalid group_id %q", d.Get("group_id"))
+		}
+		req := edgeWorkerID{
+			Name:           d.Get("name").(string),
+			GroupID:        group,
+			ResourceTierID: d.Get("resource_tier_id").(int),
+		}
+		log.Debug(fmt.Sprintf("updating edgeworker %d", id))
+		if err := api.DoRequest(http.MethodPut, idLocation(id), req, nil); err != nil {
+			log.Error(fmt.Sprintf("edgeworker update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	if err := uploadBundle(log, d, id); err != nil {
+		return err
+	}
+
+	return resourceEdgeWorkerRead(d, meta)
+}
+
+func resourceEdgeWorkerDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkerDelete")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid edgeworker ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting edgeworker %d", id))
+	if err := api.DoRequest(http.MethodDelete, idLocation(id), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("edgeworker delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceEdgeWorkerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceEdgeWorkerRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("edgeworker not found")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// uploadBundle validates the local code bundle and uploads it as a new version,
+// unless it is the bundle of the current version
+func uploadBundle(log hclog.Logger, d *schema.ResourceData, id int) error {
+	dir := d.Get("local_bundle").(string)
+	if dir == "" {
+		return nil
+	}
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+	bundle, err := buildBundle(dir)
+	if err != nil {
+		return err
+	}
+
+	hash, _ := d.GetChange("local_bundle_hash")
+	current, _ := d.GetChange("version")
+	if hash.(string) == bundleHash(bundle) && current.(string) == manifest.EdgeWorkerVersion {
+		log.Debug(fmt.Sprintf("edgeworker %d already runs the local bundle", id))
+		return nil
+	}
+
+	v, err := validateBundle(bundle)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+	warnings := make([]string, 0, len(v.Warnings))
+	for _, w := range v.Warnings {
+		warnings = append(warnings, w.Message)
+	}
+
+	log.Debug(fmt.Sprintf("uploading version %s of edgeworker %d", manifest.EdgeWorkerVersion, id))
+	if err := doBundleRequest(idLocation(id)+"/versions", bundle, nil); err != nil {
+		log.Error(fmt.Sprintf("version upload failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	if err := d.Set("local_bundle_hash", bundleHash(bundle)); err != nil {
+		return err
+	}
+	return d.Set("warnings", warnings)
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
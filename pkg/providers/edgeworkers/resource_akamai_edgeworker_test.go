@@ -0,0 +1,478 @@
+package edgeworkers
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiEdgeWorker_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAkamaiEdgeWorkerConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akamai_edgeworker.test", "edgeworker_id"),
+					resource.TestCheckResourceAttrSet("akamai_edgeworker.test", "local_bundle_hash"),
+					resource.TestCheckResourceAttr("akamai_edgeworkers_activation.test", "network", "STAGING"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAkamaiEdgeWorkerConfig = `
+provider "akamai" {
+  edgeworkers_section = "edgeworkers"
+}
+
+resource "akamai_edgeworker" "test" {
+  name = "terraform-test"
+  group_id = "grp_12345"
+  local_bundle = "testdata/hello-world"
+}
+
+resource "akamai_edgeworkers_activation" "test" {
+  edgeworker_id = akamai_edgeworker.test.edgeworker_id
+  network = "STAGING"
+  version = akamai_edgeworker.test.version
+}
+`
+
+// fakeEdgeWorkers serves the EdgeWorkers endpoints used by the provider from the mock server
+type fakeEdgeWorkers struct {
+	lock          sync.Mutex
+	ids           map[int]*edgeWorkerID
+	versions      map[int][]version
+	activations   map[int][]activation
+	deactivations map[int][]activation
+	nextID        int
+	warnings      []validationMessage
+}
+
+func newFakeEdgeWorkers(srv *mockapi.Server) *fakeEdgeWorkers {
+	f := &fakeEdgeWorkers{
+		ids:           make(map[int]*edgeWorkerID),
+		versions:      make(map[int][]version),
+		activations:   make(map[int][]activation),
+		deactivations: make(map[int][]activation),
+		nextID:        4000,
+	}
+
+	srv.Handle("POST", `/edgeworkers/v1/validations`, f.validate)
+	srv.Handle("POST", `/edgeworkers/v1/ids`, f.create)
+	srv.Handle("GET", `/edgeworkers/v1/ids/(\d+)`, f.get)
+	srv.Handle("PUT", `/edgeworkers/v1/ids/(\d+)`, f.update)
+	srv.Handle("DELETE", `/edgeworkers/v1/ids/(\d+)`, f.delete)
+	srv.Handle("GET", `/edgeworkers/v1/ids/(\d+)/versions`, f.listVersions)
+	srv.Handle("POST", `/edgeworkers/v1/ids/(\d+)/versions`, f.upload)
+	srv.Handle("GET", `/edgeworkers/v1/ids/(\d+)/activations`, f.listActivations)
+	srv.Handle("POST", `/edgeworkers/v1/ids/(\d+)/activations`, f.activate)
+	srv.Handle("GET", `/edgeworkers/v1/ids/(\d+)/activations/(\d+)`, f.getActivation)
+	srv.Handle("POST", `/edgeworkers/v1/ids/(\d+)/deactivations`, f.deactivate)
+	srv.Handle("GET", `/edgeworkers/v1/ids/(\d+)/deactivations/(\d+)`, f.getDeactivation)
+
+	return f
+}
+
+// manifest returns the bundle.json of an uploaded code bundle
+func (f *fakeEdgeWorkers) manifest(r *http.Request) (*bundleManifest, error) {
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir("", "upload")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	if err := extractBundle(data, dir); err != nil {
+		return nil, err
+	}
+	return readManifest(dir)
+}
+
+func (f *fakeEdgeWorkers) validate(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var v bundleValidation
+	if _, err := f.manifest(r); err != nil {
+		v.Errors = []validationMessage{{Type: "INVALID_BUNDLE", Message: err.Error()}}
+	}
+	v.Warnings = f.warnings
+	mockapi.WriteJSON(w, http.StatusOK, v)
+}
+
+func (f *fakeEdgeWorkers) id(w http.ResponseWriter, param string) (int, bool) {
+	id, _ := strconv.Atoi(param)
+	if _, ok := f.ids[id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return 0, false
+	}
+	return id, true
+}
+
+func (f *fakeEdgeWorkers) create(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var ew edgeWorkerID
+	if !mockapi.ReadJSON(w, r, &ew) {
+		return
+	}
+	if ew.Name == "" || ew.GroupID == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	ew.EdgeWorkerID = f.nextID
+	if ew.ResourceTierID == 0 {
+		ew.ResourceTierID = 100
+	}
+	f.ids[ew.EdgeWorkerID] = &ew
+	mockapi.WriteJSON(w, http.StatusCreated, ew)
+}
+
+func (f *fakeEdgeWorkers) get(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if id, ok := f.id(w, params[0]); ok {
+		mockapi.WriteJSON(w, http.StatusOK, f.ids[id])
+	}
+}
+
+func (f *fakeEdgeWorkers) update(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	var ew edgeWorkerID
+	if !mockapi.ReadJSON(w, r, &ew) {
+		return
+	}
+	f.ids[id].Name = ew.Name
+	f.ids[id].GroupID = ew.GroupID
+	mockapi.WriteJSON(w, http.StatusOK, f.ids[id])
+}
+
+func (f *fakeEdgeWorkers) delete(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if id, ok := f.id(w, params[0]); ok {
+		delete(f.ids, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeEdgeWorkers) listVersions(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if id, ok := f.id(w, params[0]); ok {
+		mockapi.WriteJSON(w, http.StatusOK, map[string]interface{}{"versions": f.versions[id]})
+	}
+}
+
+func (f *fakeEdgeWorkers) upload(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	if r.Header.Get("Content-Type") != "application/gzip" {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	m, err := f.manifest(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	for _, v := range f.versions[id] {
+		if v.Version == m.EdgeWorkerVersion {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+	}
+
+	v := version{
+		EdgeWorkerID: id,
+		Version:      m.EdgeWorkerVersion,
+		CreatedTime:  "2020-09-01T00:00:0" + strconv.Itoa(len(f.versions[id])) + "Z",
+	}
+	f.versions[id] = append(f.versions[id], v)
+	mockapi.WriteJSON(w, http.StatusCreated, v)
+}
+
+func (f *fakeEdgeWorkers) listActivations(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if id, ok := f.id(w, params[0]); ok {
+		mockapi.WriteJSON(w, http.StatusOK, activationsResponse{Activations: f.activations[id]})
+	}
+}
+
+func (f *fakeEdgeWorkers) activate(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	var req activationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if req.Network == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var known bool
+	for _, v := range f.versions[id] {
+		known = known || v.Version == req.Version
+	}
+	if !known {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	a := activation{
+		EdgeWorkerID: id,
+		ActivationID: len(f.activations[id]) + 1,
+		Network:      req.Network,
+		Version:      req.Version,
+		Status:       "PRESUBMIT",
+	}
+	f.activations[id] = append(f.activations[id], a)
+	mockapi.WriteJSON(w, http.StatusCreated, a)
+}
+
+// getActivation reports an activation pending once before it completes
+func (f *fakeEdgeWorkers) getActivation(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	n, _ := strconv.Atoi(params[1])
+	if n < 1 || n > len(f.activations[id]) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	a := &f.activations[id][n-1]
+	res := *a
+	a.Status = statusComplete
+	mockapi.WriteJSON(w, http.StatusOK, res)
+}
+
+func (f *fakeEdgeWorkers) deactivate(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	var req activationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+
+	// nothing shows as active on the network after a deactivation
+	for i, a := range f.activations[id] {
+		if a.Network == req.Network {
+			f.activations[id][i].Status = "DEACTIVATED"
+		}
+	}
+	a := activation{
+		EdgeWorkerID:   id,
+		DeactivationID: len(f.deactivations[id]) + 1,
+		Network:        req.Network,
+		Version:        req.Version,
+		Status:         statusComplete,
+	}
+	f.deactivations[id] = append(f.deactivations[id], a)
+	mockapi.WriteJSON(w, http.StatusCreated, a)
+}
+
+func (f *fakeEdgeWorkers) getDeactivation(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, ok := f.id(w, params[0])
+	if !ok {
+		return
+	}
+	n, _ := strconv.Atoi(params[1])
+	if n < 1 || n > len(f.deactivations[id]) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, f.deactivations[id][n-1])
+}
+
+func TestResourceEdgeWorker(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeWorkers(srv)
+	api.Init(srv.Config())
+
+	fake.warnings = []validationMessage{{Type: "ACCESS_TOKEN_EXPIRING_SOON", Message: "token expires soon"}}
+	dir := writeBundle(t, map[string]string{
+		"main.js":     "export function onClientRequest(request) {}",
+		"bundle.json": `{"edgeworker-version": "1.0"}`,
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeWorker().Schema, map[string]interface{}{
+		"name":         "hello-world",
+		"group_id":     "grp_12345",
+		"local_bundle": dir,
+	})
+	require.NoError(t, resourceEdgeWorkerCreate(d, nil))
+
+	assert.Equal(t, "4001", d.Id())
+	assert.Equal(t, 4001, d.Get("edgeworker_id"))
+	assert.Equal(t, "12345", d.Get("group_id"))
+	assert.Equal(t, 100, d.Get("resource_tier_id"))
+	assert.Equal(t, "1.0", d.Get("version"))
+	assert.Equal(t, []interface{}{"token expires soon"}, d.Get("warnings"))
+	assert.Len(t, d.Get("local_bundle_hash"), 64)
+	require.Len(t, fake.versions[4001], 1)
+
+	// a new version is uploaded when the bundle changes
+	hash := d.Get("local_bundle_hash").(string)
+	require.NoError(t, ioutil.WriteFile(dir+"/bundle.json", []byte(`{"edgeworker-version": "1.1"}`), 0644))
+	d.Set("name", "hello-world-renamed")
+	require.NoError(t, resourceEdgeWorkerUpdate(d, nil))
+	assert.Equal(t, "hello-world-renamed", fake.ids[4001].Name)
+	require.Len(t, fake.versions[4001], 2)
+	assert.Equal(t, "1.1", d.Get("version"))
+	assert.NotEqual(t, hash, d.Get("local_bundle_hash"))
+
+	// uploading an existing version surfaces the API error
+	assert.Error(t, resourceEdgeWorkerUpdate(d, nil))
+
+	require.NoError(t, resourceEdgeWorkerDelete(d, nil))
+	assert.Empty(t, fake.ids)
+
+	d.SetId("4001")
+	require.NoError(t, resourceEdgeWorkerRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceEdgeWorker_invalidBundle(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeWorkers(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeWorker().Schema, map[string]interface{}{
+		"name":         "broken",
+		"group_id":     "12345",
+		"local_bundle": writeBundle(t, map[string]string{"main.js": ""}),
+	})
+	assert.Error(t, resourceEdgeWorkerCreate(d, nil))
+	assert.Empty(t, fake.versions)
+}
+
+func TestResourceEdgeWorker_import(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeWorkers(srv)
+	api.Init(srv.Config())
+
+	fake.ids[42] = &edgeWorkerID{EdgeWorkerID: 42, Name: "existing", GroupID: 7, ResourceTierID: 200}
+	fake.versions[42] = []version{
+		{EdgeWorkerID: 42, Version: "0.2", CreatedTime: "2020-09-02T00:00:00Z"},
+		{EdgeWorkerID: 42, Version: "0.1", CreatedTime: "2020-09-01T00:00:00Z"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeWorker().Schema, map[string]interface{}{})
+	d.SetId("42")
+	out, err := resourceEdgeWorkerImport(d, nil)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "existing", d.Get("name"))
+	assert.Equal(t, "7", d.Get("group_id"))
+	assert.Equal(t, "0.2", d.Get("version"))
+
+	missing := schema.TestResourceDataRaw(t, resourceEdgeWorker().Schema, map[string]interface{}{})
+	missing.SetId("404")
+	_, err = resourceEdgeWorkerImport(missing, nil)
+	assert.Error(t, err)
+}
+
+func TestResourceEdgeWorker_customizeDiff(t *testing.T) {
+	dir := writeBundle(t, map[string]string{
+		"main.js":     "export function onClientRequest(request) {}",
+		"bundle.json": `{"edgeworker-version": "1.0"}`,
+	})
+	bundle, err := buildBundle(dir)
+	require.NoError(t, err)
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":         "hello-world",
+		"group_id":     "12345",
+		"local_bundle": dir,
+	})
+	state := &terraform.InstanceState{
+		ID: "42",
+		Attributes: map[string]string{
+			"id":                "42",
+			"name":              "hello-world",
+			"group_id":          "12345",
+			"local_bundle":      dir,
+			"local_bundle_hash": bundleHash(bundle),
+			"version":           "1.0",
+			"warnings.#":        "0",
+		},
+	}
+
+	diff, err := resourceEdgeWorker().Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+	assert.Nil(t, diff, "an unchanged bundle plans nothing")
+
+	require.NoError(t, ioutil.WriteFile(dir+"/main.js", []byte("// changed"), 0644))
+	_, err = resourceEdgeWorker().Diff(context.Background(), state, config, nil)
+	require.Error(t, err, "a changed bundle needs a new version")
+	assert.Contains(t, err.Error(), "version 1.0 of EdgeWorker 42 was already uploaded")
+
+	require.NoError(t, ioutil.WriteFile(dir+"/bundle.json", []byte(`{"edgeworker-version": "1.1"}`), 0644))
+	diff, err = resourceEdgeWorker().Diff(context.Background(), state, config, nil)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.NotEqual(t, bundleHash(bundle), diff.Attributes["local_bundle_hash"].New)
+	assert.Equal(t, "1.1", diff.Attributes["version"].New)
+
+	require.NoError(t, ioutil.WriteFile(dir+"/bundle.json", []byte(`{}`), 0644))
+	_, err = resourceEdgeWorker().Diff(context.Background(), state, config, nil)
+	assert.Error(t, err, "bundle.json is validated at plan time")
+}
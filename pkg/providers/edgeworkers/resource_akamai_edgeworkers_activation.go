@@ -0,0 +1,215 @@
+package edgeworkers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// EdgeWorker activation
+//
+// https://developer.akamai.com/api/web_performance/edgeworkers/v1.html#activation
+func resourceEdgeWorkersActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceEdgeWorkersActivationCreate,
+		Read:   resourceEdgeWorkersActivationRead,
+		Update: resourceEdgeWorkersActivationUpdate,
+		Delete: resourceEdgeWorkersActivationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceEdgeWorkersActivationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"edgeworker_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    upperStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{apiclient.NetworkStaging, apiclient.NetworkProduction}, true),
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"activation_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceEdgeWorkersActivationCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkersActivationCreate")
+
+	id := d.Get("edgeworker_id").(int)
+	req := activationRequest{
+		Network: strings.ToUpper(d.Get("network").(string)),
+		Version: d.Get("version").(string),
+	}
+
+	log.Debug(fmt.Sprintf("activating edgeworker %d version %s on %s", id, req.Version, req.Network))
+	var a activation
+	if err := api.DoRequest(http.MethodPost, idLocation(id)+"/activations", req, &a); err != nil {
+		log.Error(fmt.Sprintf("activation failed: %s", err))
+		return apiclient.Error(err)
+	}
+	d.SetId(fmt.Sprintf("%d:%s", id, req.Network))
+
+	if _, err := waitForActivation(log, id, a.ActivationID, false); err != nil {
+		return apiclient.Error(err)
+	}
+	return resourceEdgeWorkersActivationRead(d, meta)
+}
+
+func resourceEdgeWorkersActivationRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkersActivationRead")
+
+	id, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	a, err := activeVersion(id, network)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("edgeworker %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+	if a == nil {
+		log.Warn(fmt.Sprintf("edgeworker %d is not active on %s, removing from state", id, network))
+		d.SetId("")
+		return nil
+	}
+
+	attrs := map[string]interface{}{
+		"edgeworker_id": id,
+		"network":       network,
+		"version":       a.Version,
+		"activation_id": a.ActivationID,
+		"status":        a.Status,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceEdgeWorkersActivationUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("version") {
+		return resourceEdgeWorkersActivationCreate(d, meta)
+	}
+	return resourceEdgeWorkersActivationRead(d, meta)
+}
+
+func resourceEdgeWorkersActivationDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("EDGEWORKERS", "resourceEdgeWorkersActivationDelete")
+
+	id, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	req := activationRequest{
+		Network: network,
+		Version: d.Get("version").(string),
+	}
+
+	log.Debug(fmt.Sprintf("deactivating edgeworker %d version %s on %s", id, req.Version, network))
+	var a activation
+	if err := api.DoRequest(http.MethodPost, idLocation(id)+"/deactivations", req, &a); err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		log.Error(fmt.Sprintf("deactivation failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	if _, err := waitForActivation(log, id, a.DeactivationID, true); err != nil {
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<edgeworker_id>:<network>"
+func resourceEdgeWorkersActivationImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, network, err := parseActivationID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%d:%s", id, network))
+
+	if err := resourceEdgeWorkersActivationRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("edgeworker %d is not active on %s", id, network)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// activeVersion returns the latest completed activation of the edgeworker on the
+// network, or nil when it isn't active there
+func activeVersion(id int, network string) (*activation, error) {
+	var res activationsResponse
+	if err := api.DoRequest(http.MethodGet, idLocation(id)+"/activations", nil, &res); err != nil {
+		return nil, err
+	}
+
+	var active *activation
+	for i, a := range res.Activations {
+		if a.Network != network || a.Status != statusComplete {
+			continue
+		}
+		if active == nil || a.ActivationID > active.ActivationID {
+			active = &res.Activations[i]
+		}
+	}
+	return active, nil
+}
+
+// parseActivationID splits a "<edgeworker_id>:<network>" resource ID
+func parseActivationID(id string) (int, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("invalid ID %q, expected <edgeworker_id>:<network>", id)
+	}
+	edgeWorker, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid ID %q, expected <edgeworker_id>:<network>", id)
+	}
+	network := strings.ToUpper(parts[1])
+	if network != apiclient.NetworkStaging && network != apiclient.NetworkProduction {
+		return 0, "", fmt.Errorf("invalid ID %q, network must be %s or %s", id, apiclient.NetworkStaging, apiclient.NetworkProduction)
+	}
+	return edgeWorker, network, nil
+}
+
+func upperStateFunc(v interface{}) string {
+	return strings.ToUpper(v.(string))
+}
@@ -0,0 +1,83 @@
+package edgeworkers
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceEdgeWorkersActivation(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeWorkers(srv)
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	fake.ids[42] = &edgeWorkerID{EdgeWorkerID: 42, Name: "existing", GroupID: 7}
+	fake.versions[42] = []version{{EdgeWorkerID: 42, Version: "0.1"}, {EdgeWorkerID: 42, Version: "0.2"}}
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeWorkersActivation().Schema, map[string]interface{}{
+		"edgeworker_id": 42,
+		"network":       "staging",
+		"version":       "0.1",
+	})
+	require.NoError(t, resourceEdgeWorkersActivationCreate(d, nil))
+	assert.Equal(t, "42:STAGING", d.Id())
+	assert.Equal(t, 1, d.Get("activation_id"))
+	assert.Equal(t, statusComplete, d.Get("status"))
+
+	d.Set("version", "0.2")
+	require.NoError(t, resourceEdgeWorkersActivationUpdate(d, nil))
+	assert.Equal(t, 2, d.Get("activation_id"))
+	assert.Equal(t, "0.2", d.Get("version"))
+
+	// activating an unknown version fails
+	d.Set("version", "9.9")
+	assert.Error(t, resourceEdgeWorkersActivationUpdate(d, nil))
+
+	d.Set("version", "0.2")
+	require.NoError(t, resourceEdgeWorkersActivationDelete(d, nil))
+	require.Len(t, fake.deactivations[42], 1)
+	assert.Equal(t, "0.2", fake.deactivations[42][0].Version)
+
+	// nothing is active on the network any more
+	d.SetId("42:STAGING")
+	require.NoError(t, resourceEdgeWorkersActivationRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceEdgeWorkersActivation_import(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeEdgeWorkers(srv)
+	api.Init(srv.Config())
+
+	fake.ids[42] = &edgeWorkerID{EdgeWorkerID: 42, Name: "existing", GroupID: 7}
+	fake.activations[42] = []activation{
+		{EdgeWorkerID: 42, ActivationID: 1, Network: apiclient.NetworkProduction, Version: "0.1", Status: statusComplete},
+		{EdgeWorkerID: 42, ActivationID: 2, Network: apiclient.NetworkProduction, Version: "0.2", Status: statusComplete},
+		{EdgeWorkerID: 42, ActivationID: 3, Network: apiclient.NetworkStaging, Version: "0.3", Status: statusComplete},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceEdgeWorkersActivation().Schema, map[string]interface{}{})
+	d.SetId("42:production")
+	out, err := resourceEdgeWorkersActivationImport(d, nil)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, "42:PRODUCTION", d.Id())
+	assert.Equal(t, "0.2", d.Get("version"))
+	assert.Equal(t, 42, d.Get("edgeworker_id"))
+
+	for _, id := range []string{"42", "x:STAGING", "42:QA"} {
+		invalid := schema.TestResourceDataRaw(t, resourceEdgeWorkersActivation().Schema, map[string]interface{}{})
+		invalid.SetId(id)
+		_, err := resourceEdgeWorkersActivationImport(invalid, nil)
+		assert.Error(t, err, id)
+	}
+}
@@ -0,0 +1,119 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// api sends the requests to the IAM API
+var api apiclient.Client
+
+type (
+	authGrant struct {
+		GroupID   int    `json:"groupId"`
+		GroupName string `json:"groupName,omitempty"`
+		RoleID    int    `json:"roleId"`
+		RoleName  string `json:"roleName,omitempty"`
+	}
+
+	userBasicInfo struct {
+		FirstName         string `json:"firstName"`
+		LastName          string `json:"lastName"`
+		Email             string `json:"email"`
+		Phone             string `json:"phone,omitempty"`
+		Country           string `json:"country"`
+		TimeZone          string `json:"timeZone,omitempty"`
+		PreferredLanguage string `json:"preferredLanguage,omitempty"`
+		ContactType       string `json:"contactType,omitempty"`
+		JobTitle          string `json:"jobTitle,omitempty"`
+		TFAEnabled        bool   `json:"tfaEnabled"`
+	}
+
+	user struct {
+		userBasicInfo
+		UIIdentityID string      `json:"uiIdentityId,omitempty"`
+		UIUserName   string      `json:"uiUserName,omitempty"`
+		IsLocked     bool        `json:"isLocked,omitempty"`
+		AuthGrants   []authGrant `json:"authGrants"`
+	}
+
+	group struct {
+		GroupID       int    `json:"groupId,omitempty"`
+		GroupName     string `json:"groupName"`
+		ParentGroupID int    `json:"parentGroupId,omitempty"`
+	}
+
+	grantedRole struct {
+		RoleID          int    `json:"grantedRoleId"`
+		RoleName        string `json:"grantedRoleName,omitempty"`
+		RoleDescription string `json:"grantedRoleDescription,omitempty"`
+	}
+
+	role struct {
+		RoleID          int           `json:"roleId,omitempty"`
+		RoleName        string        `json:"roleName"`
+		RoleDescription string        `json:"roleDescription"`
+		RoleType        string        `json:"type,omitempty"`
+		GrantedRoles    []grantedRole `json:"grantedRoles"`
+	}
+)
+
+func userLocation(id string) string {
+	return "/identity-management/v2/user-admin/ui-identities/" + id
+}
+
+func groupLocation(id int) string {
+	return fmt.Sprintf("/identity-management/v2/user-admin/groups/%d", id)
+}
+
+func roleLocation(id int) string {
+	return fmt.Sprintf("/identity-management/v2/user-admin/roles/%d", id)
+}
+
+// getUser returns the user with its auth grants
+func getUser(id string) (*user, error) {
+	var u user
+	if err := api.DoRequest(http.MethodGet, userLocation(id)+"?authGrants=true", nil, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// updateAuthGrants replaces the auth grants of the user
+func updateAuthGrants(id string, grants []authGrant) error {
+	return api.DoRequest(http.MethodPut, userLocation(id)+"/auth-grants", grants, nil)
+}
+
+// parseGroupID accepts group IDs with or without the grp_ prefix of akamai_group
+func parseGroupID(v string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(v, "grp_"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid group ID %q", v)
+	}
+	return id, nil
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
+
+// suppressGroupPrefixDiffs ignores the grp_ prefix of group IDs in nested blocks, where
+// state functions don't apply
+func suppressGroupPrefixDiffs(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimPrefix(old, "grp_") == strings.TrimPrefix(new, "grp_")
+}
+
+// validateGroupID checks the value is a group ID with or without the grp_ prefix
+func validateGroupID(v interface{}, k string) ([]string, []error) {
+	if _, err := parseGroupID(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: %s", k, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,34 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceCountries() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCountriesRead,
+		Schema: map[string]*schema.Schema{
+			"countries": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The countries accepted for the country of a user",
+			},
+		},
+	}
+}
+
+func dataSourceCountriesRead(d *schema.ResourceData, meta interface{}) error {
+	var countries []string
+	if err := api.DoRequest(http.MethodGet, "/identity-management/v2/user-admin/common/countries", nil, &countries); err != nil {
+		return apiclient.Error(err)
+	}
+
+	d.SetId(tools.GetSHAString(fmt.Sprint(countries)))
+	return d.Set("countries", countries)
+}
@@ -0,0 +1,23 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceCountries(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, dataSourceCountries().Schema, map[string]interface{}{})
+	require.NoError(t, dataSourceCountriesRead(d, nil))
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, []interface{}{"Canada", "Poland", "USA"}, d.Get("countries"))
+}
@@ -0,0 +1,60 @@
+package iam
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGrantableRoles() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceGrantableRolesRead,
+		Schema: map[string]*schema.Schema{
+			"grantable_roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The roles the API client can grant in custom roles",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"granted_role_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGrantableRolesRead(d *schema.ResourceData, meta interface{}) error {
+	var roles []grantedRole
+	if err := api.DoRequest(http.MethodGet, "/identity-management/v2/user-admin/roles/grantable-roles", nil, &roles); err != nil {
+		return apiclient.Error(err)
+	}
+
+	ids := make([]string, 0, len(roles))
+	out := make([]interface{}, 0, len(roles))
+	for _, r := range roles {
+		ids = append(ids, strconv.Itoa(r.RoleID))
+		out = append(out, map[string]interface{}{
+			"granted_role_id": r.RoleID,
+			"name":            r.RoleName,
+			"description":     r.RoleDescription,
+		})
+	}
+
+	d.SetId(strings.Join(ids, ","))
+	return d.Set("grantable_roles", out)
+}
@@ -0,0 +1,25 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceGrantableRoles(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, dataSourceGrantableRoles().Schema, map[string]interface{}{})
+	require.NoError(t, dataSourceGrantableRolesRead(d, nil))
+	assert.Equal(t, "1,2,3", d.Id())
+	assert.Equal(t, 3, d.Get("grantable_roles.#"))
+	assert.Equal(t, "Editor", d.Get("grantable_roles.1.name"))
+	assert.Equal(t, 2, d.Get("grantable_roles.1.granted_role_id"))
+}
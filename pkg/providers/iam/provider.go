@@ -0,0 +1,146 @@
+package iam
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"iam_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("iam_section"),
+			},
+			"iam": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("iam"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_iam_countries":       dataSourceCountries(),
+			"akamai_iam_grantable_roles": dataSourceGrantableRoles(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_iam_group":                resourceGroup(),
+			"akamai_iam_role":                 resourceRole(),
+			"akamai_iam_user":                 resourceUser(),
+			"akamai_iam_user_role_assignment": resourceUserRoleAssignment(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigIAMService(d resourceData) (*edgegrid.Config, error) {
+	var IAMConfig edgegrid.Config
+	if _, ok := d.GetOk("iam"); ok {
+		log.Printf("[DEBUG] Setting iam config via HCL")
+		config := d.Get("iam").(set).List()[0].(map[string]interface{})
+
+		IAMConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(IAMConfig)
+		return &IAMConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("iam_section"); ok && section != "default" {
+		IAMConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		IAMConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(IAMConfig)
+	return &IAMConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "iam"
+}
+
+// IAMProviderVersion update version string anytime provider adds new features
+const IAMProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return IAMProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigIAMService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,138 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IAM group
+//
+// https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#groups
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceGroupCreate,
+		Read:   resourceGroupRead,
+		Update: resourceGroupUpdate,
+		Delete: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceGroupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"parent_group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    trimPrefixStateFunc("grp_"),
+				ValidateFunc: validateGroupID,
+			},
+		},
+	}
+}
+
+func resourceGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceGroupCreate")
+
+	parent, err := parseGroupID(d.Get("parent_group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	req := group{GroupName: d.Get("name").(string)}
+	log.Debug(fmt.Sprintf("creating group %q in group %d", req.GroupName, parent))
+	var g group
+	if err := api.DoRequest(http.MethodPost, groupLocation(parent), req, &g); err != nil {
+		log.Error(fmt.Sprintf("group create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(strconv.Itoa(g.GroupID))
+	return resourceGroupRead(d, meta)
+}
+
+func resourceGroupRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceGroupRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid group ID %q", d.Id())
+	}
+
+	var g group
+	if err := api.DoRequest(http.MethodGet, groupLocation(id), nil, &g); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("group %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	if err := d.Set("name", g.GroupName); err != nil {
+		return err
+	}
+	return d.Set("parent_group_id", strconv.Itoa(g.ParentGroupID))
+}
+
+func resourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceGroupUpdate")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid group ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("renaming group %d", id))
+	if err := api.DoRequest(http.MethodPut, groupLocation(id), group{GroupName: d.Get("name").(string)}, nil); err != nil {
+		log.Error(fmt.Sprintf("group update failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return resourceGroupRead(d, meta)
+}
+
+func resourceGroupDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceGroupDelete")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid group ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting group %d", id))
+	if err := api.DoRequest(http.MethodDelete, groupLocation(id), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("group delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by group ID, with or without the grp_ prefix
+func resourceGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := parseGroupID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(strconv.Itoa(id))
+
+	if err := resourceGroupRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("group %d not found", id)
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,45 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceGroup(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{
+		"name":            "Marketing",
+		"parent_group_id": "grp_10",
+	})
+	require.NoError(t, resourceGroupCreate(d, nil))
+	assert.Equal(t, "101", d.Id())
+	assert.Equal(t, 10, fake.groups[101].ParentGroupID)
+	assert.Equal(t, "10", d.Get("parent_group_id"))
+
+	d.Set("name", "Brand")
+	require.NoError(t, resourceGroupUpdate(d, nil))
+	assert.Equal(t, "Brand", fake.groups[101].GroupName)
+
+	imported := schema.TestResourceDataRaw(t, resourceGroup().Schema, map[string]interface{}{})
+	imported.SetId("grp_101")
+	_, err := resourceGroupImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "101", imported.Id())
+	assert.Equal(t, "Brand", imported.Get("name"))
+
+	require.NoError(t, resourceGroupDelete(d, nil))
+	assert.NotContains(t, fake.groups, 101)
+
+	imported.SetId("grp_101")
+	_, err = resourceGroupImport(imported, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IAM custom role, a named set of the grantable roles
+//
+// https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#roles
+func resourceRole() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRoleCreate,
+		Read:   resourceRoleRead,
+		Update: resourceRoleUpdate,
+		Delete: resourceRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"granted_roles": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the roles granted, from the akamai_iam_grantable_roles data source",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRoleCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceRoleCreate")
+
+	req := roleFromResourceData(d)
+	log.Debug(fmt.Sprintf("creating role %q", req.RoleName))
+	var r role
+	if err := api.DoRequest(http.MethodPost, "/identity-management/v2/user-admin/roles", req, &r); err != nil {
+		log.Error(fmt.Sprintf("role create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(strconv.Itoa(r.RoleID))
+	return resourceRoleRead(d, meta)
+}
+
+func resourceRoleRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceRoleRead")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid role ID %q", d.Id())
+	}
+
+	var r role
+	if err := api.DoRequest(http.MethodGet, roleLocation(id)+"?grantedRoles=true", nil, &r); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("role %d not found, removing from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	granted := make([]interface{}, 0, len(r.GrantedRoles))
+	for _, g := range r.GrantedRoles {
+		granted = append(granted, g.RoleID)
+	}
+
+	attrs := map[string]interface{}{
+		"name":          r.RoleName,
+		"description":   r.RoleDescription,
+		"granted_roles": schema.NewSet(schema.HashInt, granted),
+		"type":          r.RoleType,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceRoleUpdate")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid role ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("updating role %d", id))
+	if err := api.DoRequest(http.MethodPut, roleLocation(id), roleFromResourceData(d), nil); err != nil {
+		log.Error(fmt.Sprintf("role update failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return resourceRoleRead(d, meta)
+}
+
+func resourceRoleDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceRoleDelete")
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("invalid role ID %q", d.Id())
+	}
+
+	log.Debug(fmt.Sprintf("deleting role %d", id))
+	if err := api.DoRequest(http.MethodDelete, roleLocation(id), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("role delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func roleFromResourceData(d *schema.ResourceData) role {
+	var ids []int
+	for _, id := range d.Get("granted_roles").(*schema.Set).List() {
+		ids = append(ids, id.(int))
+	}
+	sort.Ints(ids)
+
+	r := role{
+		RoleName:        d.Get("name").(string),
+		RoleDescription: d.Get("description").(string),
+		GrantedRoles:    make([]grantedRole, 0, len(ids)),
+	}
+	for _, id := range ids {
+		r.GrantedRoles = append(r.GrantedRoles, grantedRole{RoleID: id})
+	}
+	return r
+}
@@ -0,0 +1,40 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRole(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceRole().Schema, map[string]interface{}{
+		"name":          "Publishers",
+		"description":   "Edit and activate configurations",
+		"granted_roles": []interface{}{3, 2},
+	})
+	require.NoError(t, resourceRoleCreate(d, nil))
+	assert.Equal(t, "101", d.Id())
+	assert.Equal(t, []grantedRole{{RoleID: 2}, {RoleID: 3}}, fake.roles[101].GrantedRoles)
+	assert.Equal(t, "custom", d.Get("type"))
+
+	d.Set("granted_roles", []interface{}{1})
+	require.NoError(t, resourceRoleUpdate(d, nil))
+	assert.Equal(t, []grantedRole{{RoleID: 1}}, fake.roles[101].GrantedRoles)
+	assert.Equal(t, 1, d.Get("granted_roles").(*schema.Set).Len())
+
+	require.NoError(t, resourceRoleDelete(d, nil))
+	assert.Empty(t, fake.roles)
+
+	d.SetId("101")
+	require.NoError(t, resourceRoleRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
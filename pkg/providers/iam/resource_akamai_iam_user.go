@@ -0,0 +1,274 @@
+package iam
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IAM user of Akamai Control Center
+//
+// https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#users
+func resourceUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserCreate,
+		Read:   resourceUserRead,
+		Update: resourceUserUpdate,
+		Delete: resourceUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"first_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"last_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "One of the akamai_iam_countries",
+			},
+			"phone": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"job_title": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"time_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"preferred_language": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"contact_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"enable_tfa": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"send_otp_email": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to email the user a one time password when the user is created",
+			},
+			"auth_grants": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Set:         authGrantHash,
+				Elem:        authGrantResource,
+				Description: "The roles of the user by group, required when creating the user",
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_locked": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+var authGrantResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"group_id": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateFunc:     validateGroupID,
+			DiffSuppressFunc: suppressGroupPrefixDiffs,
+		},
+		"role_id": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+	},
+}
+
+// authGrantHash hashes grants by group and role, ignoring the grp_ prefix of the group ID
+func authGrantHash(v interface{}) int {
+	m := v.(map[string]interface{})
+	group, _ := parseGroupID(m["group_id"].(string))
+	return schema.HashString(fmt.Sprintf("%d:%d", group, m["role_id"].(int)))
+}
+
+func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceUserCreate")
+
+	grants, err := authGrantsFromSet(d.Get("auth_grants").(*schema.Set))
+	if err != nil {
+		return err
+	}
+	if len(grants) == 0 {
+		return fmt.Errorf("auth_grants must have at least one grant to create a user")
+	}
+
+	req := user{
+		userBasicInfo: userBasicInfoFromResourceData(d),
+		AuthGrants:    grants,
+	}
+
+	log.Debug(fmt.Sprintf("creating user %s", req.Email))
+	var u user
+	path := fmt.Sprintf("/identity-management/v2/user-admin/ui-identities?sendEmail=%t", d.Get("send_otp_email").(bool))
+	if err := api.DoRequest(http.MethodPost, path, req, &u); err != nil {
+		log.Error(fmt.Sprintf("user create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(u.UIIdentityID)
+	return resourceUserRead(d, meta)
+}
+
+func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceUserRead")
+
+	u, err := getUser(d.Id())
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("user %s not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	grants := make([]interface{}, 0, len(u.AuthGrants))
+	for _, g := range u.AuthGrants {
+		grants = append(grants, map[string]interface{}{
+			"group_id": strconv.Itoa(g.GroupID),
+			"role_id":  g.RoleID,
+		})
+	}
+
+	attrs := map[string]interface{}{
+		"first_name":         u.FirstName,
+		"last_name":          u.LastName,
+		"email":              u.Email,
+		"country":            u.Country,
+		"phone":              u.Phone,
+		"job_title":          u.JobTitle,
+		"time_zone":          u.TimeZone,
+		"preferred_language": u.PreferredLanguage,
+		"contact_type":       u.ContactType,
+		"enable_tfa":         u.TFAEnabled,
+		"auth_grants":        schema.NewSet(authGrantHash, grants),
+		"user_name":          u.UIUserName,
+		"is_locked":          u.IsLocked,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceUserUpdate")
+
+	if d.HasChanges("first_name", "last_name", "email", "country", "phone", "job_title", "time_zone", "preferred_language", "contact_type", "enable_tfa") {
+		log.Debug(fmt.Sprintf("updating user %s", d.Id()))
+		if err := api.DoRequest(http.MethodPut, userLocation(d.Id())+"/basic-info", userBasicInfoFromResourceData(d), nil); err != nil {
+			log.Error(fmt.Sprintf("user update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	if d.HasChange("auth_grants") {
+		grants, err := authGrantsFromSet(d.Get("auth_grants").(*schema.Set))
+		if err != nil {
+			return err
+		}
+		if len(grants) == 0 {
+			return fmt.Errorf("auth_grants must have at least one grant")
+		}
+		log.Debug(fmt.Sprintf("updating auth grants of user %s", d.Id()))
+		if err := updateAuthGrants(d.Id(), grants); err != nil {
+			log.Error(fmt.Sprintf("auth grants update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	return resourceUserRead(d, meta)
+}
+
+func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "resourceUserDelete")
+
+	log.Debug(fmt.Sprintf("deleting user %s", d.Id()))
+	if err := api.DoRequest(http.MethodDelete, userLocation(d.Id()), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("user delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func userBasicInfoFromResourceData(d *schema.ResourceData) userBasicInfo {
+	return userBasicInfo{
+		FirstName:         d.Get("first_name").(string),
+		LastName:          d.Get("last_name").(string),
+		Email:             d.Get("email").(string),
+		Phone:             d.Get("phone").(string),
+		Country:           d.Get("country").(string),
+		TimeZone:          d.Get("time_zone").(string),
+		PreferredLanguage: d.Get("preferred_language").(string),
+		ContactType:       d.Get("contact_type").(string),
+		JobTitle:          d.Get("job_title").(string),
+		TFAEnabled:        d.Get("enable_tfa").(bool),
+	}
+}
+
+// authGrantsFromSet converts auth_grants blocks to grants sorted by group and role
+func authGrantsFromSet(s *schema.Set) ([]authGrant, error) {
+	grants := make([]authGrant, 0, s.Len())
+	for _, v := range s.List() {
+		m := v.(map[string]interface{})
+		group, err := parseGroupID(m["group_id"].(string))
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, authGrant{GroupID: group, RoleID: m["role_id"].(int)})
+	}
+	sortAuthGrants(grants)
+	return grants, nil
+}
+
+func sortAuthGrants(grants []authGrant) {
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].GroupID != grants[j].GroupID {
+			return grants[i].GroupID < grants[j].GroupID
+		}
+		return grants[i].RoleID < grants[j].RoleID
+	})
+}
@@ -0,0 +1,230 @@
+package iam
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// grantsLock serializes the read-modify-write of auth grants, terraform applies the
+// assignments of a user in parallel
+var grantsLock sync.Mutex
+
+// Role of an IAM user on a group, one of the auth grants of the user
+//
+// https://developer.akamai.com/api/core_features/identity_management_user_admin/v2.html#putuserauthgrants
+func resourceUserRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceUserRoleAssignmentCreate,
+		Read:   resourceUserRoleAssignmentRead,
+		Update: resourceUserRoleAssignmentUpdate,
+		Delete: resourceUserRoleAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceUserRoleAssignmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				StateFunc:    trimPrefixStateFunc("grp_"),
+				ValidateFunc: validateGroupID,
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceUserRoleAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	userID := d.Get("user_id").(string)
+	groupID, err := parseGroupID(d.Get("group_id").(string))
+	if err != nil {
+		return err
+	}
+
+	grantsLock.Lock()
+	defer grantsLock.Unlock()
+
+	u, err := getUser(userID)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+	for _, g := range u.AuthGrants {
+		if g.GroupID == groupID && g.RoleID != d.Get("role_id").(int) {
+			return fmt.Errorf("user %s already has role %d on group %d, import the assignment to manage it", userID, g.RoleID, groupID)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s:%d", userID, groupID))
+	if err := writeAssignment(d, u, d.Get("role_id").(int)); err != nil {
+		d.SetId("")
+		return err
+	}
+	return readAssignment(d)
+}
+
+func resourceUserRoleAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	return readAssignment(d)
+}
+
+func resourceUserRoleAssignmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	userID, _, err := parseAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	grantsLock.Lock()
+	defer grantsLock.Unlock()
+
+	u, err := getUser(userID)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+	if err := writeAssignment(d, u, d.Get("role_id").(int)); err != nil {
+		return err
+	}
+	return readAssignment(d)
+}
+
+func resourceUserRoleAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	userID, groupID, err := parseAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	grantsLock.Lock()
+	defer grantsLock.Unlock()
+
+	u, err := getUser(userID)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+	if len(u.AuthGrants) == 1 && u.AuthGrants[0].GroupID == groupID {
+		return fmt.Errorf("group %d is the only group of user %s, delete the user instead", groupID, userID)
+	}
+	if err := writeAssignment(d, u, 0); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<user_id>:<group_id>"
+func resourceUserRoleAssignmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	userID, groupID, err := parseAssignmentID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(fmt.Sprintf("%s:%d", userID, groupID))
+
+	if err := readAssignment(d); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("user %s has no role on group %d", userID, groupID)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readAssignment sets the role of the user on the group
+func readAssignment(d *schema.ResourceData) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "readAssignment")
+
+	userID, groupID, err := parseAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	u, err := getUser(userID)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("user %s not found, removing assignment from state", userID))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	for _, g := range u.AuthGrants {
+		if g.GroupID != groupID {
+			continue
+		}
+		attrs := map[string]interface{}{
+			"user_id":  userID,
+			"group_id": strconv.Itoa(groupID),
+			"role_id":  g.RoleID,
+		}
+		for k, v := range attrs {
+			if err := d.Set(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	log.Warn(fmt.Sprintf("user %s has no role on group %d, removing assignment from state", userID, groupID))
+	d.SetId("")
+	return nil
+}
+
+// writeAssignment sets the role of the user on the group of the assignment, keeping the
+// other grants, a zero role removes the grant
+func writeAssignment(d *schema.ResourceData, u *user, roleID int) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IAM", "writeAssignment")
+
+	userID, groupID, err := parseAssignmentID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	grants := make([]authGrant, 0, len(u.AuthGrants)+1)
+	for _, g := range u.AuthGrants {
+		if g.GroupID != groupID {
+			grants = append(grants, authGrant{GroupID: g.GroupID, RoleID: g.RoleID})
+		}
+	}
+	if roleID != 0 {
+		grants = append(grants, authGrant{GroupID: groupID, RoleID: roleID})
+	}
+	sortAuthGrants(grants)
+
+	log.Debug(fmt.Sprintf("updating auth grants of user %s for group %d", userID, groupID))
+	if err := updateAuthGrants(userID, grants); err != nil {
+		log.Error(fmt.Sprintf("auth grants update failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return nil
+}
+
+// parseAssignmentID splits a "<user_id>:<group_id>" resource ID
+func parseAssignmentID(id string) (string, int, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" {
+		return "", 0, fmt.Errorf("invalid ID %q, expected <user_id>:<group_id>", id)
+	}
+	groupID, err := parseGroupID(parts[1])
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid ID %q, expected <user_id>:<group_id>", id)
+	}
+	return parts[0], groupID, nil
+}
@@ -0,0 +1,67 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceUserRoleAssignment(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	fake.groups[20] = &group{GroupID: 20, GroupName: "Marketing", ParentGroupID: 10}
+	fake.users["A-B-1"] = &user{UIIdentityID: "A-B-1", AuthGrants: []authGrant{{GroupID: 10, RoleID: 3}}}
+
+	d := schema.TestResourceDataRaw(t, resourceUserRoleAssignment().Schema, map[string]interface{}{
+		"user_id":  "A-B-1",
+		"group_id": "grp_20",
+		"role_id":  2,
+	})
+	require.NoError(t, resourceUserRoleAssignmentCreate(d, nil))
+	assert.Equal(t, "A-B-1:20", d.Id())
+	assert.Equal(t, []authGrant{{GroupID: 10, RoleID: 3}, {GroupID: 20, RoleID: 2}}, fake.users["A-B-1"].AuthGrants)
+
+	// another role on a group of the user is left alone
+	other := schema.TestResourceDataRaw(t, resourceUserRoleAssignment().Schema, map[string]interface{}{
+		"user_id":  "A-B-1",
+		"group_id": "10",
+		"role_id":  1,
+	})
+	err := resourceUserRoleAssignmentCreate(other, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already has role 3 on group 10")
+	assert.Equal(t, "", other.Id())
+
+	d.Set("role_id", 1)
+	require.NoError(t, resourceUserRoleAssignmentUpdate(d, nil))
+	assert.Equal(t, []authGrant{{GroupID: 10, RoleID: 3}, {GroupID: 20, RoleID: 1}}, fake.users["A-B-1"].AuthGrants)
+
+	imported := schema.TestResourceDataRaw(t, resourceUserRoleAssignment().Schema, map[string]interface{}{})
+	imported.SetId("A-B-1:grp_10")
+	_, err = resourceUserRoleAssignmentImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "A-B-1:10", imported.Id())
+	assert.Equal(t, 3, imported.Get("role_id"))
+
+	require.NoError(t, resourceUserRoleAssignmentDelete(d, nil))
+	assert.Equal(t, []authGrant{{GroupID: 10, RoleID: 3}}, fake.users["A-B-1"].AuthGrants)
+
+	// the last grant of a user can't be removed
+	err = resourceUserRoleAssignmentDelete(imported, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only group")
+
+	for _, id := range []string{"A-B-1", "A-B-1:marketing", "A-B-1:20"} {
+		invalid := schema.TestResourceDataRaw(t, resourceUserRoleAssignment().Schema, map[string]interface{}{})
+		invalid.SetId(id)
+		_, err := resourceUserRoleAssignmentImport(invalid, nil)
+		assert.Error(t, err, id)
+	}
+}
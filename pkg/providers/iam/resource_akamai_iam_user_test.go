@@ -0,0 +1,353 @@
+package iam
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIAM serves the IAM endpoints used by the provider from the mock server
+type fakeIAM struct {
+	lock   sync.Mutex
+	nextID int
+	users  map[string]*user
+	groups map[int]*group
+	roles  map[int]*role
+	// sentEmail records the sendEmail parameter of user creations
+	sentEmail []string
+}
+
+func newFakeIAM(srv *mockapi.Server) *fakeIAM {
+	f := &fakeIAM{
+		nextID: 100,
+		users:  make(map[string]*user),
+		groups: map[int]*group{10: {GroupID: 10, GroupName: "Example"}},
+		roles:  make(map[int]*role),
+	}
+
+	srv.Handle("POST", `/identity-management/v2/user-admin/ui-identities`, f.createUser)
+	srv.Handle("GET", `/identity-management/v2/user-admin/ui-identities/([^/]+)`, f.getUser)
+	srv.Handle("PUT", `/identity-management/v2/user-admin/ui-identities/([^/]+)/basic-info`, f.updateBasicInfo)
+	srv.Handle("PUT", `/identity-management/v2/user-admin/ui-identities/([^/]+)/auth-grants`, f.updateAuthGrants)
+	srv.Handle("DELETE", `/identity-management/v2/user-admin/ui-identities/([^/]+)`, f.deleteUser)
+	srv.Handle("POST", `/identity-management/v2/user-admin/groups/(\d+)`, f.createGroup)
+	srv.Handle("GET", `/identity-management/v2/user-admin/groups/(\d+)`, f.getGroup)
+	srv.Handle("PUT", `/identity-management/v2/user-admin/groups/(\d+)`, f.updateGroup)
+	srv.Handle("DELETE", `/identity-management/v2/user-admin/groups/(\d+)`, f.deleteGroup)
+	srv.Handle("GET", `/identity-management/v2/user-admin/roles/grantable-roles`, f.grantableRoles)
+	srv.Handle("POST", `/identity-management/v2/user-admin/roles`, f.createRole)
+	srv.Handle("GET", `/identity-management/v2/user-admin/roles/(\d+)`, f.getRole)
+	srv.Handle("PUT", `/identity-management/v2/user-admin/roles/(\d+)`, f.updateRole)
+	srv.Handle("DELETE", `/identity-management/v2/user-admin/roles/(\d+)`, f.deleteRole)
+	srv.Handle("GET", `/identity-management/v2/user-admin/common/countries`, f.countries)
+
+	return f
+}
+
+func (f *fakeIAM) id() int {
+	f.nextID++
+	return f.nextID
+}
+
+// validGrants checks the grants reference existing groups, users need at least one
+func (f *fakeIAM) validGrants(grants []authGrant) bool {
+	for _, g := range grants {
+		if _, ok := f.groups[g.GroupID]; !ok {
+			return false
+		}
+	}
+	return len(grants) > 0
+}
+
+func (f *fakeIAM) createUser(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var u user
+	if !mockapi.ReadJSON(w, r, &u) {
+		return
+	}
+	if u.Email == "" || !f.validGrants(u.AuthGrants) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	u.UIIdentityID = "A-B-" + strconv.Itoa(f.id())
+	u.UIUserName = u.Email
+	if u.TimeZone == "" {
+		u.TimeZone = "GMT"
+	}
+	f.users[u.UIIdentityID] = &u
+	f.sentEmail = append(f.sentEmail, r.URL.Query().Get("sendEmail"))
+	mockapi.WriteJSON(w, http.StatusCreated, u)
+}
+
+func (f *fakeIAM) getUser(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	u, ok := f.users[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, u)
+}
+
+func (f *fakeIAM) updateBasicInfo(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	u, ok := f.users[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var info userBasicInfo
+	if !mockapi.ReadJSON(w, r, &info) {
+		return
+	}
+	u.userBasicInfo = info
+	mockapi.WriteJSON(w, http.StatusOK, info)
+}
+
+func (f *fakeIAM) updateAuthGrants(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	u, ok := f.users[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var grants []authGrant
+	if !mockapi.ReadJSON(w, r, &grants) {
+		return
+	}
+	if !f.validGrants(grants) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	u.AuthGrants = grants
+	mockapi.WriteJSON(w, http.StatusOK, grants)
+}
+
+func (f *fakeIAM) deleteUser(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.users[params[0]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.users, params[0])
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeIAM) createGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	parent, _ := strconv.Atoi(params[0])
+	var g group
+	if _, ok := f.groups[parent]; !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !mockapi.ReadJSON(w, r, &g) {
+		return
+	}
+	if g.GroupName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	g.GroupID = f.id()
+	g.ParentGroupID = parent
+	f.groups[g.GroupID] = &g
+	mockapi.WriteJSON(w, http.StatusCreated, g)
+}
+
+func (f *fakeIAM) getGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	g, ok := f.groups[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, g)
+}
+
+func (f *fakeIAM) updateGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	g, ok := f.groups[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var req group
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if req.GroupName == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	g.GroupName = req.GroupName
+	mockapi.WriteJSON(w, http.StatusOK, g)
+}
+
+func (f *fakeIAM) deleteGroup(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	if _, ok := f.groups[id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.groups, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+var fakeGrantableRoles = []grantedRole{
+	{RoleID: 1, RoleName: "Admin", RoleDescription: "Full access"},
+	{RoleID: 2, RoleName: "Editor", RoleDescription: "Edit configurations"},
+	{RoleID: 3, RoleName: "Viewer", RoleDescription: "Read only"},
+}
+
+func (f *fakeIAM) grantableRoles(w http.ResponseWriter, r *http.Request, _ []string) {
+	mockapi.WriteJSON(w, http.StatusOK, fakeGrantableRoles)
+}
+
+func (f *fakeIAM) createRole(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var role role
+	if !mockapi.ReadJSON(w, r, &role) {
+		return
+	}
+	if role.RoleName == "" || len(role.GrantedRoles) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	role.RoleID = f.id()
+	role.RoleType = "custom"
+	f.roles[role.RoleID] = &role
+	mockapi.WriteJSON(w, http.StatusCreated, role)
+}
+
+func (f *fakeIAM) getRole(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	role, ok := f.roles[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, role)
+}
+
+func (f *fakeIAM) updateRole(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	current, ok := f.roles[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var role role
+	if !mockapi.ReadJSON(w, r, &role) {
+		return
+	}
+	if len(role.GrantedRoles) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	current.RoleName, current.RoleDescription, current.GrantedRoles = role.RoleName, role.RoleDescription, role.GrantedRoles
+	mockapi.WriteJSON(w, http.StatusOK, current)
+}
+
+func (f *fakeIAM) deleteRole(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	id, _ := strconv.Atoi(params[0])
+	if _, ok := f.roles[id]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.roles, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeIAM) countries(w http.ResponseWriter, r *http.Request, _ []string) {
+	mockapi.WriteJSON(w, http.StatusOK, []string{"Canada", "Poland", "USA"})
+}
+
+func TestResourceUser(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeIAM(srv)
+	api.Init(srv.Config())
+
+	config := map[string]interface{}{
+		"first_name":     "Jane",
+		"last_name":      "Doe",
+		"email":          "jane@example.com",
+		"country":        "USA",
+		"send_otp_email": false,
+	}
+	d := schema.TestResourceDataRaw(t, resourceUser().Schema, config)
+	err := resourceUserCreate(d, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one grant")
+
+	config["auth_grants"] = []interface{}{
+		map[string]interface{}{"group_id": "grp_10", "role_id": 3},
+	}
+	d = schema.TestResourceDataRaw(t, resourceUser().Schema, config)
+	require.NoError(t, resourceUserCreate(d, nil))
+	assert.Equal(t, "A-B-101", d.Id())
+	assert.Equal(t, []string{"false"}, fake.sentEmail)
+	assert.Equal(t, []authGrant{{GroupID: 10, RoleID: 3}}, fake.users["A-B-101"].AuthGrants)
+	assert.Equal(t, "GMT", d.Get("time_zone"))
+	assert.Equal(t, "jane@example.com", d.Get("user_name"))
+
+	// the grp_ prefix doesn't change the grant
+	assert.Equal(t,
+		authGrantHash(map[string]interface{}{"group_id": "grp_10", "role_id": 3}),
+		authGrantHash(map[string]interface{}{"group_id": "10", "role_id": 3}))
+	assert.True(t, d.Get("auth_grants").(*schema.Set).Contains(map[string]interface{}{"group_id": "grp_10", "role_id": 3}))
+
+	d.Set("job_title", "Engineer")
+	d.Set("auth_grants", []interface{}{
+		map[string]interface{}{"group_id": "10", "role_id": 2},
+	})
+	require.NoError(t, resourceUserUpdate(d, nil))
+	assert.Equal(t, "Engineer", fake.users["A-B-101"].JobTitle)
+	assert.Equal(t, []authGrant{{GroupID: 10, RoleID: 2}}, fake.users["A-B-101"].AuthGrants)
+
+	require.NoError(t, resourceUserDelete(d, nil))
+	assert.Empty(t, fake.users)
+
+	d.SetId("A-B-101")
+	require.NoError(t, resourceUserRead(d, nil))
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,111 @@
+package imaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The Image and Video Manager API names networks in lower case, unlike the shared apiclient
+// ones
+const (
+	networkStaging    = "staging"
+	networkProduction = "production"
+)
+
+// api sends the requests to the Image and Video Manager API
+var api apiclient.Client
+
+type (
+	policySet struct {
+		ID     string `json:"id,omitempty"`
+		Name   string `json:"name"`
+		Region string `json:"region"`
+		Type   string `json:"type,omitempty"`
+	}
+)
+
+// doPolicyRequest sends the request with the Contract and Policy-Set headers the API
+// scopes policy sets and policies with, empty headers are left out
+func doPolicyRequest(method, path string, headers map[string]string, body, out interface{}) error {
+	req, err := api.NewRequest(method, path, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range headers {
+		if v != "" {
+			req.Header.Set(k, v)
+		}
+	}
+	return api.Do(req, out)
+}
+
+func policySetLocation(id string) string {
+	return "/imaging/v2/policysets/" + id
+}
+
+func policyLocation(network, id string) string {
+	return fmt.Sprintf("/imaging/v2/network/%s/policies/%s", network, id)
+}
+
+// headers returns the Contract and Policy-Set headers of a request
+func headers(contractID, policySetID string) map[string]string {
+	return map[string]string{
+		"Contract":   strings.TrimPrefix(contractID, "ctr_"),
+		"Policy-Set": policySetID,
+	}
+}
+
+// serverFields are assigned by the API to policies and never part of the configured JSON
+var serverFields = []string{"id", "version", "previousVersion", "dateCreated", "user"}
+
+// normalizePolicy decodes a policy and drops the fields assigned by the API
+func normalizePolicy(data string) (map[string]interface{}, error) {
+	policy := make(map[string]interface{})
+	if data == "" {
+		return policy, nil
+	}
+	if err := json.Unmarshal([]byte(data), &policy); err != nil {
+		return nil, err
+	}
+	for _, f := range serverFields {
+		delete(policy, f)
+	}
+	return policy, nil
+}
+
+// equivalentPolicies reports whether the policies only differ by formatting, key order
+// and API assigned fields
+func equivalentPolicies(a, b string) bool {
+	pa, err := normalizePolicy(a)
+	if err != nil {
+		return false
+	}
+	pb, err := normalizePolicy(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(pa, pb)
+}
+
+func suppressEquivalentPolicyDiffs(k, old, new string, d *schema.ResourceData) bool {
+	return equivalentPolicies(old, new)
+}
+
+// validatePolicy checks the value is a JSON policy object
+func validatePolicy(v interface{}, k string) ([]string, []error) {
+	if _, err := normalizePolicy(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON policy object: %s", k, err)}
+	}
+	return nil, nil
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
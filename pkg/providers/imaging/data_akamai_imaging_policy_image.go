@@ -0,0 +1,311 @@
+package imaging
+
+import (
+	"encoding/json"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// transformation is an image transformation block and the policy JSON keys of its attributes
+type transformation struct {
+	name   string
+	fields map[string]string
+	schema map[string]*schema.Schema
+}
+
+// transformations supported as typed blocks, by block name
+var transformations = map[string]transformation{
+	"resize": {
+		name:   "Resize",
+		fields: map[string]string{"width": "width", "height": "height", "type": "type", "aspect": "aspect"},
+		schema: map[string]*schema.Schema{
+			"width":  optionalInt(),
+			"height": optionalInt(),
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"normal", "upsize", "downsize"}, false),
+			},
+			"aspect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"fit", "stretch", "ignore"}, false),
+			},
+		},
+	},
+	"crop": {
+		name: "Crop",
+		fields: map[string]string{"width": "width", "height": "height", "x_position": "xPosition", "y_position": "yPosition",
+			"gravity": "gravity", "allow_expansion": "allowExpansion"},
+		schema: map[string]*schema.Schema{
+			"width":      optionalInt(),
+			"height":     optionalInt(),
+			"x_position": optionalInt(),
+			"y_position": optionalInt(),
+			"gravity": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"allow_expansion": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+		},
+	},
+	"rotate": {
+		name:   "Rotate",
+		fields: map[string]string{"degrees": "degrees"},
+		schema: map[string]*schema.Schema{
+			"degrees": optionalInt(),
+		},
+	},
+	"grayscale": {
+		name:   "Grayscale",
+		fields: map[string]string{"type": "type"},
+		schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"Rec601", "Rec709", "Brightness", "Lightness"}, false),
+			},
+		},
+	},
+	"blur": {
+		name:   "Blur",
+		fields: map[string]string{"sigma": "sigma"},
+		schema: map[string]*schema.Schema{
+			"sigma": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+		},
+	},
+	"trim": {
+		name:   "Trim",
+		fields: map[string]string{"fuzz": "fuzz", "padding": "padding"},
+		schema: map[string]*schema.Schema{
+			"fuzz": {
+				Type:     schema.TypeFloat,
+				Optional: true,
+			},
+			"padding": optionalInt(),
+		},
+	},
+}
+
+func optionalInt() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+	}
+}
+
+func transformationSchema() map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(transformations))
+	for block, t := range transformations {
+		s[block] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: t.schema},
+		}
+	}
+	return s
+}
+
+// Image policy JSON from typed HCL
+//
+// https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#policy
+func dataSourceImagingPolicyImage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceImagingPolicyImageRead,
+		Schema: map[string]*schema.Schema{
+			"policy": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rollout_duration": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Seconds over which the policy is rolled out, between 3600 and 604800",
+						},
+						"hosts": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"breakpoints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"widths": {
+										Type:     schema.TypeList,
+										Required: true,
+										Elem:     &schema.Schema{Type: schema.TypeInt},
+									},
+								},
+							},
+						},
+						"output": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"perceptual_quality": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										ValidateFunc: validation.StringInSlice([]string{"high", "mediumHigh", "medium", "mediumLow", "low"}, false),
+									},
+									"quality":          optionalInt(),
+									"adaptive_quality": optionalInt(),
+									"allowed_formats": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"forced_formats": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+						"transformations": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The transformations in the order they are applied, each with one transformation block",
+							Elem:        &schema.Resource{Schema: transformationSchema()},
+						},
+						"variables": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"type": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice([]string{"bool", "number", "url", "color", "gravity", "placement", "scaleDimension", "grayscaleType", "aspectCrop", "resizeAspect", "resizeType", "dimension", "perceptualQuality", "focus"}, false),
+									},
+									"default_value": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The policy as JSON, to use in akamai_imaging_policy_image.json",
+			},
+		},
+	}
+}
+
+func dataSourceImagingPolicyImageRead(d *schema.ResourceData, meta interface{}) error {
+	in := d.Get("policy").([]interface{})[0].(map[string]interface{})
+	policy := make(map[string]interface{})
+
+	if v := in["rollout_duration"].(int); v != 0 {
+		policy["rolloutDuration"] = v
+	}
+	if v := in["hosts"].([]interface{}); len(v) > 0 {
+		policy["hosts"] = v
+	}
+	if v := in["breakpoints"].([]interface{}); len(v) > 0 && v[0] != nil {
+		policy["breakpoints"] = map[string]interface{}{"widths": v[0].(map[string]interface{})["widths"]}
+	}
+	if v := in["output"].([]interface{}); len(v) > 0 && v[0] != nil {
+		policy["output"] = fieldsToJSON(v[0].(map[string]interface{}), map[string]string{
+			"perceptual_quality": "perceptualQuality",
+			"quality":            "quality",
+			"adaptive_quality":   "adaptiveQuality",
+			"allowed_formats":    "allowedFormats",
+			"forced_formats":     "forcedFormats",
+		})
+	}
+
+	var out []interface{}
+	for _, t := range in["transformations"].([]interface{}) {
+		block, _ := t.(map[string]interface{})
+		for name, spec := range transformations {
+			list, _ := block[name].([]interface{})
+			if len(list) == 0 {
+				continue
+			}
+			m, _ := list[0].(map[string]interface{})
+			tr := fieldsToJSON(m, spec.fields)
+			tr["transformation"] = spec.name
+			out = append(out, tr)
+		}
+	}
+	if len(out) > 0 {
+		policy["transformations"] = out
+	}
+
+	var variables []interface{}
+	for _, v := range in["variables"].([]interface{}) {
+		variables = append(variables, fieldsToJSON(v.(map[string]interface{}), map[string]string{
+			"name":          "name",
+			"type":          "type",
+			"default_value": "defaultValue",
+		}))
+	}
+	if len(variables) > 0 {
+		policy["variables"] = variables
+	}
+
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(tools.GetSHAString(string(data)))
+	return d.Set("json", string(data))
+}
+
+// fieldsToJSON maps the attributes of a block to their policy JSON keys, leaving out the
+// unset ones
+func fieldsToJSON(m map[string]interface{}, fields map[string]string) map[string]interface{} {
+	out := make(map[string]interface{})
+	for attr, key := range fields {
+		switch v := m[attr].(type) {
+		case string:
+			if v != "" {
+				out[key] = v
+			}
+		case int:
+			if v != 0 {
+				out[key] = v
+			}
+		case float64:
+			if v != 0 {
+				out[key] = v
+			}
+		case bool:
+			if v {
+				out[key] = v
+			}
+		case []interface{}:
+			if len(v) > 0 {
+				out[key] = v
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package imaging
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceImagingPolicyImage(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceImagingPolicyImage().Schema, map[string]interface{}{
+		"policy": []interface{}{
+			map[string]interface{}{
+				"breakpoints": []interface{}{
+					map[string]interface{}{"widths": []interface{}{320, 640}},
+				},
+				"output": []interface{}{
+					map[string]interface{}{"perceptual_quality": "mediumHigh", "allowed_formats": []interface{}{"webp", "jpeg"}},
+				},
+				"transformations": []interface{}{
+					map[string]interface{}{
+						"resize": []interface{}{map[string]interface{}{"width": 640, "aspect": "fit"}},
+					},
+					map[string]interface{}{
+						"crop": []interface{}{map[string]interface{}{"width": 200, "height": 100, "gravity": "Center"}},
+					},
+					map[string]interface{}{
+						"grayscale": []interface{}{map[string]interface{}{"type": "Rec601"}},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, dataSourceImagingPolicyImageRead(d, nil))
+
+	assert.JSONEq(t, `{
+		"breakpoints": {"widths": [320, 640]},
+		"output": {"perceptualQuality": "mediumHigh", "allowedFormats": ["webp", "jpeg"]},
+		"transformations": [
+			{"transformation": "Resize", "width": 640, "aspect": "fit"},
+			{"transformation": "Crop", "width": 200, "height": 100, "gravity": "Center"},
+			{"transformation": "Grayscale", "type": "Rec601"}
+		]
+	}`, d.Get("json").(string))
+	assert.NotEmpty(t, d.Id())
+}
@@ -0,0 +1,143 @@
+package imaging
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"imaging_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("imaging_section"),
+			},
+			"imaging": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("imaging"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_imaging_policy_image": dataSourceImagingPolicyImage(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_imaging_policy_image": resourceImagingPolicyImage(),
+			"akamai_imaging_policy_set":   resourceImagingPolicySet(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigImagingService(d resourceData) (*edgegrid.Config, error) {
+	var ImagingConfig edgegrid.Config
+	if _, ok := d.GetOk("imaging"); ok {
+		log.Printf("[DEBUG] Setting imaging config via HCL")
+		config := d.Get("imaging").(set).List()[0].(map[string]interface{})
+
+		ImagingConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(ImagingConfig)
+		return &ImagingConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("imaging_section"); ok && section != "default" {
+		ImagingConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		ImagingConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(ImagingConfig)
+	return &ImagingConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "imaging"
+}
+
+// ImagingProviderVersion update version string anytime provider adds new features
+const ImagingProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return ImagingProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigImagingService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,234 @@
+package imaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Image and Video Manager image policy, deployed to staging then, when enabled, production
+//
+// https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#policy
+func resourceImagingPolicyImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceImagingPolicyImageCreate,
+		Read:   resourceImagingPolicyImageRead,
+		Update: resourceImagingPolicyImageUpdate,
+		Delete: resourceImagingPolicyImageDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceImagingPolicyImageImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"policy_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policyset_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("ctr_"),
+			},
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validatePolicy,
+				DiffSuppressFunc: suppressEquivalentPolicyDiffs,
+				Description:      "The policy as JSON, e.g. from the akamai_imaging_policy_image data source",
+			},
+			"activate_on_production": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to deploy the policy to production after staging",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the policy on staging",
+			},
+		},
+	}
+}
+
+func resourceImagingPolicyImageCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(fmt.Sprintf("%s:%s", d.Get("policyset_id").(string), d.Get("policy_id").(string)))
+
+	if err := deployPolicy(d, networkStaging); err != nil {
+		d.SetId("")
+		return err
+	}
+	if d.Get("activate_on_production").(bool) {
+		if err := deployPolicy(d, networkProduction); err != nil {
+			return err
+		}
+	}
+	return resourceImagingPolicyImageRead(d, meta)
+}
+
+func resourceImagingPolicyImageRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicyImageRead")
+
+	policySetID, policyID, err := parsePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+	h := headers(d.Get("contract_id").(string), policySetID)
+
+	var staging json.RawMessage
+	if err := doPolicyRequest(http.MethodGet, policyLocation(networkStaging, policyID), h, nil, &staging); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("policy %s not found on staging, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	policy, err := normalizePolicy(string(staging))
+	if err != nil {
+		return err
+	}
+	var version struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(staging, &version); err != nil {
+		return err
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+
+	// a production policy that differs from staging is deployed again on the next apply
+	onProduction := d.Get("activate_on_production").(bool)
+	if onProduction {
+		var production json.RawMessage
+		err := doPolicyRequest(http.MethodGet, policyLocation(networkProduction, policyID), h, nil, &production)
+		switch {
+		case apiclient.IsNotFound(err):
+			log.Warn(fmt.Sprintf("policy %s not found on production", d.Id()))
+			onProduction = false
+		case err != nil:
+			return apiclient.Error(err)
+		case !equivalentPolicies(string(staging), string(production)):
+			log.Warn(fmt.Sprintf("policy %s on production differs from staging", d.Id()))
+			onProduction = false
+		}
+	}
+
+	attrs := map[string]interface{}{
+		"policy_id":              policyID,
+		"policyset_id":           policySetID,
+		"json":                   string(data),
+		"version":                version.Version,
+		"activate_on_production": onProduction,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceImagingPolicyImageUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("json") {
+		if err := deployPolicy(d, networkStaging); err != nil {
+			return err
+		}
+	}
+	if d.Get("activate_on_production").(bool) && d.HasChanges("json", "activate_on_production") {
+		if err := deployPolicy(d, networkProduction); err != nil {
+			return err
+		}
+	}
+	return resourceImagingPolicyImageRead(d, meta)
+}
+
+func resourceImagingPolicyImageDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicyImageDelete")
+
+	policySetID, policyID, err := parsePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+	h := headers(d.Get("contract_id").(string), policySetID)
+
+	// remove the policy from production first so it never serves a policy missing on staging
+	for _, network := range []string{networkProduction, networkStaging} {
+		log.Debug(fmt.Sprintf("deleting policy %s on %s", d.Id(), network))
+		if err := doPolicyRequest(http.MethodDelete, policyLocation(network, policyID), h, nil, nil); err != nil && !apiclient.IsNotFound(err) {
+			log.Error(fmt.Sprintf("policy delete on %s failed: %s", network, err))
+			return apiclient.Error(err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<policyset_id>:<policy_id>:<contract_id>"
+func resourceImagingPolicyImageImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid ID %q, expected <policyset_id>:<policy_id>:<contract_id>", d.Id())
+	}
+	d.SetId(parts[0] + ":" + parts[1])
+	if err := d.Set("contract_id", strings.TrimPrefix(parts[2], "ctr_")); err != nil {
+		return nil, err
+	}
+
+	if err := resourceImagingPolicyImageRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("policy %s not found in policy set %s", parts[1], parts[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// deployPolicy writes the configured policy to the network
+func deployPolicy(d *schema.ResourceData, network string) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "deployPolicy")
+
+	policySetID, policyID, err := parsePolicyID(d.Id())
+	if err != nil {
+		return err
+	}
+	policy, err := normalizePolicy(d.Get("json").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Debug(fmt.Sprintf("deploying policy %s to %s", d.Id(), network))
+	h := headers(d.Get("contract_id").(string), policySetID)
+	if err := doPolicyRequest(http.MethodPut, policyLocation(network, policyID), h, policy, nil); err != nil {
+		log.Error(fmt.Sprintf("policy deploy to %s failed: %s", network, err))
+		return apiclient.Error(err)
+	}
+	return nil
+}
+
+// parsePolicyID splits a "<policyset_id>:<policy_id>" resource ID
+func parsePolicyID(id string) (string, string, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid ID %q, expected <policyset_id>:<policy_id>", id)
+	}
+	return parts[0], parts[1], nil
+}
@@ -0,0 +1,60 @@
+package imaging
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `{
+	"breakpoints": {"widths": [320, 640, 1280]},
+	"transformations": [{"transformation": "Resize", "width": 640, "aspect": "fit"}]
+}`
+
+func TestResourceImagingPolicyImage(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeImaging(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceImagingPolicyImage().Schema, map[string]interface{}{
+		"policy_id":    "thumbnails",
+		"policyset_id": "ps-1",
+		"contract_id":  "ctr_1-AB123",
+		"json":         testPolicy,
+	})
+	require.NoError(t, resourceImagingPolicyImageCreate(d, nil))
+	assert.Equal(t, "ps-1:thumbnails", d.Id())
+	assert.Equal(t, 1, d.Get("version"))
+	assert.Contains(t, fake.policies["ps-1/staging"], "thumbnails")
+	assert.NotContains(t, fake.policies["ps-1/production"], "thumbnails")
+
+	// the fields assigned by the API don't show as a diff
+	assert.True(t, equivalentPolicies(testPolicy, d.Get("json").(string)))
+	assert.NotContains(t, d.Get("json"), "dateCreated")
+
+	d.Set("activate_on_production", true)
+	require.NoError(t, resourceImagingPolicyImageUpdate(d, nil))
+	assert.Contains(t, fake.policies["ps-1/production"], "thumbnails")
+	assert.True(t, d.Get("activate_on_production").(bool))
+
+	// a policy changed on production outside terraform is deployed again
+	fake.policies["ps-1/production"]["thumbnails"]["rolloutDuration"] = 3600
+	require.NoError(t, resourceImagingPolicyImageRead(d, nil))
+	assert.False(t, d.Get("activate_on_production").(bool))
+
+	imported := schema.TestResourceDataRaw(t, resourceImagingPolicyImage().Schema, map[string]interface{}{})
+	imported.SetId("ps-1:thumbnails:ctr_1-AB123")
+	_, err := resourceImagingPolicyImageImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ps-1:thumbnails", imported.Id())
+	assert.Equal(t, "thumbnails", imported.Get("policy_id"))
+
+	require.NoError(t, resourceImagingPolicyImageDelete(d, nil))
+	assert.Empty(t, fake.policies["ps-1/staging"])
+	assert.Empty(t, fake.policies["ps-1/production"])
+}
@@ -0,0 +1,152 @@
+package imaging
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Image and Video Manager policy set
+//
+// https://developer.akamai.com/api/web_performance/image_and_video_manager/v2.html#policyset
+func resourceImagingPolicySet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceImagingPolicySetCreate,
+		Read:   resourceImagingPolicySetRead,
+		Update: resourceImagingPolicySetUpdate,
+		Delete: resourceImagingPolicySetDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceImagingPolicySetImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"US", "EMEA", "ASIA", "AUSTRALIA", "JAPAN", "CHINA"}, false),
+				Description:  "Where the images of the policy set are stored and transformed",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "IMAGE",
+				ValidateFunc: validation.StringInSlice([]string{"IMAGE", "VIDEO"}, false),
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Required:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("ctr_"),
+			},
+		},
+	}
+}
+
+func resourceImagingPolicySetCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicySetCreate")
+
+	req := policySet{
+		Name:   d.Get("name").(string),
+		Region: d.Get("region").(string),
+		Type:   d.Get("type").(string),
+	}
+
+	log.Debug(fmt.Sprintf("creating policy set %q", req.Name))
+	var ps policySet
+	if err := doPolicyRequest(http.MethodPost, "/imaging/v2/policysets", headers(d.Get("contract_id").(string), ""), req, &ps); err != nil {
+		log.Error(fmt.Sprintf("policy set create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(ps.ID)
+	return resourceImagingPolicySetRead(d, meta)
+}
+
+func resourceImagingPolicySetRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicySetRead")
+
+	var ps policySet
+	if err := doPolicyRequest(http.MethodGet, policySetLocation(d.Id()), headers(d.Get("contract_id").(string), ""), nil, &ps); err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("policy set %s not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	attrs := map[string]interface{}{
+		"name":   ps.Name,
+		"region": ps.Region,
+		"type":   ps.Type,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceImagingPolicySetUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicySetUpdate")
+
+	req := policySet{
+		Name:   d.Get("name").(string),
+		Region: d.Get("region").(string),
+	}
+
+	log.Debug(fmt.Sprintf("updating policy set %s", d.Id()))
+	if err := doPolicyRequest(http.MethodPut, policySetLocation(d.Id()), headers(d.Get("contract_id").(string), ""), req, nil); err != nil {
+		log.Error(fmt.Sprintf("policy set update failed: %s", err))
+		return apiclient.Error(err)
+	}
+	return resourceImagingPolicySetRead(d, meta)
+}
+
+// Policy sets must have no policies but the default one to be deleted
+func resourceImagingPolicySetDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("IMAGING", "resourceImagingPolicySetDelete")
+
+	log.Debug(fmt.Sprintf("deleting policy set %s", d.Id()))
+	if err := doPolicyRequest(http.MethodDelete, policySetLocation(d.Id()), headers(d.Get("contract_id").(string), ""), nil, nil); err != nil && !apiclient.IsNotFound(err) {
+		log.Error(fmt.Sprintf("policy set delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Import by "<policy_set_id>:<contract_id>"
+func resourceImagingPolicySetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid ID %q, expected <policy_set_id>:<contract_id>", d.Id())
+	}
+	d.SetId(parts[0])
+	if err := d.Set("contract_id", strings.TrimPrefix(parts[1], "ctr_")); err != nil {
+		return nil, err
+	}
+
+	if err := resourceImagingPolicySetRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("policy set %s not found", parts[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,198 @@
+package imaging
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeImaging serves the Image and Video Manager endpoints used by the provider from the mock server
+type fakeImaging struct {
+	lock       sync.Mutex
+	nextID     int
+	policySets map[string]*policySet
+	// policies holds the policies of each network by policy set and policy ID
+	policies map[string]map[string]map[string]interface{}
+}
+
+func newFakeImaging(srv *mockapi.Server) *fakeImaging {
+	f := &fakeImaging{
+		policySets: make(map[string]*policySet),
+		policies:   make(map[string]map[string]map[string]interface{}),
+	}
+
+	srv.Handle("POST", `/imaging/v2/policysets`, f.createPolicySet)
+	srv.Handle("GET", `/imaging/v2/policysets/([^/]+)`, f.getPolicySet)
+	srv.Handle("PUT", `/imaging/v2/policysets/([^/]+)`, f.updatePolicySet)
+	srv.Handle("DELETE", `/imaging/v2/policysets/([^/]+)`, f.deletePolicySet)
+	srv.Handle("PUT", `/imaging/v2/network/(staging|production)/policies/([^/]+)`, f.putPolicy)
+	srv.Handle("GET", `/imaging/v2/network/(staging|production)/policies/([^/]+)`, f.getPolicy)
+	srv.Handle("DELETE", `/imaging/v2/network/(staging|production)/policies/([^/]+)`, f.deletePolicy)
+
+	return f
+}
+
+// scoped rejects requests without the Contract header
+func (f *fakeImaging) scoped(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("Contract") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func (f *fakeImaging) createPolicySet(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var ps policySet
+	if !mockapi.ReadJSON(w, r, &ps) {
+		return
+	}
+	if !f.scoped(w, r) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	f.nextID++
+	ps.ID = fmt.Sprintf("ps-%d", f.nextID)
+	f.policySets[ps.ID] = &ps
+	mockapi.WriteJSON(w, http.StatusCreated, ps)
+}
+
+func (f *fakeImaging) getPolicySet(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ps, ok := f.policySets[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, ps)
+}
+
+func (f *fakeImaging) updatePolicySet(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	ps, ok := f.policySets[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var req policySet
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	ps.Name, ps.Region = req.Name, req.Region
+	mockapi.WriteJSON(w, http.StatusOK, ps)
+}
+
+func (f *fakeImaging) deletePolicySet(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.policySets[params[0]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.policySets, params[0])
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeImaging) network(r *http.Request, network string) map[string]map[string]interface{} {
+	key := r.Header.Get("Policy-Set") + "/" + network
+	if f.policies[key] == nil {
+		f.policies[key] = make(map[string]map[string]interface{})
+	}
+	return f.policies[key]
+}
+
+// putPolicy stores the policy with the fields the API assigns
+func (f *fakeImaging) putPolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if !f.scoped(w, r) {
+		return
+	}
+	policy := make(map[string]interface{})
+	if !mockapi.ReadJSON(w, r, &policy) {
+		return
+	}
+	policies := f.network(r, params[0])
+	version := 1
+	if prev, ok := policies[params[1]]; ok {
+		version = prev["version"].(int) + 1
+	}
+	policy["id"], policy["version"], policy["dateCreated"] = params[1], version, "2021-01-01 00:00:00+0000"
+	policies[params[1]] = policy
+	mockapi.WriteJSON(w, http.StatusOK, map[string]interface{}{"operationPerformed": "UPDATED"})
+}
+
+func (f *fakeImaging) getPolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	policy, ok := f.network(r, params[0])[params[1]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, policy)
+}
+
+func (f *fakeImaging) deletePolicy(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	policies := f.network(r, params[0])
+	if _, ok := policies[params[1]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(policies, params[1])
+	mockapi.WriteJSON(w, http.StatusOK, map[string]interface{}{"operationPerformed": "DELETED"})
+}
+
+func TestResourceImagingPolicySet(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeImaging(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceImagingPolicySet().Schema, map[string]interface{}{
+		"name":        "images",
+		"region":      "EMEA",
+		"contract_id": "ctr_1-AB123",
+	})
+	require.NoError(t, resourceImagingPolicySetCreate(d, nil))
+	assert.Equal(t, "ps-1", d.Id())
+	assert.Equal(t, "IMAGE", d.Get("type"))
+
+	d.Set("region", "US")
+	require.NoError(t, resourceImagingPolicySetUpdate(d, nil))
+	assert.Equal(t, "US", fake.policySets["ps-1"].Region)
+
+	imported := schema.TestResourceDataRaw(t, resourceImagingPolicySet().Schema, map[string]interface{}{})
+	imported.SetId("ps-1:ctr_1-AB123")
+	_, err := resourceImagingPolicySetImport(imported, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ps-1", imported.Id())
+	assert.Equal(t, "1-AB123", imported.Get("contract_id"))
+	assert.Equal(t, "images", imported.Get("name"))
+
+	require.NoError(t, resourceImagingPolicySetDelete(d, nil))
+	assert.Empty(t, fake.policySets)
+
+	d.SetId("ps-1")
+	require.NoError(t, resourceImagingPolicySetRead(d, nil))
+	assert.Empty(t, d.Id())
+}
@@ -0,0 +1,118 @@
+package networklists
+
+import (
+	"fmt"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Network List lookup by name or type
+//
+// https://developer.akamai.com/api/cloud_security/network_lists/v2.html#getlists
+func dataSourceNetworkList() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceNetworkListRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{listTypeIP, listTypeGEO}, false),
+			},
+			"unique_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sync_point": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"list": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"network_lists": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"unique_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"element_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkListRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "dataSourceNetworkListRead")
+
+	name := d.Get("name").(string)
+	listType := d.Get("type").(string)
+
+	log.Debug(fmt.Sprintf("looking up network lists name [%s] type [%s]", name, listType))
+	lists, err := findNetworkLists(name, listType)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	if name != "" && len(lists) == 0 {
+		return fmt.Errorf("network list %q not found", name)
+	}
+
+	matches := make([]interface{}, 0, len(lists))
+	for _, list := range lists {
+		matches = append(matches, map[string]interface{}{
+			"unique_id":     list.UniqueID,
+			"name":          list.Name,
+			"type":          list.Type,
+			"element_count": list.ElementCount,
+		})
+	}
+	if err := d.Set("network_lists", matches); err != nil {
+		return err
+	}
+
+	// a single match is exposed directly, with its elements
+	if len(lists) != 1 {
+		d.SetId(fmt.Sprintf("%s:%s", name, listType))
+		return nil
+	}
+
+	list, err := getNetworkList(lists[0].UniqueID)
+	if err != nil {
+		return apiclient.Error(err)
+	}
+
+	d.SetId(list.UniqueID)
+	if err := d.Set("unique_id", list.UniqueID); err != nil {
+		return err
+	}
+	if err := d.Set("sync_point", list.SyncPoint); err != nil {
+		return err
+	}
+	return d.Set("list", list.List)
+}
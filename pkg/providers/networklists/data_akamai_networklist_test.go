@@ -0,0 +1,67 @@
+package networklists
+
+import (
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataSourceNetworkList(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeNetworkLists(srv)
+	api.Init(srv.Config())
+
+	fake.lists["1_BLOCK"] = &networkList{UniqueID: "1_BLOCK", Name: "block", Type: "IP", List: []string{"10.0.0.1"}, ElementCount: 1, SyncPoint: 4}
+	fake.lists["2_BLOCKGEO"] = &networkList{UniqueID: "2_BLOCKGEO", Name: "block-geo", Type: "GEO", List: []string{"KP"}, ElementCount: 1}
+	fake.lists["3_ALLOW"] = &networkList{UniqueID: "3_ALLOW", Name: "allow", Type: "IP", List: []string{}}
+
+	tests := map[string]struct {
+		config   map[string]interface{}
+		id       string
+		matches  int
+		elements int
+		wantErr  bool
+	}{
+		"by name": {
+			config:   map[string]interface{}{"name": "block"},
+			id:       "1_BLOCK",
+			matches:  1,
+			elements: 1,
+		},
+		"by type": {
+			config:  map[string]interface{}{"type": "IP"},
+			id:      ":IP",
+			matches: 2,
+		},
+		"by name and type": {
+			config:   map[string]interface{}{"name": "block-geo", "type": "GEO"},
+			id:       "2_BLOCKGEO",
+			matches:  1,
+			elements: 1,
+		},
+		"not found": {
+			config:  map[string]interface{}{"name": "missing"},
+			wantErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceNetworkList().Schema, test.config)
+			err := dataSourceNetworkListRead(d, nil)
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.id, d.Id())
+			assert.Equal(t, test.matches, d.Get("network_lists.#"))
+			assert.Equal(t, test.elements, d.Get("list").(*schema.Set).Len())
+		})
+	}
+}
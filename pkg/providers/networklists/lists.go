@@ -0,0 +1,180 @@
+package networklists
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/go-hclog"
+)
+
+const (
+	listTypeIP  = "IP"
+	listTypeGEO = "GEO"
+)
+
+var (
+	// api sends the requests to the Network Lists API
+	api apiclient.Client
+
+	// activationPollInterval and activationPollTimeout bound waitForActivation
+	activationPollInterval = 30 * time.Second
+	activationPollTimeout  = 90 * time.Minute
+)
+
+type (
+	networkList struct {
+		UniqueID     string   `json:"uniqueId,omitempty"`
+		Name         string   `json:"name"`
+		Type         string   `json:"type"`
+		Description  string   `json:"description,omitempty"`
+		List         []string `json:"list"`
+		ElementCount int      `json:"elementCount,omitempty"`
+		SyncPoint    int      `json:"syncPoint"`
+		ReadOnly     bool     `json:"readOnly,omitempty"`
+		Shared       bool     `json:"shared,omitempty"`
+		ContractID   string   `json:"contractId,omitempty"`
+		GroupID      int      `json:"groupId,omitempty"`
+	}
+
+	networkListsResponse struct {
+		NetworkLists []networkList `json:"networkLists"`
+	}
+
+	activationRequest struct {
+		Comments               string   `json:"comments,omitempty"`
+		NotificationRecipients []string `json:"notificationRecipients"`
+	}
+
+	activationStatus struct {
+		ActivationID     int    `json:"activationId"`
+		ActivationStatus string `json:"activationStatus"`
+		SyncPoint        int    `json:"syncPoint"`
+		UniqueID         string `json:"uniqueId"`
+		Network          string `json:"network,omitempty"`
+	}
+)
+
+func listLocation(id string) string {
+	return "/network-list/v2/network-lists/" + url.PathEscape(id)
+}
+
+// findNetworkLists searches network lists by name and type, either may be empty
+func findNetworkLists(name, listType string) ([]networkList, error) {
+	query := url.Values{}
+	if name != "" {
+		query.Set("search", name)
+	}
+	if listType != "" {
+		query.Set("listType", listType)
+	}
+	query.Set("includeElements", "false")
+
+	var res networkListsResponse
+	if err := api.DoRequest(http.MethodGet, "/network-list/v2/network-lists?"+query.Encode(), nil, &res); err != nil {
+		return nil, err
+	}
+
+	// search matches substrings of names and elements, only keep exact name matches
+	if name == "" {
+		return res.NetworkLists, nil
+	}
+	lists := make([]networkList, 0, len(res.NetworkLists))
+	for _, list := range res.NetworkLists {
+		if strings.EqualFold(list.Name, name) {
+			lists = append(lists, list)
+		}
+	}
+	return lists, nil
+}
+
+func createNetworkList(list *networkList) (*networkList, error) {
+	var created networkList
+	if err := api.DoRequest(http.MethodPost, "/network-list/v2/network-lists", list, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func getNetworkList(id string) (*networkList, error) {
+	var list networkList
+	if err := api.DoRequest(http.MethodGet, listLocation(id)+"?includeElements=true", nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// updateNetworkList replaces the list, its syncPoint must match the current one
+func updateNetworkList(list *networkList) (*networkList, error) {
+	var updated networkList
+	if err := api.DoRequest(http.MethodPut, listLocation(list.UniqueID), list, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// appendElements adds elements to the list, existing elements are kept
+func appendElements(id string, elements []string) (*networkList, error) {
+	var updated networkList
+	body := map[string][]string{"list": elements}
+	if err := api.DoRequest(http.MethodPost, listLocation(id)+"/append", body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// removeElement removes a single element from the list
+func removeElement(id, element string) error {
+	path := listLocation(id) + "/elements?element=" + url.QueryEscape(element)
+	return api.DoRequest(http.MethodDelete, path, nil, nil)
+}
+
+func deleteNetworkList(id string) error {
+	return api.DoRequest(http.MethodDelete, listLocation(id), nil, nil)
+}
+
+func activateNetworkList(id, network string, req *activationRequest) (*activationStatus, error) {
+	var status activationStatus
+	path := fmt.Sprintf("%s/environments/%s/activate", listLocation(id), network)
+	if err := api.DoRequest(http.MethodPost, path, req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+func getActivationStatus(id, network string) (*activationStatus, error) {
+	var status activationStatus
+	path := fmt.Sprintf("%s/environments/%s/status", listLocation(id), network)
+	if err := api.DoRequest(http.MethodGet, path, nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// waitForActivation polls the list status on the network until it is active or failed
+func waitForActivation(log hclog.Logger, id, network string) (*activationStatus, error) {
+	var status *activationStatus
+	err := apiclient.WaitForActivation(activationPollInterval, activationPollTimeout, func() (bool, error) {
+		var err error
+		if status, err = getActivationStatus(id, network); err != nil {
+			return false, err
+		}
+		log.Debug(fmt.Sprintf("list %s status on %s [%s]", id, network, status.ActivationStatus))
+
+		switch status.ActivationStatus {
+		case apiclient.StatusActive:
+			return true, nil
+		case apiclient.StatusFailed:
+			return true, fmt.Errorf("%w: network list %s on %s", apiclient.ErrActivationFailed, id, network)
+		}
+		return false, nil
+	})
+	if errors.Is(err, apiclient.ErrActivationTimeout) {
+		return status, fmt.Errorf("%w: network list %s on %s, last status %q", err, id, network, status.ActivationStatus)
+	}
+	return status, err
+}
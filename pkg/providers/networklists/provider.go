@@ -0,0 +1,143 @@
+package networklists
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"networklist_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("networklist_section"),
+			},
+			"networklist": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("networklist"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"akamai_networklist": dataSourceNetworkList(),
+		},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_networklist":             resourceNetworkList(),
+			"akamai_networklist_activations": resourceNetworkListActivations(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigNetworkListsService(d resourceData) (*edgegrid.Config, error) {
+	var NetworkListsConfig edgegrid.Config
+	if _, ok := d.GetOk("networklist"); ok {
+		log.Printf("[DEBUG] Setting networklist config via HCL")
+		config := d.Get("networklist").(set).List()[0].(map[string]interface{})
+
+		NetworkListsConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		api.Init(NetworkListsConfig)
+		return &NetworkListsConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("networklist_section"); ok && section != "default" {
+		NetworkListsConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		NetworkListsConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.Init(NetworkListsConfig)
+	return &NetworkListsConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "networklists"
+}
+
+// NetworkListsProviderVersion update version string anytime provider adds new features
+const NetworkListsProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return NetworkListsProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigNetworkListsService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
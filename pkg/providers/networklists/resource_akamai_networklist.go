@@ -0,0 +1,314 @@
+package networklists
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// modes controlling how the configured elements are applied to the list
+const (
+	modeAppend  = "APPEND"
+	modeReplace = "REPLACE"
+	modeRemove  = "REMOVE"
+)
+
+// Network List
+//
+// https://developer.akamai.com/api/cloud_security/network_lists/v2.html#networklist
+func resourceNetworkList() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkListCreate,
+		Read:   resourceNetworkListRead,
+		Update: resourceNetworkListUpdate,
+		Delete: resourceNetworkListDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceNetworkListImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{listTypeIP, listTypeGEO}, false),
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"list": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      modeReplace,
+				ValidateFunc: validation.StringInSlice([]string{modeAppend, modeReplace, modeRemove}, false),
+			},
+			"contract_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("ctr_"),
+			},
+			"group_id": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				ForceNew:  true,
+				StateFunc: trimPrefixStateFunc("grp_"),
+			},
+			"unique_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sync_point": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"element_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkListCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListCreate")
+
+	list := &networkList{
+		Name:        d.Get("name").(string),
+		Type:        d.Get("type").(string),
+		Description: d.Get("description").(string),
+		List:        []string{},
+		ContractID:  strings.TrimPrefix(d.Get("contract_id").(string), "ctr_"),
+	}
+	if group, ok := d.GetOk("group_id"); ok {
+		id, err := groupID(group.(string))
+		if err != nil {
+			return fmt.Errorf("invalid group_id %q", group)
+		}
+		list.GroupID = id
+	}
+	// elements of a REMOVE list are not expected to be in it
+	if d.Get("mode").(string) != modeRemove {
+		list.List = expandStringSet(d.Get("list"))
+	}
+
+	log.Debug(fmt.Sprintf("creating %s network list [%s]", list.Type, list.Name))
+	created, err := createNetworkList(list)
+	if err != nil {
+		log.Error(fmt.Sprintf("network list create failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(created.UniqueID)
+	return resourceNetworkListRead(d, meta)
+}
+
+func resourceNetworkListRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListRead")
+
+	log.Debug(fmt.Sprintf("reading network list [%s]", d.Id()))
+	list, err := getNetworkList(d.Id())
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("network list [%s] not found, removing from state", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	if err := d.Set("list", stateElements(d.Get("mode").(string), expandStringSet(d.Get("list")), list.List)); err != nil {
+		return err
+	}
+
+	return flattenNetworkList(d, list)
+}
+
+func resourceNetworkListUpdate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListUpdate")
+
+	id := d.Id()
+	mode := d.Get("mode").(string)
+
+	if mode == modeReplace || d.HasChanges("name", "description") {
+		current, err := getNetworkList(id)
+		if err != nil {
+			return apiclient.Error(err)
+		}
+
+		current.Name = d.Get("name").(string)
+		current.Description = d.Get("description").(string)
+		if mode == modeReplace {
+			current.List = expandStringSet(d.Get("list"))
+		}
+
+		log.Debug(fmt.Sprintf("updating network list [%s] at sync point %d", id, current.SyncPoint))
+		if _, err := updateNetworkList(current); err != nil {
+			log.Error(fmt.Sprintf("network list update failed: %s", err))
+			return apiclient.Error(err)
+		}
+	}
+
+	if d.HasChange("list") || d.HasChange("mode") {
+		elements := expandStringSet(d.Get("list"))
+		switch mode {
+		case modeAppend:
+			log.Debug(fmt.Sprintf("appending %d elements to network list [%s]", len(elements), id))
+			if _, err := appendElements(id, elements); err != nil {
+				return apiclient.Error(err)
+			}
+		case modeRemove:
+			current, err := getNetworkList(id)
+			if err != nil {
+				return apiclient.Error(err)
+			}
+			for _, element := range intersect(elements, current.List) {
+				log.Debug(fmt.Sprintf("removing [%s] from network list [%s]", element, id))
+				if err := removeElement(id, element); err != nil {
+					return apiclient.Error(err)
+				}
+			}
+		}
+	}
+
+	return resourceNetworkListRead(d, meta)
+}
+
+func resourceNetworkListDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListDelete")
+
+	log.Debug(fmt.Sprintf("deleting network list [%s]", d.Id()))
+	if err := deleteNetworkList(d.Id()); err != nil {
+		if apiclient.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		log.Error(fmt.Sprintf("network list delete failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Imported lists are managed in REPLACE mode
+func resourceNetworkListImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("mode", modeReplace); err != nil {
+		return nil, err
+	}
+	if err := resourceNetworkListRead(d, meta); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("network list not found")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// stateElements returns the elements to store in state for the mode. APPEND and REMOVE
+// only manage the configured elements, so drift is only reported for those.
+func stateElements(mode string, configured, remote []string) []string {
+	switch mode {
+	case modeAppend:
+		return intersect(configured, remote)
+	case modeRemove:
+		return difference(configured, remote)
+	default:
+		return remote
+	}
+}
+
+func flattenNetworkList(d *schema.ResourceData, list *networkList) error {
+	attrs := map[string]interface{}{
+		"name":          list.Name,
+		"type":          list.Type,
+		"description":   list.Description,
+		"unique_id":     list.UniqueID,
+		"sync_point":    list.SyncPoint,
+		"element_count": list.ElementCount,
+	}
+	if list.ContractID != "" {
+		attrs["contract_id"] = list.ContractID
+	}
+	if list.GroupID != 0 {
+		attrs["group_id"] = strconv.Itoa(list.GroupID)
+	}
+
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expandStringSet(v interface{}) []string {
+	out := make([]string, 0)
+	if s, ok := v.(*schema.Set); ok {
+		for _, e := range s.List() {
+			out = append(out, e.(string))
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// intersect returns the elements of a that are in b, elements are compared ignoring case
+func intersect(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, e := range b {
+		in[strings.ToUpper(e)] = true
+	}
+	out := make([]string, 0)
+	for _, e := range a {
+		if in[strings.ToUpper(e)] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// difference returns the elements of a that are not in b
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, e := range b {
+		in[strings.ToUpper(e)] = true
+	}
+	out := make([]string, 0)
+	for _, e := range a {
+		if !in[strings.ToUpper(e)] {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func trimPrefixStateFunc(prefix string) schema.SchemaStateFunc {
+	return func(v interface{}) string {
+		return strings.TrimPrefix(v.(string), prefix)
+	}
+}
+
+// groupID accepts both "grp_123" and "123"
+func groupID(v string) (int, error) {
+	return strconv.Atoi(strings.TrimPrefix(v, "grp_"))
+}
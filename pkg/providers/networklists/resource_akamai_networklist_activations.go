@@ -0,0 +1,156 @@
+package networklists
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Network List Activations
+//
+// https://developer.akamai.com/api/cloud_security/network_lists/v2.html#activations
+func resourceNetworkListActivations() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkListActivationsCreate,
+		Read:   resourceNetworkListActivationsRead,
+		Update: resourceNetworkListActivationsUpdate,
+		Delete: resourceNetworkListActivationsDelete,
+		Schema: map[string]*schema.Schema{
+			"network_list_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"network": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      apiclient.NetworkStaging,
+				StateFunc:    upperStateFunc,
+				ValidateFunc: validation.StringInSlice([]string{apiclient.NetworkStaging, apiclient.NetworkProduction}, true),
+			},
+			"notes": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Activated by Terraform",
+			},
+			"notification_emails": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"sync_point": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Sync point of the list to activate, changing it activates the list again",
+			},
+			"activate": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"activation_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceNetworkListActivationsCreate(d *schema.ResourceData, meta interface{}) error {
+	id := d.Get("network_list_id").(string)
+	network := strings.ToUpper(d.Get("network").(string))
+
+	d.SetId(fmt.Sprintf("%s:%s", id, network))
+	if err := activateList(d); err != nil {
+		d.SetId("")
+		return err
+	}
+	return resourceNetworkListActivationsRead(d, meta)
+}
+
+func resourceNetworkListActivationsRead(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListActivationsRead")
+
+	id := d.Get("network_list_id").(string)
+	network := strings.ToUpper(d.Get("network").(string))
+
+	status, err := getActivationStatus(id, network)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			log.Warn(fmt.Sprintf("network list [%s] not found, removing activation from state", id))
+			d.SetId("")
+			return nil
+		}
+		return apiclient.Error(err)
+	}
+
+	return d.Set("status", status.ActivationStatus)
+}
+
+func resourceNetworkListActivationsUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChanges("sync_point", "activate") {
+		if err := activateList(d); err != nil {
+			return err
+		}
+	}
+	return resourceNetworkListActivationsRead(d, meta)
+}
+
+// Network lists can't be deactivated, deleting only removes the activation from state
+func resourceNetworkListActivationsDelete(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "resourceNetworkListActivationsDelete")
+
+	log.Info(fmt.Sprintf("network list [%s] stays active, removing activation from state", d.Id()))
+	d.SetId("")
+	return nil
+}
+
+// activateList activates the list on the network and waits for the activation to complete
+func activateList(d *schema.ResourceData) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("NETWORKLISTS", "activateList")
+
+	if !d.Get("activate").(bool) {
+		return nil
+	}
+
+	id := d.Get("network_list_id").(string)
+	network := strings.ToUpper(d.Get("network").(string))
+	req := &activationRequest{
+		Comments:               d.Get("notes").(string),
+		NotificationRecipients: expandStringSet(d.Get("notification_emails")),
+	}
+
+	log.Debug(fmt.Sprintf("activating network list [%s] on %s", id, network))
+	activation, err := activateNetworkList(id, network, req)
+	if err != nil {
+		log.Error(fmt.Sprintf("network list activation failed: %s", err))
+		return apiclient.Error(err)
+	}
+	if err := d.Set("activation_id", activation.ActivationID); err != nil {
+		return err
+	}
+
+	status, err := waitForActivation(log, id, network)
+	if err != nil {
+		return err
+	}
+	log.Info(fmt.Sprintf("network list [%s] %s on %s", id, status.ActivationStatus, network))
+
+	return d.Set("status", status.ActivationStatus)
+}
+
+func upperStateFunc(v interface{}) string {
+	return strings.ToUpper(v.(string))
+}
@@ -0,0 +1,71 @@
+package networklists
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceNetworkListActivations(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeNetworkLists(srv)
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	fake.lists["1_SHARED"] = &networkList{UniqueID: "1_SHARED", Name: "shared", Type: "IP", List: []string{"10.0.0.1"}}
+
+	d := schema.TestResourceDataRaw(t, resourceNetworkListActivations().Schema, map[string]interface{}{
+		"network_list_id":     "1_SHARED",
+		"network":             "production",
+		"notification_emails": []interface{}{"noreply@example.com"},
+	})
+	require.NoError(t, resourceNetworkListActivationsCreate(d, nil))
+
+	assert.Equal(t, "1_SHARED:PRODUCTION", d.Id())
+	assert.Equal(t, apiclient.StatusActive, d.Get("status"))
+	assert.Equal(t, 1, d.Get("activation_id"))
+	assert.Equal(t, 1, fake.activations)
+
+	// a new sync point activates the list again
+	d.Set("sync_point", 3)
+	require.NoError(t, resourceNetworkListActivationsUpdate(d, nil))
+	assert.Equal(t, 2, fake.activations)
+
+	require.NoError(t, resourceNetworkListActivationsDelete(d, nil))
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceNetworkListActivations_failed(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	api.Init(srv.Config())
+	activationPollInterval = 0
+
+	srv.Handle("POST", `/network-list/v2/network-lists/([^/]+)/environments/(STAGING|PRODUCTION)/activate`,
+		func(w http.ResponseWriter, r *http.Request, params []string) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"activationId": 7, "activationStatus": "PENDING_ACTIVATION"}`))
+		})
+	srv.Handle("GET", `/network-list/v2/network-lists/([^/]+)/environments/(STAGING|PRODUCTION)/status`,
+		func(w http.ResponseWriter, r *http.Request, params []string) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"activationStatus": "FAILED"}`))
+		})
+
+	d := schema.TestResourceDataRaw(t, resourceNetworkListActivations().Schema, map[string]interface{}{
+		"network_list_id":     "1_SHARED",
+		"notification_emails": []interface{}{"noreply@example.com"},
+	})
+	err := resourceNetworkListActivationsCreate(d, nil)
+	assert.True(t, errors.Is(err, apiclient.ErrActivationFailed), "unexpected error: %v", err)
+	assert.Equal(t, "", d.Id())
+}
@@ -0,0 +1,358 @@
+package networklists
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiNetworkList_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAkamaiNetworkListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAkamaiNetworkListConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akamai_networklist.test", "unique_id"),
+					resource.TestCheckResourceAttr("akamai_networklist.test", "list.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAkamaiNetworkListConfig = `
+provider "akamai" {
+  networklist_section = "networklist"
+}
+
+resource "akamai_networklist" "test" {
+  name = "terraform-test"
+  type = "IP"
+  description = "Terraform acceptance test"
+  list = ["10.1.1.1", "10.2.0.0/16"]
+}
+`
+
+func testAccCheckAkamaiNetworkListDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "akamai_networklist" {
+			continue
+		}
+
+		if _, err := getNetworkList(rs.Primary.ID); err == nil {
+			return fmt.Errorf("network list %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+// fakeNetworkLists serves the Network Lists endpoints used by the provider from the mock server
+type fakeNetworkLists struct {
+	lock        sync.Mutex
+	lists       map[string]*networkList
+	status      map[string]string
+	nextID      int
+	activations int
+}
+
+func newFakeNetworkLists(srv *mockapi.Server) *fakeNetworkLists {
+	f := &fakeNetworkLists{
+		lists:  make(map[string]*networkList),
+		status: make(map[string]string),
+		nextID: 1000,
+	}
+
+	srv.Handle("GET", `/network-list/v2/network-lists`, f.search)
+	srv.Handle("POST", `/network-list/v2/network-lists`, f.create)
+	srv.Handle("GET", `/network-list/v2/network-lists/([^/]+)`, f.get)
+	srv.Handle("PUT", `/network-list/v2/network-lists/([^/]+)`, f.update)
+	srv.Handle("DELETE", `/network-list/v2/network-lists/([^/]+)`, f.delete)
+	srv.Handle("POST", `/network-list/v2/network-lists/([^/]+)/append`, f.append)
+	srv.Handle("DELETE", `/network-list/v2/network-lists/([^/]+)/elements`, f.remove)
+	srv.Handle("POST", `/network-list/v2/network-lists/([^/]+)/environments/(STAGING|PRODUCTION)/activate`, f.activate)
+	srv.Handle("GET", `/network-list/v2/network-lists/([^/]+)/environments/(STAGING|PRODUCTION)/status`, f.activationStatus)
+
+	return f
+}
+
+func (f *fakeNetworkLists) save(list *networkList) *networkList {
+	sort.Strings(list.List)
+	list.ElementCount = len(list.List)
+	list.SyncPoint++
+	f.lists[list.UniqueID] = list
+	return list
+}
+
+func (f *fakeNetworkLists) search(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	search := strings.ToLower(r.URL.Query().Get("search"))
+	listType := r.URL.Query().Get("listType")
+
+	lists := make([]networkList, 0)
+	for _, list := range f.lists {
+		if (listType == "" || list.Type == listType) && strings.Contains(strings.ToLower(list.Name), search) {
+			lists = append(lists, *list)
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].UniqueID < lists[j].UniqueID })
+	mockapi.WriteJSON(w, http.StatusOK, networkListsResponse{NetworkLists: lists})
+}
+
+func (f *fakeNetworkLists) create(w http.ResponseWriter, r *http.Request, _ []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var list networkList
+	if !mockapi.ReadJSON(w, r, &list) {
+		return
+	}
+	if list.Name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.nextID++
+	list.UniqueID = fmt.Sprintf("%d_%s", f.nextID, strings.ToUpper(list.Name))
+	list.SyncPoint = -1
+	mockapi.WriteJSON(w, http.StatusCreated, f.save(&list))
+}
+
+func (f *fakeNetworkLists) get(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	list, ok := f.lists[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	mockapi.WriteJSON(w, http.StatusOK, list)
+}
+
+func (f *fakeNetworkLists) update(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	current, ok := f.lists[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var list networkList
+	if !mockapi.ReadJSON(w, r, &list) {
+		return
+	}
+	if list.SyncPoint != current.SyncPoint {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	list.UniqueID = current.UniqueID
+	mockapi.WriteJSON(w, http.StatusOK, f.save(&list))
+}
+
+func (f *fakeNetworkLists) delete(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.lists[params[0]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	delete(f.lists, params[0])
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeNetworkLists) append(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	list, ok := f.lists[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var body networkList
+	if !mockapi.ReadJSON(w, r, &body) {
+		return
+	}
+	list.List = append(list.List, difference(body.List, list.List)...)
+	mockapi.WriteJSON(w, http.StatusOK, f.save(list))
+}
+
+func (f *fakeNetworkLists) remove(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	list, ok := f.lists[params[0]]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	list.List = difference(list.List, []string{r.URL.Query().Get("element")})
+	mockapi.WriteJSON(w, http.StatusOK, f.save(list))
+}
+
+func (f *fakeNetworkLists) activate(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var req activationRequest
+	if !mockapi.ReadJSON(w, r, &req) {
+		return
+	}
+	if len(req.NotificationRecipients) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if _, ok := f.lists[params[0]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	f.activations++
+	f.status[params[0]+":"+params[1]] = "PENDING_ACTIVATION"
+	mockapi.WriteJSON(w, http.StatusOK, activationStatus{
+		ActivationID:     f.activations,
+		ActivationStatus: "PENDING_ACTIVATION",
+		UniqueID:         params[0],
+	})
+}
+
+// activationStatus reports a pending activation once before it becomes active
+func (f *fakeNetworkLists) activationStatus(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if _, ok := f.lists[params[0]]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	key := params[0] + ":" + params[1]
+	status, ok := f.status[key]
+	switch {
+	case !ok:
+		status = "INACTIVE"
+	case status == "PENDING_ACTIVATION":
+		f.status[key] = apiclient.StatusActive
+	}
+	mockapi.WriteJSON(w, http.StatusOK, activationStatus{ActivationStatus: status, UniqueID: params[0]})
+}
+
+func TestResourceNetworkList_replace(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeNetworkLists(srv)
+	api.Init(srv.Config())
+
+	d := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, map[string]interface{}{
+		"name":        "blocklist",
+		"type":        "IP",
+		"description": "blocked addresses",
+		"list":        []interface{}{"10.1.1.1", "10.2.0.0/16"},
+		"contract_id": "ctr_1-MOCK",
+		"group_id":    "grp_1",
+	})
+	require.NoError(t, resourceNetworkListCreate(d, nil))
+
+	assert.Equal(t, "1001_BLOCKLIST", d.Id())
+	assert.Equal(t, "1-MOCK", d.Get("contract_id"))
+	assert.Equal(t, "1", d.Get("group_id"))
+	assert.Equal(t, 2, d.Get("element_count"))
+	assert.Equal(t, 0, d.Get("sync_point"))
+
+	// elements added outside of terraform are reported as drift
+	fake.lists[d.Id()].List = append(fake.lists[d.Id()].List, "10.3.3.3")
+	require.NoError(t, resourceNetworkListRead(d, nil))
+	assert.Equal(t, 3, d.Get("list").(*schema.Set).Len())
+
+	d.Set("list", []interface{}{"10.1.1.1"})
+	require.NoError(t, resourceNetworkListUpdate(d, nil))
+	assert.Equal(t, []string{"10.1.1.1"}, fake.lists[d.Id()].List)
+	assert.Equal(t, 1, d.Get("sync_point"))
+
+	require.NoError(t, resourceNetworkListDelete(d, nil))
+	assert.Empty(t, fake.lists)
+}
+
+func TestResourceNetworkList_appendRemove(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeNetworkLists(srv)
+	api.Init(srv.Config())
+
+	fake.lists["1_SHARED"] = &networkList{UniqueID: "1_SHARED", Name: "shared", Type: "GEO", List: []string{"CA", "US"}}
+
+	appended := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, map[string]interface{}{
+		"name": "shared",
+		"type": "GEO",
+		"mode": modeAppend,
+		"list": []interface{}{"FR"},
+	})
+	appended.SetId("1_SHARED")
+	require.NoError(t, resourceNetworkListRead(appended, nil))
+	assert.Equal(t, 0, appended.Get("list").(*schema.Set).Len(), "missing elements must show as drift")
+
+	appended.Set("list", []interface{}{"FR"})
+	require.NoError(t, resourceNetworkListUpdate(appended, nil))
+	assert.Equal(t, []string{"CA", "FR", "US"}, fake.lists["1_SHARED"].List)
+	assert.Equal(t, []interface{}{"FR"}, appended.Get("list").(*schema.Set).List())
+
+	removed := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, map[string]interface{}{
+		"name": "shared",
+		"type": "GEO",
+		"mode": modeRemove,
+		"list": []interface{}{"US", "DE"},
+	})
+	removed.SetId("1_SHARED")
+	require.NoError(t, resourceNetworkListRead(removed, nil))
+	assert.Equal(t, []interface{}{"DE"}, removed.Get("list").(*schema.Set).List())
+
+	removed.Set("list", []interface{}{"US", "DE"})
+	require.NoError(t, resourceNetworkListUpdate(removed, nil))
+	assert.Equal(t, []string{"CA", "FR"}, fake.lists["1_SHARED"].List)
+}
+
+func TestResourceNetworkList_import(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeNetworkLists(srv)
+	api.Init(srv.Config())
+
+	fake.lists["1_SHARED"] = &networkList{UniqueID: "1_SHARED", Name: "shared", Type: "IP", List: []string{"10.0.0.1"}}
+
+	d := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, map[string]interface{}{})
+	d.SetId("1_SHARED")
+	out, err := resourceNetworkListImport(d, nil)
+	require.NoError(t, err)
+	require.Len(t, out, 1)
+	assert.Equal(t, modeReplace, d.Get("mode"))
+	assert.Equal(t, "shared", d.Get("name"))
+	assert.Equal(t, 1, d.Get("list").(*schema.Set).Len())
+
+	missing := schema.TestResourceDataRaw(t, resourceNetworkList().Schema, map[string]interface{}{})
+	missing.SetId("404_MISSING")
+	_, err = resourceNetworkListImport(missing, nil)
+	assert.Error(t, err)
+}
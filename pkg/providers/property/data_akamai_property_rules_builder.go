@@ -0,0 +1,451 @@
+package property
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// A rule of a property rule tree, with typed behaviors and criteria generated from the
+// bundled schema of its rule format, a subset of the PAPI schema. Behaviors and criteria
+// outside the subset are set as JSON. Child rules are the json of other rules builders, so
+// a tree is a family of data sources. The json of the default rule is the rules JSON of
+// akamai_property.
+func dataSourcePropertyRulesBuilder() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourcePropertyRulesBuilderRead,
+		Schema: rulesBuilderSchema(),
+	}
+}
+
+// rulesBuilderBlock returns the block of a rule format, e.g. rules_v2020_03_04
+func rulesBuilderBlock(format string) string {
+	return "rules_" + strings.Replace(format, "-", "_", -1)
+}
+
+func rulesBuilderSchema() map[string]*schema.Schema {
+	formats := make([]string, 0, len(bundledRuleFormats))
+	for name := range bundledRuleFormats {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	blocks := make([]string, 0, len(formats))
+	for _, name := range formats {
+		blocks = append(blocks, rulesBuilderBlock(name))
+	}
+
+	s := map[string]*schema.Schema{
+		"rule_format": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"json": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The rule in canonical JSON, or the rules JSON of the tree for the default rule",
+		},
+	}
+	for _, name := range formats {
+		f, err := getBundledRuleFormat(name)
+		if err != nil {
+			log.Printf("[ERROR] %s", err)
+			continue
+		}
+		s[rulesBuilderBlock(name)] = &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			MaxItems:     1,
+			ExactlyOneOf: blocks,
+			Elem:         &schema.Resource{Schema: ruleBuilderSchema(f)},
+		}
+	}
+	return s
+}
+
+func ruleBuilderSchema(f *ruleFormat) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"comments": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"criteria_must_satisfy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "all",
+			ValidateFunc: validation.StringInSlice([]string{"all", "any"}, false),
+		},
+		"is_secure": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Whether the property serves HTTPS traffic, only on the default rule",
+		},
+		"variable": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The property variables, only on the default rule",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^PMUSER_[A-Z0-9_]+$`), "must start with PMUSER_"),
+					},
+					"value": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"hidden": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+					"sensitive": {
+						Type:     schema.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"behavior": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The behaviors of the rule in order, each setting exactly one behavior block or json",
+			Elem:        &schema.Resource{Schema: catalogSchema(f.Behaviors)},
+		},
+		"criterion": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The criteria of the rule, each setting exactly one criterion block or json",
+			Elem:        &schema.Resource{Schema: catalogSchema(f.Criteria)},
+		},
+		"children": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The json of the child rules builders",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsJSON,
+			},
+		},
+	}
+}
+
+// catalogSchema returns one block per behavior or criterion, and the json of the ones
+// outside of the bundled schema
+func catalogSchema(catalog map[string]*jsonSchema) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema, len(catalog)+1)
+	s["json"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringIsJSON,
+		Description:  "The behavior or criterion in the JSON format of PAPI, with its name and options",
+	}
+	for name, options := range catalog {
+		s[snakeCase(name)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: optionsSchema(options)},
+		}
+	}
+	return s
+}
+
+func optionsSchema(s *jsonSchema) map[string]*schema.Schema {
+	attrs := make(map[string]*schema.Schema, len(s.Properties))
+	for name, option := range s.Properties {
+		attr := optionSchema(option)
+		if s.isRequired(name) {
+			attr.Required = true
+		} else {
+			attr.Optional = true
+		}
+		attrs[snakeCase(name)] = attr
+	}
+	return attrs
+}
+
+// optionSchema returns the attribute of an option, typed and validated from its JSON schema.
+// Options of unknown type are JSON strings.
+func optionSchema(s *jsonSchema) *schema.Schema {
+	switch s.mainType() {
+	case "string":
+		return &schema.Schema{Type: schema.TypeString, ValidateFunc: stringOptionValidator(s)}
+	case "boolean":
+		return &schema.Schema{Type: schema.TypeBool}
+	case "integer":
+		return &schema.Schema{Type: schema.TypeInt, ValidateFunc: intOptionValidator(s)}
+	case "number":
+		return &schema.Schema{Type: schema.TypeFloat}
+	case "array":
+		attr := &schema.Schema{Type: schema.TypeList}
+		switch {
+		case s.Items == nil:
+			attr.Elem = &schema.Schema{Type: schema.TypeString}
+		case s.Items.mainType() == "object":
+			attr.Elem = &schema.Resource{Schema: optionsSchema(s.Items)}
+		default:
+			attr.Elem = optionSchema(s.Items)
+		}
+		return attr
+	case "object":
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+			Elem:     &schema.Resource{Schema: optionsSchema(s)},
+		}
+	}
+	return &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringIsJSON}
+}
+
+func stringOptionValidator(s *jsonSchema) schema.SchemaValidateFunc {
+	var validators []schema.SchemaValidateFunc
+	if enum := s.enumStrings(); len(enum) > 0 {
+		validators = append(validators, validation.StringInSlice(enum, false))
+	}
+	if s.Pattern != "" {
+		if re, err := regexp.Compile(s.Pattern); err == nil {
+			validators = append(validators, validation.StringMatch(re, fmt.Sprintf("must match %s", s.Pattern)))
+		}
+	}
+	switch len(validators) {
+	case 0:
+		return nil
+	case 1:
+		return validators[0]
+	}
+	return validation.All(validators...)
+}
+
+func intOptionValidator(s *jsonSchema) schema.SchemaValidateFunc {
+	switch {
+	case s.Minimum != nil && s.Maximum != nil:
+		return validation.IntBetween(int(*s.Minimum), int(*s.Maximum))
+	case s.Minimum != nil:
+		return validation.IntAtLeast(int(*s.Minimum))
+	case s.Maximum != nil:
+		return validation.IntAtMost(int(*s.Maximum))
+	}
+	return nil
+}
+
+func dataSourcePropertyRulesBuilderRead(d *schema.ResourceData, meta interface{}) error {
+	for name := range bundledRuleFormats {
+		block := rulesBuilderBlock(name)
+		if list := d.Get(block).([]interface{}); len(list) == 0 {
+			continue
+		}
+		f, err := getBundledRuleFormat(name)
+		if err != nil {
+			return err
+		}
+
+		rule, err := readBuilderRule(d, block+".0", f)
+		if err != nil {
+			return err
+		}
+		var tree interface{} = rule
+		if rule["name"] == "default" {
+			tree = map[string]interface{}{"rules": rule}
+		}
+		data, err := json.Marshal(tree)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("[DEBUG] [PAPI] Built rule %s with rule format %s", rule["name"], name)
+		d.SetId(tools.GetSHAString(string(data)))
+		d.Set("rule_format", name)
+		return d.Set("json", string(data))
+	}
+	return fmt.Errorf("one of the rules blocks must be set")
+}
+
+// readBuilderRule returns the rule of a rules block in the JSON format of PAPI
+func readBuilderRule(d *schema.ResourceData, prefix string, f *ruleFormat) (map[string]interface{}, error) {
+	name := d.Get(prefix + ".name").(string)
+	rule := map[string]interface{}{
+		"name":      name,
+		"behaviors": make([]interface{}, 0),
+		"children":  make([]interface{}, 0),
+	}
+	if comments := d.Get(prefix + ".comments").(string); comments != "" {
+		rule["comments"] = comments
+	}
+
+	behaviors, err := readCatalogEntries(d, prefix+".behavior", f.Behaviors)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %s", name, err)
+	}
+	rule["behaviors"] = behaviors
+
+	criteria, err := readCatalogEntries(d, prefix+".criterion", f.Criteria)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %s", name, err)
+	}
+
+	variables := make([]interface{}, 0)
+	for _, v := range d.Get(prefix + ".variable").([]interface{}) {
+		v := v.(map[string]interface{})
+		variables = append(variables, map[string]interface{}{
+			"name":        v["name"],
+			"value":       v["value"],
+			"description": v["description"],
+			"hidden":      v["hidden"],
+			"sensitive":   v["sensitive"],
+		})
+	}
+
+	if name == "default" {
+		if len(criteria) > 0 {
+			return nil, fmt.Errorf("rule %q: the default rule can not have criteria", name)
+		}
+		rule["options"] = map[string]interface{}{"is_secure": d.Get(prefix + ".is_secure").(bool)}
+		if len(variables) > 0 {
+			rule["variables"] = variables
+		}
+	} else {
+		if _, ok := d.GetOk(prefix + ".is_secure"); ok {
+			return nil, fmt.Errorf("rule %q: is_secure is only valid on the default rule", name)
+		}
+		if len(variables) > 0 {
+			return nil, fmt.Errorf("rule %q: variables are only valid on the default rule", name)
+		}
+		rule["criteria"] = criteria
+		rule["criteriaMustSatisfy"] = d.Get(prefix + ".criteria_must_satisfy").(string)
+	}
+
+	for i, v := range d.Get(prefix + ".children").([]interface{}) {
+		dec := json.NewDecoder(bytes.NewBufferString(v.(string)))
+		dec.UseNumber()
+		var child map[string]interface{}
+		if err := dec.Decode(&child); err != nil {
+			return nil, fmt.Errorf("rule %q: child %d: %s", name, i, err)
+		}
+		if _, ok := child["rules"]; ok {
+			return nil, fmt.Errorf("rule %q: child %d is a default rule", name, i)
+		}
+		if _, ok := child["name"]; !ok {
+			return nil, fmt.Errorf("rule %q: child %d is not a rule", name, i)
+		}
+		rule["children"] = append(rule["children"].([]interface{}), child)
+	}
+
+	return rule, nil
+}
+
+// readCatalogEntries returns the behaviors or criteria of a list of blocks, each setting
+// exactly one behavior or criterion, as a block or as json
+func readCatalogEntries(d *schema.ResourceData, prefix string, catalog map[string]*jsonSchema) ([]interface{}, error) {
+	names := make([]string, 0, len(catalog))
+	for name := range catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	kind := strings.TrimPrefix(prefix[strings.LastIndex(prefix, "."):], ".")
+	entries := make([]interface{}, 0)
+	for i := range d.Get(prefix).([]interface{}) {
+		var set []string
+		for _, name := range names {
+			if list := d.Get(fmt.Sprintf("%s.%d.%s", prefix, i, snakeCase(name))).([]interface{}); len(list) > 0 {
+				set = append(set, name)
+			}
+		}
+		raw := d.Get(fmt.Sprintf("%s.%d.json", prefix, i)).(string)
+		if raw != "" {
+			set = append(set, "json")
+		}
+		if len(set) != 1 {
+			return nil, fmt.Errorf("%s %d must set exactly one %s or json, got %d", kind, i, kind, len(set))
+		}
+
+		if raw != "" {
+			entry, err := readCatalogJSON(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s %d: %s", kind, i, err)
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		name := set[0]
+		entries = append(entries, map[string]interface{}{
+			"name":    name,
+			"options": readOptions(d, fmt.Sprintf("%s.%d.%s.0", prefix, i, snakeCase(name)), catalog[name]),
+		})
+	}
+	return entries, nil
+}
+
+// readCatalogJSON returns the behavior or criterion of a json attribute, which must have a
+// name and options
+func readCatalogJSON(raw string) (map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewBufferString(raw))
+	dec.UseNumber()
+	var entry map[string]interface{}
+	if err := dec.Decode(&entry); err != nil {
+		return nil, err
+	}
+	if name, ok := entry["name"].(string); !ok || name == "" {
+		return nil, fmt.Errorf("json has no name")
+	}
+	if _, ok := entry["options"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("json of %s has no options", entry["name"])
+	}
+	return entry, nil
+}
+
+// readOptions returns the options set in a block, by their PAPI name
+func readOptions(d *schema.ResourceData, prefix string, s *jsonSchema) map[string]interface{} {
+	options := make(map[string]interface{})
+	for name, option := range s.Properties {
+		key := prefix + "." + snakeCase(name)
+		switch option.mainType() {
+		case "object":
+			if list := d.Get(key).([]interface{}); len(list) > 0 {
+				options[name] = readOptions(d, key+".0", option)
+			}
+		case "array":
+			list, ok := d.GetOk(key)
+			if !ok {
+				continue
+			}
+			if option.Items != nil && option.Items.mainType() == "object" {
+				values := make([]interface{}, 0)
+				for i := range list.([]interface{}) {
+					values = append(values, readOptions(d, fmt.Sprintf("%s.%d", key, i), option.Items))
+				}
+				options[name] = values
+			} else {
+				options[name] = list
+			}
+		case "string", "boolean", "integer", "number":
+			if v, ok := d.GetOkExists(key); ok {
+				options[name] = v
+			}
+		default:
+			if v, ok := d.GetOk(key); ok {
+				var value interface{}
+				if err := json.Unmarshal([]byte(v.(string)), &value); err == nil {
+					options[name] = value
+				}
+			}
+		}
+	}
+	return options
+}
@@ -0,0 +1,156 @@
+package property
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for name, expected := range map[string]string{
+		"enableTrueClientIp": "enable_true_client_ip",
+		"httpPort":           "http_port",
+		"cpCode":             "cp_code",
+		"ssmap":              "ssmap",
+		"is_secure":          "is_secure",
+		"g2oToken":           "g2o_token",
+		"forceSSLForward":    "force_ssl_forward",
+	} {
+		assert.Equal(t, expected, snakeCase(name), name)
+	}
+}
+
+func TestBundledRuleFormats(t *testing.T) {
+	for name := range bundledRuleFormats {
+		f, err := getBundledRuleFormat(name)
+		require.NoError(t, err, name)
+		assert.Contains(t, f.Behaviors, "origin")
+		assert.Contains(t, f.Criteria, "path")
+	}
+	require.NoError(t, schema.InternalMap(rulesBuilderSchema()).InternalValidate(nil))
+}
+
+func builderRule(t *testing.T, rule map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, dataSourcePropertyRulesBuilder().Schema, map[string]interface{}{
+		"rules_v2020_03_04": []interface{}{rule},
+	})
+}
+
+func TestDataSourcePropertyRulesBuilder(t *testing.T) {
+	child := builderRule(t, map[string]interface{}{
+		"name":     "Static",
+		"comments": "Cache static content",
+		"criterion": []interface{}{
+			map[string]interface{}{"file_extension": []interface{}{map[string]interface{}{
+				"match_operator": "IS_ONE_OF",
+				"values":         []interface{}{"css", "js"},
+			}}},
+		},
+		"behavior": []interface{}{
+			map[string]interface{}{"caching": []interface{}{map[string]interface{}{
+				"behavior": "MAX_AGE",
+				"ttl":      "1d",
+			}}},
+		},
+	})
+	require.NoError(t, dataSourcePropertyRulesBuilderRead(child, nil))
+	assert.Equal(t, "v2020-03-04", child.Get("rule_format"))
+	assert.JSONEq(t, `{
+		"name": "Static",
+		"comments": "Cache static content",
+		"criteriaMustSatisfy": "all",
+		"criteria": [{"name": "fileExtension", "options": {"matchOperator": "IS_ONE_OF", "values": ["css", "js"]}}],
+		"behaviors": [{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}}],
+		"children": []
+	}`, child.Get("json").(string))
+
+	d := builderRule(t, map[string]interface{}{
+		"name":      "default",
+		"is_secure": true,
+		"variable": []interface{}{
+			map[string]interface{}{"name": "PMUSER_ORIGIN", "value": "origin.example.com"},
+		},
+		"behavior": []interface{}{
+			map[string]interface{}{"origin": []interface{}{map[string]interface{}{
+				"origin_type":           "CUSTOMER",
+				"hostname":              "origin.example.com",
+				"http_port":             80,
+				"enable_true_client_ip": false,
+			}}},
+			map[string]interface{}{"cp_code": []interface{}{map[string]interface{}{
+				"value": []interface{}{map[string]interface{}{"id": 12345}},
+			}}},
+			map[string]interface{}{"json": `{"name": "http2", "options": {"enabled": ""}}`},
+		},
+		"children": []interface{}{child.Get("json")},
+	})
+	require.NoError(t, dataSourcePropertyRulesBuilderRead(d, nil))
+
+	var tree struct {
+		Rules map[string]interface{} `json:"rules"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(d.Get("json").(string)), &tree))
+	assert.Equal(t, map[string]interface{}{"is_secure": true}, tree.Rules["options"])
+	assert.NotContains(t, tree.Rules, "criteria")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "origin", "options": map[string]interface{}{
+			"originType": "CUSTOMER", "hostname": "origin.example.com", "httpPort": float64(80), "enableTrueClientIp": false,
+		}},
+		map[string]interface{}{"name": "cpCode", "options": map[string]interface{}{
+			"value": map[string]interface{}{"id": float64(12345)},
+		}},
+		map[string]interface{}{"name": "http2", "options": map[string]interface{}{"enabled": ""}},
+	}, tree.Rules["behaviors"])
+	assert.Equal(t, "PMUSER_ORIGIN", tree.Rules["variables"].([]interface{})[0].(map[string]interface{})["name"])
+	assert.Equal(t, "Static", tree.Rules["children"].([]interface{})[0].(map[string]interface{})["name"])
+}
+
+func TestDataSourcePropertyRulesBuilder_errors(t *testing.T) {
+	for name, rule := range map[string]map[string]interface{}{
+		"two behaviors in a block": {
+			"name": "default",
+			"behavior": []interface{}{map[string]interface{}{
+				"prefetch":     []interface{}{map[string]interface{}{"enabled": true}},
+				"prefetchable": []interface{}{map[string]interface{}{"enabled": true}},
+			}},
+		},
+		"criteria on the default rule": {
+			"name": "default",
+			"criterion": []interface{}{map[string]interface{}{
+				"request_protocol": []interface{}{map[string]interface{}{"value": "HTTPS"}},
+			}},
+		},
+		"is_secure on a child rule": {
+			"name":      "child",
+			"is_secure": true,
+		},
+		"a behavior block and json": {
+			"name": "default",
+			"behavior": []interface{}{map[string]interface{}{
+				"prefetch": []interface{}{map[string]interface{}{"enabled": true}},
+				"json":     `{"name": "http2", "options": {}}`,
+			}},
+		},
+		"json without options": {
+			"name":     "default",
+			"behavior": []interface{}{map[string]interface{}{"json": `{"name": "http2"}`}},
+		},
+		"default rule as a child": {
+			"name":     "child",
+			"children": []interface{}{`{"rules": {"name": "default"}}`},
+		},
+	} {
+		assert.Error(t, dataSourcePropertyRulesBuilderRead(builderRule(t, rule), nil), name)
+	}
+
+	// options are validated against the rule format
+	attrs := rulesBuilderSchema()["rules_v2020_03_04"].Elem.(*schema.Resource).Schema["behavior"].Elem.(*schema.Resource).Schema["origin"].Elem.(*schema.Resource).Schema
+	_, errs := attrs["origin_type"].ValidateFunc("NOT_A_TYPE", "origin_type")
+	assert.NotEmpty(t, errs)
+	_, errs = attrs["http_port"].ValidateFunc(70000, "http_port")
+	assert.NotEmpty(t, errs)
+	assert.Equal(t, schema.TypeBool, attrs["compress"].Type)
+}
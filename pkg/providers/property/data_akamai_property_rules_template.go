@@ -0,0 +1,262 @@
+package property
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// includePrefix starts a JSON string replaced by the JSON of a file, relative to the
+	// directory of the including file
+	includePrefix = "#include:"
+	// variablePrefix starts a reference to a template variable, ended by }
+	variablePrefix = "${env."
+)
+
+// Rules JSON composed from a main file and the snippet files it includes. Every file is a JSON
+// value in which a "#include:path" string is replaced by the JSON of the file, and ${env.name}
+// by the value of a variable, typed outside of strings.
+func dataSourcePropertyRulesTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePropertyRulesTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"template_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The main JSON file of the rules",
+			},
+			"variables": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The variables, overriding the ones of the variable files",
+			},
+			"variable_files": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON files with an object of variables, each overriding the ones before",
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePropertyRulesTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	vars, err := templateVariables(d)
+	if err != nil {
+		return err
+	}
+
+	file := d.Get("template_file").(string)
+	log.Printf("[DEBUG] [PAPI] Rendering rules template %s", file)
+	t := &rulesTemplate{vars: vars}
+	data, err := t.render(file)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(tools.GetSHAString(data))
+	return d.Set("json", data)
+}
+
+// templateVariables returns the JSON of the variables, from the variable files then the map
+func templateVariables(d *schema.ResourceData) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, file := range d.Get("variable_files").([]interface{}) {
+		data, err := ioutil.ReadFile(file.(string))
+		if err != nil {
+			return nil, err
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, fmt.Errorf("%s: %s", file, jsonErrorPosition(data, err))
+		}
+		for name, value := range values {
+			vars[name] = string(compactJSON(value))
+		}
+	}
+	for name, value := range d.Get("variables").(map[string]interface{}) {
+		// a value of the map is JSON if it parses, e.g. true or 80, and a string otherwise
+		if json.Valid([]byte(value.(string))) {
+			vars[name] = string(compactJSON([]byte(value.(string))))
+		} else {
+			data, _ := json.Marshal(value)
+			vars[name] = string(data)
+		}
+	}
+	return vars, nil
+}
+
+type rulesTemplate struct {
+	vars map[string]string
+	// files is the chain of files being included, to detect cycles
+	files []string
+}
+
+// render returns the JSON of a template file, with its includes and variables resolved
+func (t *rulesTemplate) render(file string) (string, error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	for _, f := range t.files {
+		if f == path {
+			return "", fmt.Errorf("%s: include cycle: %s", file, strings.Join(append(t.files, path), " -> "))
+		}
+	}
+	t.files = append(t.files, path)
+	defer func() { t.files = t.files[:len(t.files)-1] }()
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	// the substitutions are on one line, so the lines of the output are the ones of the file
+	var out bytes.Buffer
+	line := 1
+	for i := 0; i < len(src); {
+		switch {
+		case src[i] == '"':
+			end := stringEnd(src, i)
+			literal := src[i:end]
+			var value string
+			if err := json.Unmarshal(literal, &value); err == nil && strings.HasPrefix(value, includePrefix) {
+				include := strings.TrimSpace(strings.TrimPrefix(value, includePrefix))
+				if !filepath.IsAbs(include) {
+					include = filepath.Join(filepath.Dir(path), include)
+				}
+				data, err := t.render(include)
+				if err != nil {
+					return "", fmt.Errorf("%s:%d: %s", file, line, err)
+				}
+				out.WriteString(data)
+			} else {
+				s, err := t.substitute(string(literal), true)
+				if err != nil {
+					return "", fmt.Errorf("%s:%d: %s", file, line, err)
+				}
+				out.WriteString(s)
+			}
+			line += bytes.Count(literal, []byte("\n"))
+			i = end
+		case bytes.HasPrefix(src[i:], []byte(variablePrefix)):
+			end := bytes.IndexByte(src[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("%s:%d: unterminated variable", file, line)
+			}
+			s, err := t.substitute(string(src[i:i+end+1]), false)
+			if err != nil {
+				return "", fmt.Errorf("%s:%d: %s", file, line, err)
+			}
+			out.WriteString(s)
+			i += end + 1
+		default:
+			if src[i] == '\n' {
+				line++
+			}
+			out.WriteByte(src[i])
+			i++
+		}
+	}
+
+	var value interface{}
+	dec := json.NewDecoder(bytes.NewReader(out.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&value); err != nil {
+		return "", fmt.Errorf("%s: %s", file, jsonErrorPosition(out.Bytes(), err))
+	}
+	if dec.More() {
+		return "", fmt.Errorf("%s: more than one JSON value", file)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// substitute replaces the variables of a JSON string literal, or of a variable reference
+// outside of strings by the JSON of its value
+func (t *rulesTemplate) substitute(s string, inString bool) (string, error) {
+	var out strings.Builder
+	for {
+		start := strings.Index(s, variablePrefix)
+		if start < 0 {
+			out.WriteString(s)
+			return out.String(), nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %s", s)
+		}
+		name := s[start+len(variablePrefix) : start+end]
+		value, ok := t.vars[name]
+		if !ok {
+			return "", fmt.Errorf("undefined variable %s", name)
+		}
+		out.WriteString(s[:start])
+		if inString {
+			// in a string, the text of a string value, or the JSON of another value
+			var str string
+			if json.Unmarshal([]byte(value), &str) == nil {
+				data, _ := json.Marshal(str)
+				value = string(data[1 : len(data)-1])
+			} else {
+				data, _ := json.Marshal(value)
+				value = string(data[1 : len(data)-1])
+			}
+		}
+		out.WriteString(value)
+		s = s[start+end+1:]
+	}
+}
+
+// stringEnd returns the offset after the JSON string literal starting at i
+func stringEnd(src []byte, i int) int {
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case '"':
+			return j + 1
+		}
+	}
+	return len(src)
+}
+
+// jsonErrorPosition prefixes a JSON syntax error with its line
+func jsonErrorPosition(data []byte, err error) string {
+	offset := int64(-1)
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		offset = e.Offset
+	case *json.UnmarshalTypeError:
+		offset = e.Offset
+	}
+	if offset < 0 || offset > int64(len(data)) {
+		return err.Error()
+	}
+	line := 1 + bytes.Count(data[:offset], []byte("\n"))
+	return fmt.Sprintf("%d: %s", line, err)
+}
+
+func compactJSON(data []byte) []byte {
+	var b bytes.Buffer
+	if err := json.Compact(&b, data); err != nil {
+		return data
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,120 @@
+package property
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTemplateFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "rules-template")
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func renderTemplate(t *testing.T, dir string, attrs map[string]interface{}) (*schema.ResourceData, error) {
+	attrs["template_file"] = filepath.Join(dir, "main.json")
+	d := schema.TestResourceDataRaw(t, dataSourcePropertyRulesTemplate().Schema, attrs)
+	return d, dataSourcePropertyRulesTemplateRead(d, nil)
+}
+
+func TestDataSourcePropertyRulesTemplate(t *testing.T) {
+	dir := writeTemplateFiles(t, map[string]string{
+		"main.json": `{
+  "rules": {
+    "name": "default",
+    "behaviors": "#include:snippets/behaviors.json",
+    "children": [
+      "#include:snippets/performance.json"
+    ],
+    "options": {"is_secure": ${env.secure}}
+  }
+}`,
+		"snippets/behaviors.json": `[
+  {"name": "origin", "options": {"hostname": "${env.origin}", "httpPort": ${env.port}}}
+]`,
+		"snippets/performance.json": `{
+  "name": "Performance",
+  "comments": "Tiered distribution is ${env.tiered}",
+  "children": ["#include:tiered.json"]
+}`,
+		"snippets/tiered.json": `{"name": "Tiered", "behaviors": [{"name": "tieredDistribution", "options": {"enabled": ${env.tiered}}}]}`,
+		"vars.json":            `{"origin": "default.example.com", "port": 80, "tiered": false}`,
+	})
+	defer os.RemoveAll(dir)
+
+	d, err := renderTemplate(t, dir, map[string]interface{}{
+		"variable_files": []interface{}{filepath.Join(dir, "vars.json")},
+		"variables": map[string]interface{}{
+			"origin": `origin "a".example.com`,
+			"secure": "true",
+		},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"rules": {
+		"name": "default",
+		"behaviors": [{"name": "origin", "options": {"hostname": "origin \"a\".example.com", "httpPort": 80}}],
+		"children": [{
+			"name": "Performance",
+			"comments": "Tiered distribution is false",
+			"children": [{"name": "Tiered", "behaviors": [{"name": "tieredDistribution", "options": {"enabled": false}}]}]
+		}],
+		"options": {"is_secure": true}
+	}}`, d.Get("json").(string))
+	assert.NotEmpty(t, d.Id())
+}
+
+func TestDataSourcePropertyRulesTemplate_errors(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		expected string
+	}{
+		"syntax error in a snippet": {
+			files: map[string]string{
+				"main.json": "{\n  \"rules\": \"#include:bad.json\"\n}",
+				"bad.json":  "{\n  \"name\": \"bad\",\n  \"behaviors\": [,]\n}",
+			},
+			expected: "main.json:2: $DIR/bad.json: 3: invalid character ',' looking for beginning of value",
+		},
+		"undefined variable": {
+			files: map[string]string{
+				"main.json": "{\n\n  \"rules\": {\"name\": \"${env.missing}\"}\n}",
+			},
+			expected: "main.json:3: undefined variable missing",
+		},
+		"missing include": {
+			files: map[string]string{
+				"main.json": "{\"rules\": \"#include:missing.json\"}",
+			},
+			expected: "main.json:1: open $DIR/missing.json: no such file or directory",
+		},
+		"include cycle": {
+			files: map[string]string{
+				"main.json": "{\"rules\": \"#include:a.json\"}",
+				"a.json":    "{\"children\": [\"#include:main.json\"]}",
+			},
+			expected: "include cycle: $DIR/main.json -> $DIR/a.json -> $DIR/main.json",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeTemplateFiles(t, test.files)
+			defer os.RemoveAll(dir)
+
+			_, err := renderTemplate(t, dir, map[string]interface{}{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), strings.Replace(test.expected, "$DIR", dir, -1))
+		})
+	}
+}
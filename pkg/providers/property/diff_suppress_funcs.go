@@ -8,7 +8,6 @@ import (
 	"log"
 
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
-	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/tidwall/gjson"
@@ -42,79 +41,45 @@ func suppressEquivalentJsonDiffs(k, old, new string, d *schema.ResourceData) boo
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs OB %s\n", string(ob.Bytes())))
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs NB %s\n", string(nb.Bytes())))
 
-	rulesOld, err := getRulesForComp(d, old, "")
-	rulesOld.Etag = ""
-	jsonBody, err := jsonhooks.Marshal(rulesOld)
+	changes, err := getRulesDiff(d, old, new, CorrelationID)
 	if err != nil {
 		return false
 	}
-	sha1hashOld := tools.GetSHAString(string(jsonBody))
-
-	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs SHA from OLD Json %s\n", sha1hashOld))
-
-	rulesNew, err := getRulesForComp(d, new, "")
-	rulesNew.Etag = ""
-	jsonBodyNew, err := jsonhooks.Marshal(rulesNew)
-	if err != nil {
-		return false
-	}
-	sha1hashNew := tools.GetSHAString(string(jsonBodyNew))
-
-	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs SHA from NEW Json %s\n", sha1hashNew))
-
-	if sha1hashOld == sha1hashNew {
-		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs SHA Equal skip diff \n")
+	if len(changes) == 0 {
+		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs rules equal skip diff \n")
 		return true
-	} else {
-		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs SHA Not Equal diff applies \n")
-		return false
 	}
-
+	edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs rules changed diff applies \n")
+	return false
 }
 
-func suppressEquivalentJsonPendingDiffs(old, new string, d *schema.ResourceDiff) bool {
+// suppressEquivalentJsonPendingDiffs returns the changes of the rules in a pending diff, and
+// whether there are none
+func suppressEquivalentJsonPendingDiffs(old, new string, d *schema.ResourceDiff) ([]ruleTreeChange, bool) {
 	CorrelationID := "[PAPI][suppressEquivalentJsonPendingDiffs-" + tools.CreateNonce() + "]"
 	ob := bytes.NewBufferString("")
 	if err := json.Compact(ob, []byte(old)); err != nil {
-		return false
+		return nil, false
 	}
 
 	nb := bytes.NewBufferString("")
 	if err := json.Compact(nb, []byte(new)); err != nil {
-		return false
+		return nil, false
 	}
 
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs OB %s\n", string(ob.Bytes())))
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs NB %s\n", string(nb.Bytes())))
 
-	rulesOld, err := getRulesForComp(d, old, "")
-	rulesOld.Etag = ""
-	jsonBody, err := jsonhooks.Marshal(rulesOld)
+	changes, err := getRulesDiff(d, old, new, CorrelationID)
 	if err != nil {
-		return false
-	}
-	sha1hashOld := tools.GetSHAString(string(jsonBody))
-
-	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs SHA from OLD Json %s\n", sha1hashOld))
-
-	rulesNew, err := getRulesForComp(d, new, "")
-	rulesNew.Etag = ""
-	jsonBodyNew, err := jsonhooks.Marshal(rulesNew)
-	if err != nil {
-		return false
+		return nil, false
 	}
-	sha1hashNew := tools.GetSHAString(string(jsonBodyNew))
-
-	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  suppressEquivalentJsonDiffs SHA from NEW Json %s\n", sha1hashNew))
-
-	if sha1hashOld == sha1hashNew {
-		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs SHA Equal skip diff \n")
-		return true
-	} else {
-		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonDiffs SHA Not Equal diff applies \n")
-		return false
+	if len(changes) == 0 {
+		edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonPendingDiffs rules equal skip diff \n")
+		return nil, true
 	}
-
+	edge.PrintfCorrelation("[DEBUG]", CorrelationID, "  suppressEquivalentJsonPendingDiffs rules changed diff applies \n")
+	return changes, false
 }
 
 func getRulesForComp(d interface{}, json string, correlationid string) (*papi.Rules, error) {
@@ -59,11 +59,13 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"akamai_contract":       dataSourcePropertyContract(),
-			"akamai_cp_code":        dataSourceCPCode(),
-			"akamai_group":          dataSourcePropertyGroups(),
-			"akamai_property_rules": dataPropertyRules(),
-			"akamai_property":       dataSourceAkamaiProperty(),
+			"akamai_contract":                dataSourcePropertyContract(),
+			"akamai_cp_code":                 dataSourceCPCode(),
+			"akamai_group":                   dataSourcePropertyGroups(),
+			"akamai_property_rules":          dataPropertyRules(),
+			"akamai_property_rules_builder":  dataSourcePropertyRulesBuilder(),
+			"akamai_property_rules_template": dataSourcePropertyRulesTemplate(),
+			"akamai_property":                dataSourceAkamaiProperty(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"akamai_cp_code":             resourceCPCode(),
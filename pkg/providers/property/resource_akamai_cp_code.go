@@ -65,6 +65,8 @@ func resourceCPCodeCreate(d *schema.ResourceData, meta interface{}) error {
 			edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("%s", err.(client.APIError).RawBody))
 			return err
 		}
+		// papi-v1 caches the CP codes it lists, which no longer include the new one
+		papi.Profilecache.Delete("cpcodes")
 	}
 
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  Resulting CP Code: %#v\n\n\n", cpCode))
@@ -4,8 +4,14 @@ import (
 	"log"
 	"testing"
 
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccAkamaiCPCode_basic(t *testing.T) {
@@ -26,6 +32,42 @@ func TestAccAkamaiCPCode_basic(t *testing.T) {
 	})
 }
 
+func TestResourceCPCode(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	papi.Config = srv.Config()
+	edge.SetupLogging()
+
+	config := map[string]interface{}{
+		"name":     "terraform-testing",
+		"contract": mockapi.ContractID,
+		"group":    mockapi.GroupID,
+		"product":  "prd_SPM",
+	}
+	d := schema.TestResourceDataRaw(t, resourceCPCode().Schema, config)
+	require.NoError(t, resourceCPCodeCreate(d, nil))
+	assert.Regexp(t, `^cpc_\d+$`, d.Id())
+	assert.Contains(t, srv.Requests(), "POST /papi/v1/cpcodes")
+
+	// an existing CP code of the same name is reused, as CP codes cannot be deleted
+	reused := schema.TestResourceDataRaw(t, resourceCPCode().Schema, config)
+	require.NoError(t, resourceCPCodeCreate(reused, nil))
+	assert.Equal(t, d.Id(), reused.Id())
+
+	require.NoError(t, resourceCPCodeRead(d, nil))
+	assert.Equal(t, reused.Id(), d.Id())
+
+	require.NoError(t, resourceCPCodeDelete(d, nil))
+	var creates int
+	for _, req := range srv.Requests() {
+		if req == "POST /papi/v1/cpcodes" {
+			creates++
+		}
+	}
+	assert.Equal(t, 1, creates)
+}
+
 func testAccAkamaiCpCodeConfig() string {
 	return `
 provider "akamai" {
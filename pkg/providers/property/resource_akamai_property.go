@@ -2,20 +2,25 @@ package property
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
 	"log"
+	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
-
-	//log "github.com/sirupsen/logrus"
+	"time"
 
 	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/tools"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	//log "github.com/sirupsen/logrus"
 	"github.com/tidwall/gjson"
 )
 
@@ -149,6 +154,61 @@ var akamaiPropertySchema = map[string]*schema.Schema{
 		Type:     schema.TypeString,
 		Optional: true,
 	},
+	"base_version": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  "The version new versions are created from: latest, staging, production or a version number",
+		ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(latest|staging|production|[1-9][0-9]*)$`), "must be latest, staging, production or a version number"),
+	},
+	"version_notes": {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+	"versions": {
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"version": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"notes": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"etag": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"staging_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"production_status": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"created_from": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"updated_by_user": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"updated_date": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	},
+	"rules_diff": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The changes of the rules planned, a summary then one change per line, empty once applied",
+	},
 	"rulessha": &schema.Schema{
 		Type:     schema.TypeString,
 		Computed: true,
@@ -175,6 +235,9 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	baseVersion := d.Get("base_version").(string)
+	rebase := baseVersion != ""
+
 	var property *papi.Property
 	if property = findProperty(d, CorrelationID); property == nil {
 		if product == nil {
@@ -185,9 +248,11 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return err
 		}
+		// the first version of a new property is the base
+		rebase = false
 	}
 
-	err = ensureEditableVersion(property, CorrelationID)
+	err = ensureEditableVersion(property, baseVersion, rebase, CorrelationID)
 	if err != nil {
 		return err
 	}
@@ -201,7 +266,7 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	err = rules.Save(CorrelationID)
+	err = saveRules(rules, d.Get("version_notes").(string), CorrelationID)
 	if err != nil {
 		if err == papi.ErrorMap[papi.ErrInvalidRules] && len(rules.Errors) > 0 {
 			var msg string
@@ -236,6 +301,9 @@ func resourcePropertyCreate(d *schema.ResourceData, meta interface{}) error {
 		d.Set("rules", string(jsonBody))
 	}
 
+	// the write changed the version, so Read lists the versions again
+	d.Set("versions", nil)
+
 	d.Partial(false)
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, "Done")
 	return resourcePropertyRead(d, meta)
@@ -466,6 +534,7 @@ func resourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("rules", string(jsonBody))
 	}
 	d.Set("rulessha", sha1hashAPI)
+	d.Set("rules_diff", "")
 
 	if rules.RuleFormat != "" {
 		d.Set("rule_format", rules.RuleFormat)
@@ -474,6 +543,7 @@ func resourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  Property RuleFormat from API : %s\n", property.RuleFormat))
+	outdated := versionsOutdated(d, property)
 	d.Set("version", property.LatestVersion)
 	if property.StagingVersion > 0 {
 		d.Set("staging_version", property.StagingVersion)
@@ -482,6 +552,16 @@ func resourcePropertyRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("production_version", property.ProductionVersion)
 	}
 
+	if outdated {
+		versions, err := property.GetVersions(CorrelationID)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("versions", flattenVersions(versions)); err != nil {
+			return err
+		}
+	}
+
 	d.Partial(false)
 	return nil
 }
@@ -496,14 +576,14 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 		return e
 	}
 
-	err := ensureEditableVersion(property, CorrelationID)
+	err := ensureEditableVersion(property, d.Get("base_version").(string), d.HasChange("base_version"), CorrelationID)
 	if err != nil {
 		return err
 	}
 
 	rules, err := getRules(d, property, property.Contract, property.Group, CorrelationID)
 
-	if d.HasChange("rule_format") || d.HasChange("rules") {
+	if d.HasChanges("rule_format", "rules", "base_version", "version_notes") {
 		if ruleFormat, ok := d.GetOk("rule_format"); ok {
 			property.RuleFormat = ruleFormat.(string)
 			rules.RuleFormat = ruleFormat.(string)
@@ -518,7 +598,7 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  UPDATE Check rules after unmarshal from Json %s\n", string(jsonBody)))
-		e = rules.Save(CorrelationID)
+		e = saveRules(rules, d.Get("version_notes").(string), CorrelationID)
 		if e != nil {
 			if e == papi.ErrorMap[papi.ErrInvalidRules] && len(rules.Errors) > 0 {
 				var msg string
@@ -555,6 +635,9 @@ func resourcePropertyUpdate(d *schema.ResourceData, meta interface{}) error {
 		d.Set("edge_hostnames", ehnMap)
 	}
 
+	// the write changed the version, so Read lists the versions again
+	d.Set("versions", nil)
+
 	d.Partial(false)
 
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, "Done")
@@ -568,13 +651,28 @@ func resourceCustomDiffCustomizeDiff(_ context.Context, d *schema.ResourceDiff,
 	// Note that this gets put into state after the update, regardless of whether
 	// or not anything is acted upon in the diff.
 
+	if err := validatePropertyRules(d); err != nil {
+		return err
+	}
+
+	if d.HasChange("base_version") || d.HasChange("version_notes") {
+		d.SetNewComputed("version")
+		d.SetNewComputed("versions")
+	}
+
 	old, new := d.GetChange("rules")
 
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  resourceCustomDiffCustomizeDiff OLD "+old.(string)))
 	edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  resourceCustomDiffCustomizeDiff NEW "+new.(string)))
-	if !suppressEquivalentJsonPendingDiffs(old.(string), new.(string), d) {
+	if changes, equal := suppressEquivalentJsonPendingDiffs(old.(string), new.(string), d); !equal {
 		edge.PrintfCorrelation("[DEBUG]", CorrelationID, fmt.Sprintf("  resourceCustomDiffCustomizeDiff CHANGED VALUES "+old.(string)+" "+new.(string)))
 		d.SetNewComputed("version")
+		d.SetNewComputed("versions")
+		if len(changes) == 0 {
+			return d.SetNewComputed("rules_diff")
+		}
+		// rules_diff only shows in the plan, Read clears it
+		return d.SetNew("rules_diff", formatRuleTreeChanges(changes))
 	}
 
 	return nil
@@ -1364,7 +1462,10 @@ func findProperty(d *schema.ResourceData, correlationid string) *papi.Property {
 	return property
 }
 
-func ensureEditableVersion(property *papi.Property, correlationid string) error {
+// ensureEditableVersion makes the latest version of a property editable, creating a new version
+// from the base version when the latest one has been activated, or when rebase is set as
+// base_version changed
+func ensureEditableVersion(property *papi.Property, baseVersion string, rebase bool, correlationid string) error {
 	latestVersion, err := property.GetLatestVersion("", correlationid)
 	if err != nil {
 		return err
@@ -1375,14 +1476,111 @@ func ensureEditableVersion(property *papi.Property, correlationid string) error
 		return err
 	}
 
-	if latestVersion.ProductionStatus != papi.StatusInactive || latestVersion.StagingStatus != papi.StatusInactive {
-		// The latest version has been activated on either production or staging, so we need to create a new version to apply changes on
-		newVersion := versions.NewVersion(latestVersion, false, correlationid)
-		err = newVersion.Save(correlationid)
+	editable := latestVersion.ProductionStatus == papi.StatusInactive && latestVersion.StagingStatus == papi.StatusInactive
+	if editable && !rebase {
+		return property.GetProperty(correlationid)
+	}
+
+	base, err := getBaseVersion(property, versions, latestVersion, baseVersion, correlationid)
+	if err != nil {
+		return err
+	}
+	if editable && base.PropertyVersion == latestVersion.PropertyVersion {
+		return property.GetProperty(correlationid)
+	}
+
+	// The latest version has been activated on either production or staging, or changes are to
+	// be based on another version, so we need to create a new version to apply changes on
+	edge.PrintfCorrelation("[DEBUG]", correlationid, fmt.Sprintf("  Creating version of property %s from version %d", property.PropertyID, base.PropertyVersion))
+	newVersion := versions.NewVersion(base, false, correlationid)
+	err = newVersion.Save(correlationid)
+	if err != nil {
+		return err
+	}
+
+	return property.GetProperty(correlationid)
+}
+
+// getBaseVersion returns the version of a property a base_version of latest, staging,
+// production or a version number stands for
+func getBaseVersion(property *papi.Property, versions *papi.Versions, latestVersion *papi.Version, baseVersion string, correlationid string) (*papi.Version, error) {
+	switch baseVersion {
+	case "", "latest":
+		return latestVersion, nil
+	case "staging", "production":
+		version, err := property.GetLatestVersion(papi.NetworkValue(strings.ToUpper(baseVersion)), correlationid)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("no version of property %s is active on %s: %s", property.PropertyID, baseVersion, err)
 		}
+		return version, nil
 	}
 
-	return property.GetProperty(correlationid)
+	number, err := strconv.Atoi(baseVersion)
+	if err != nil {
+		return nil, fmt.Errorf("base_version must be latest, staging, production or a version number, got %q", baseVersion)
+	}
+	for _, version := range versions.Versions.Items {
+		if version.PropertyVersion == number {
+			return version, nil
+		}
+	}
+	return nil, fmt.Errorf("version %d of property %s does not exist", number, property.PropertyID)
+}
+
+// saveRules saves a rule tree like Rules.Save, along with the notes of its version, which
+// papi-v1 has no field for
+func saveRules(rules *papi.Rules, notes string, correlationid string) error {
+	if notes == "" {
+		return rules.Save(correlationid)
+	}
+
+	body, err := jsonhooks.Marshal(rules)
+	if err != nil {
+		return err
+	}
+	var tree map[string]interface{}
+	if err := json.Unmarshal(body, &tree); err != nil {
+		return err
+	}
+	tree["comments"] = notes
+
+	rules.Errors = []*papi.RuleErrors{}
+	path := fmt.Sprintf("/papi/v1/properties/%s/versions/%d/rules", rules.PropertyID, rules.PropertyVersion)
+	if err := apiclient.DoRequest(papi.Config, http.MethodPut, path, tree, rules); err != nil {
+		return err
+	}
+	if len(rules.Errors) != 0 {
+		return papi.ErrorMap[papi.ErrInvalidRules]
+	}
+	return nil
+}
+
+// versionsOutdated reports whether the versions attribute needs listing the versions again,
+// which is when they were cleared by a write or a version was created or activated since
+func versionsOutdated(d *schema.ResourceData, property *papi.Property) bool {
+	return len(d.Get("versions").([]interface{})) == 0 ||
+		d.Get("version").(int) != property.LatestVersion ||
+		property.StagingVersion > 0 && d.Get("staging_version").(int) != property.StagingVersion ||
+		property.ProductionVersion > 0 && d.Get("production_version").(int) != property.ProductionVersion
+}
+
+// flattenVersions returns the versions of a property as the versions attribute, the most recent first
+func flattenVersions(versions *papi.Versions) []interface{} {
+	list := make([]interface{}, 0, len(versions.Versions.Items))
+	for _, version := range versions.Versions.Items {
+		list = append(list, map[string]interface{}{
+			"version":           version.PropertyVersion,
+			"notes":             version.Note,
+			"etag":              version.Etag,
+			"staging_status":    string(version.StagingStatus),
+			"production_status": string(version.ProductionStatus),
+			"created_from":      version.CreateFromVersion,
+			"updated_by_user":   version.UpdatedByUser,
+			"updated_date":      version.UpdatedDate.Format(time.RFC3339),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].(map[string]interface{})["version"].(int) > list[j].(map[string]interface{})["version"].(int)
+	})
+	return list
 }
@@ -1,13 +1,19 @@
 package property
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testAccAkamaiPropertyConfig = `
@@ -269,3 +275,208 @@ func testAccCheckAkamaiPropertyExists(s *terraform.State) error {
 	}
 	return nil
 }
+
+// testMockProperty creates a property on the mock API and activates its version 1 on production
+func testMockProperty(t *testing.T) *papi.Property {
+	edge.SetupLogging()
+
+	groups, err := papi.GetGroups()
+	require.NoError(t, err)
+	group, err := groups.FindGroup(mockapi.GroupID)
+	require.NoError(t, err)
+	contracts, err := papi.GetContracts()
+	require.NoError(t, err)
+	contract, err := contracts.FindContract(mockapi.ContractID)
+	require.NoError(t, err)
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.Contract = contract
+	property.Group = group
+	property.PropertyName = "www.example.com"
+	property.ProductID = "prd_SPM"
+	require.NoError(t, property.Save(""))
+	testMockActivate(t, property, 1, papi.NetworkProduction)
+	return property
+}
+
+func testMockActivate(t *testing.T, property *papi.Property, version int, network papi.NetworkValue) {
+	activation := papi.NewActivation(papi.NewActivations())
+	activation.PropertyVersion = version
+	activation.Network = network
+	activation.NotifyEmails = []string{"noreply@example.com"}
+	require.NoError(t, activation.Save(property, true))
+}
+
+func TestEnsureEditableVersion(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	papi.Config = srv.Config()
+
+	property := testMockProperty(t)
+
+	// the active latest version is branched from
+	require.NoError(t, ensureEditableVersion(property, "", false, ""))
+	assert.Equal(t, 2, property.LatestVersion)
+
+	// an editable latest version is edited
+	require.NoError(t, ensureEditableVersion(property, "latest", false, ""))
+	assert.Equal(t, 2, property.LatestVersion)
+
+	// a hotfix is branched from production even though the latest version is editable
+	testMockActivate(t, property, 2, papi.NetworkStaging)
+	require.NoError(t, ensureEditableVersion(property, "", false, ""))
+	assert.Equal(t, 3, property.LatestVersion)
+	require.NoError(t, ensureEditableVersion(property, "production", true, ""))
+	assert.Equal(t, 4, property.LatestVersion)
+
+	versions, err := property.GetVersions("")
+	require.NoError(t, err)
+	list := flattenVersions(versions)
+	require.Len(t, list, 4)
+	assert.Equal(t, 4, list[0].(map[string]interface{})["version"])
+	assert.Equal(t, 1, list[0].(map[string]interface{})["created_from"])
+	assert.Equal(t, 2, list[1].(map[string]interface{})["created_from"])
+	assert.Equal(t, "ACTIVE", list[2].(map[string]interface{})["staging_status"])
+	assert.Equal(t, "ACTIVE", list[3].(map[string]interface{})["production_status"])
+
+	// the base version being the latest editable one, nothing is created
+	require.NoError(t, ensureEditableVersion(property, "4", true, ""))
+	assert.Equal(t, 4, property.LatestVersion)
+
+	assert.EqualError(t, ensureEditableVersion(property, "9", true, ""), "version 9 of property "+property.PropertyID+" does not exist")
+}
+
+func TestEnsureEditableVersionNotActive(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	papi.Config = srv.Config()
+
+	property := testMockProperty(t)
+	err := ensureEditableVersion(property, "staging", true, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no version of property "+property.PropertyID+" is active on staging")
+}
+
+func TestSaveRulesVersionNotes(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	papi.Config = srv.Config()
+
+	property := testMockProperty(t)
+	require.NoError(t, ensureEditableVersion(property, "", false, ""))
+
+	rules, err := property.GetRules("")
+	require.NoError(t, err)
+	require.NoError(t, saveRules(rules, "Hotfix for origin timeouts", ""))
+
+	versions, err := property.GetVersions("")
+	require.NoError(t, err)
+	list := flattenVersions(versions)
+	assert.Equal(t, "Hotfix for origin timeouts", list[0].(map[string]interface{})["notes"])
+	assert.Equal(t, "", list[1].(map[string]interface{})["notes"])
+}
+
+func TestResourceProperty(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	papi.Config = srv.Config()
+	edge.SetupLogging()
+
+	cpCode := schema.TestResourceDataRaw(t, resourceCPCode().Schema, map[string]interface{}{
+		"name":     "www.example.com",
+		"contract": mockapi.ContractID,
+		"group":    mockapi.GroupID,
+		"product":  "prd_SPM",
+	})
+	require.NoError(t, resourceCPCodeCreate(cpCode, nil))
+	edgeHostname := schema.TestResourceDataRaw(t, resourceSecureEdgeHostName().Schema, map[string]interface{}{
+		"product":       "prd_SPM",
+		"contract":      mockapi.ContractID,
+		"group":         mockapi.GroupID,
+		"edge_hostname": "www.example.com.edgesuite.net",
+	})
+	require.NoError(t, resourceSecureEdgeHostNameCreate(edgeHostname, nil))
+
+	d := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+		"name":          "www.example.com",
+		"contract":      mockapi.ContractID,
+		"group":         mockapi.GroupID,
+		"product":       "prd_SPM",
+		"cp_code":       cpCode.Id(),
+		"rule_format":   mockapi.RuleFormat,
+		"hostnames":     map[string]interface{}{"www.example.com": "www.example.com.edgesuite.net"},
+		"rules":         `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"behavior":"NO_STORE"}}]}}`,
+		"version_notes": "Initial version",
+	})
+	require.NoError(t, resourcePropertyCreate(d, nil))
+	assert.Regexp(t, `^prp_\d+$`, d.Id())
+	assert.Equal(t, 1, d.Get("version"))
+	assert.Equal(t, mockapi.RuleFormat, d.Get("rule_format"))
+	assert.Equal(t, map[string]interface{}{"www.example.com": "www.example.com.edgesuite.net"}, d.Get("edge_hostnames"))
+	assert.Contains(t, d.Get("rules"), `"NO_STORE"`)
+	assert.Contains(t, d.Get("rules"), `"cpCode","options":{"value":{"id":`+strings.TrimPrefix(cpCode.Id(), "cpc_"))
+	assert.Equal(t, "Initial version", d.Get("versions.0.notes"))
+
+	// the version is edited in place until it is activated
+	r := resourceProperty()
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "www.example.com",
+		"contract":    mockapi.ContractID,
+		"group":       mockapi.GroupID,
+		"product":     "prd_SPM",
+		"cp_code":     cpCode.Id(),
+		"rule_format": mockapi.RuleFormat,
+		"hostnames":   map[string]interface{}{"www.example.com": "www.example.com.edgesuite.net"},
+		"rules":       `{"rules":{"name":"default","behaviors":[{"name":"caching","options":{"behavior":"MAX_AGE","ttl":"1d"}}]}}`,
+	}), nil)
+	require.NoError(t, err)
+	assert.Contains(t, diff.Attributes["rules_diff"].New, "The rules change ")
+	updated, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+	require.NoError(t, resourcePropertyUpdate(updated, nil))
+	assert.Equal(t, 1, updated.Get("version"))
+	assert.Equal(t, "", updated.Get("rules_diff"), "rules_diff is only planned")
+	assert.Contains(t, updated.Get("rules"), `"MAX_AGE"`)
+	assert.NotEqual(t, d.Get("rulessha"), updated.Get("rulessha"))
+
+	property := papi.NewProperty(papi.NewProperties())
+	property.PropertyID = d.Id()
+	require.NoError(t, property.GetProperty(""))
+	testMockActivate(t, property, 1, papi.NetworkStaging)
+	require.NoError(t, resourcePropertyRead(updated, nil))
+	assert.Equal(t, 1, updated.Get("staging_version"))
+	assert.Equal(t, "ACTIVE", updated.Get("versions.0.staging_status"))
+
+	// the versions are only listed again when they change
+	listVersions := func() int {
+		n := 0
+		for _, req := range srv.Requests() {
+			if req == "GET /papi/v1/properties/"+d.Id()+"/versions" {
+				n++
+			}
+		}
+		return n
+	}
+	listed := listVersions()
+	require.NotZero(t, listed)
+	require.NoError(t, resourcePropertyRead(updated, nil))
+	assert.Equal(t, listed, listVersions())
+	assert.EqualError(t, resourcePropertyDelete(updated, nil), "property is still active on STAGING and cannot be deleted")
+
+	inactive := schema.TestResourceDataRaw(t, resourceProperty().Schema, map[string]interface{}{
+		"name":        "inactive.example.com",
+		"contract":    mockapi.ContractID,
+		"group":       mockapi.GroupID,
+		"rule_format": mockapi.RuleFormat,
+	})
+	require.NoError(t, resourcePropertyCreate(inactive, nil))
+	id := inactive.Id()
+	require.NoError(t, resourcePropertyDelete(inactive, nil))
+	assert.Equal(t, "", inactive.Id())
+	assert.Contains(t, srv.Requests(), "DELETE /papi/v1/properties/"+id)
+}
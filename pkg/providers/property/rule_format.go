@@ -0,0 +1,321 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+)
+
+type (
+	// jsonSchema is the part of JSON schema used by the PAPI rule format schemas
+	jsonSchema struct {
+		Ref                  string                 `json:"$ref,omitempty"`
+		Type                 interface{}            `json:"type,omitempty"`
+		Enum                 []interface{}          `json:"enum,omitempty"`
+		Pattern              string                 `json:"pattern,omitempty"`
+		Minimum              *float64               `json:"minimum,omitempty"`
+		Maximum              *float64               `json:"maximum,omitempty"`
+		Items                *jsonSchema            `json:"items,omitempty"`
+		Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+		Required             []string               `json:"required,omitempty"`
+		AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	}
+
+	// ruleFormat is a rule format schema, with the options of each behavior and criterion
+	ruleFormat struct {
+		Name      string
+		Behaviors map[string]*jsonSchema
+		Criteria  map[string]*jsonSchema
+	}
+)
+
+// bundledRuleFormats are the rule format schemas shipped with the provider for the rules
+// builder. They are trimmed to the behaviors and criteria of the builder, so rules are
+// validated against the complete schemas from PAPI instead.
+var bundledRuleFormats = map[string]string{
+	"v2020-03-04": ruleFormatV20200304,
+}
+
+// ruleFormats caches the parsed rule format schemas, by name for the bundled ones and by
+// product and name for the ones fetched from PAPI
+var ruleFormats sync.Map
+
+// ruleFormatCacheDir returns the directory the schemas fetched from PAPI are cached in, by
+// product and rule format, or "" when there is none
+var ruleFormatCacheDir = func() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "terraform-provider-akamai", "rule-formats")
+}
+
+// getRuleFormat returns the complete schema of a rule format for the product, fetched from
+// PAPI. Versioned schemas are cached on disk and read from the cache when PAPI can't be
+// reached.
+func getRuleFormat(product, name string) (*ruleFormat, error) {
+	key := product + "/" + name
+	if f, ok := ruleFormats.Load(key); ok {
+		return f.(*ruleFormat), nil
+	}
+
+	var cache string
+	if dir := ruleFormatCacheDir(); dir != "" && name != "latest" {
+		cache = filepath.Join(dir, product, name+".json")
+	}
+
+	var data json.RawMessage
+	path := fmt.Sprintf("/papi/v1/schemas/products/%s/%s", product, name)
+	if err := apiclient.DoRequest(papi.Config, http.MethodGet, path, nil, &data); err != nil {
+		if cache == "" || apiclient.IsNotFound(err) {
+			return nil, apiclient.Error(err)
+		}
+		cached, cacheErr := ioutil.ReadFile(cache)
+		if cacheErr != nil {
+			return nil, apiclient.Error(err)
+		}
+		log.Printf("[WARN] [PAPI] Using the cached schema of rule format %s for product %s: %s", name, product, err)
+		data = cached
+	} else if cache != "" {
+		if err := os.MkdirAll(filepath.Dir(cache), 0755); err == nil {
+			err = ioutil.WriteFile(cache, data, 0644)
+		}
+		if err != nil {
+			log.Printf("[WARN] [PAPI] Not caching the schema of rule format %s for product %s: %s", name, product, err)
+		}
+	}
+
+	f, err := parseRuleFormat(name, data)
+	if err != nil {
+		return nil, err
+	}
+	ruleFormats.Store(key, f)
+	return f, nil
+}
+
+// getBundledRuleFormat returns the bundled schema of a rule format
+func getBundledRuleFormat(name string) (*ruleFormat, error) {
+	if f, ok := ruleFormats.Load(name); ok {
+		return f.(*ruleFormat), nil
+	}
+	data, ok := bundledRuleFormats[name]
+	if !ok {
+		return nil, fmt.Errorf("rule format %s is not bundled", name)
+	}
+	f, err := parseRuleFormat(name, []byte(data))
+	if err != nil {
+		return nil, err
+	}
+	ruleFormats.Store(name, f)
+	return f, nil
+}
+
+// parseRuleFormat reads the behaviors and criteria catalog of a rule format schema, with
+// the $ref of their options resolved
+func parseRuleFormat(name string, data []byte) (*ruleFormat, error) {
+	var doc interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("reading rule format %s schema: %s", name, err)
+	}
+	r := &refResolver{doc: doc, defs: make(map[string]*jsonSchema)}
+
+	f := &ruleFormat{
+		Name:      name,
+		Behaviors: make(map[string]*jsonSchema),
+		Criteria:  make(map[string]*jsonSchema),
+	}
+	for ref, options := range map[string]map[string]*jsonSchema{
+		"#/definitions/catalog/behaviors": f.Behaviors,
+		"#/definitions/catalog/criteria":  f.Criteria,
+	} {
+		entries, _ := r.pointer(ref).(map[string]interface{})
+		for n := range entries {
+			entry, err := r.resolve(&jsonSchema{Ref: ref + "/" + pointerEscaper.Replace(n)}, make(map[string]bool))
+			if err != nil {
+				return nil, fmt.Errorf("reading rule format %s schema: %s", name, err)
+			}
+			if options[n] = entry.Properties["options"]; options[n] == nil {
+				options[n] = &jsonSchema{Type: "object"}
+			}
+		}
+	}
+	if len(f.Behaviors) == 0 {
+		return nil, fmt.Errorf("rule format %s schema has no behaviors", name)
+	}
+	return f, nil
+}
+
+// pointerEscaper escapes a key in a JSON pointer
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// refResolver resolves the local $ref of a schema document, e.g. #/definitions/type/port
+type refResolver struct {
+	doc interface{}
+	// defs holds the decoded schemas by $ref, before their own $ref are resolved
+	defs map[string]*jsonSchema
+}
+
+// pointer returns the value of the document at a JSON pointer, or nil
+func (r *refResolver) pointer(ref string) interface{} {
+	value := r.doc
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = m[token]
+	}
+	return value
+}
+
+// resolve returns the schema with every $ref replaced by its definition. A $ref to a
+// definition being resolved is recursive and left as an empty schema, which takes any value.
+func (r *refResolver) resolve(s *jsonSchema, seen map[string]bool) (*jsonSchema, error) {
+	if s == nil {
+		return nil, nil
+	}
+	if s.Ref != "" {
+		if seen[s.Ref] {
+			return &jsonSchema{}, nil
+		}
+		def, err := r.definition(s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		seen[s.Ref] = true
+		defer delete(seen, s.Ref)
+		return r.resolve(def, seen)
+	}
+
+	resolved := *s
+	var err error
+	if resolved.Items, err = r.resolve(s.Items, seen); err != nil {
+		return nil, err
+	}
+	if len(s.Properties) > 0 {
+		resolved.Properties = make(map[string]*jsonSchema, len(s.Properties))
+		for k, p := range s.Properties {
+			if resolved.Properties[k], err = r.resolve(p, seen); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &resolved, nil
+}
+
+// definition returns the schema a $ref points at
+func (r *refResolver) definition(ref string) (*jsonSchema, error) {
+	if def, ok := r.defs[ref]; ok {
+		return def, nil
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("$ref %s is not local to the schema", ref)
+	}
+	value := r.pointer(ref)
+	if value == nil {
+		return nil, fmt.Errorf("$ref %s is not defined", ref)
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var def jsonSchema
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, fmt.Errorf("$ref %s: %s", ref, err)
+	}
+	r.defs[ref] = &def
+	return &def, nil
+}
+
+// types returns the JSON types allowed by the schema, without null
+func (s *jsonSchema) types() []string {
+	var types []string
+	switch t := s.Type.(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if v, ok := v.(string); ok {
+				types = append(types, v)
+			}
+		}
+	}
+	list := types[:0]
+	for _, t := range types {
+		if t != "null" {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+// mainType returns the first JSON type allowed by the schema, other than null
+func (s *jsonSchema) mainType() string {
+	if types := s.types(); len(types) > 0 {
+		return types[0]
+	}
+	if len(s.Properties) > 0 {
+		return "object"
+	}
+	return ""
+}
+
+// enumStrings returns the string values of the enum of the schema
+func (s *jsonSchema) enumStrings() []string {
+	list := make([]string, 0, len(s.Enum))
+	for _, v := range s.Enum {
+		if v, ok := v.(string); ok {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+func (s *jsonSchema) isRequired(name string) bool {
+	for _, r := range s.Required {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the property names of the schema in order
+func (s *jsonSchema) sortedKeys() []string {
+	keys := make([]string, 0, len(s.Properties))
+	for k := range s.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// snakeCase returns the Terraform attribute name of a PAPI name, e.g. enable_true_client_ip
+// for enableTrueClientIp
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
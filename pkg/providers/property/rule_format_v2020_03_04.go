@@ -0,0 +1,1364 @@
+package property
+
+// ruleFormatV20200304 is the PAPI rule format schema of v2020-03-04, trimmed to the behaviors
+// and criteria of the rules builder. It is not complete, so it is not used for validation.
+const ruleFormatV20200304 = `
+{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "definitions": {
+    "catalog": {
+      "behaviors": {
+        "allowPost": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "allowPost"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "allowWithoutContentLength": {
+                  "type": "boolean"
+                },
+                "enabled": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "allowTransferEncoding": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "allowTransferEncoding"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "enabled": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "cacheKeyQueryParams": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "cacheKeyQueryParams"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "behavior": {
+                  "enum": [
+                    "INCLUDE_ALL_PRESERVE_ORDER",
+                    "INCLUDE_ALL_ALPHABETIZE_ORDER",
+                    "IGNORE_ALL",
+                    "INCLUDE",
+                    "IGNORE"
+                  ],
+                  "type": "string"
+                },
+                "exactMatch": {
+                  "type": "boolean"
+                },
+                "parameters": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "caching": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "caching"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "behavior": {
+                  "enum": [
+                    "MAX_AGE",
+                    "NO_STORE",
+                    "BYPASS_CACHE",
+                    "CACHE_CONTROL_AND_EXPIRES",
+                    "CACHE_CONTROL",
+                    "EXPIRES"
+                  ],
+                  "type": "string"
+                },
+                "defaultTtl": {
+                  "pattern": "^[0-9]+[smhd]$",
+                  "type": "string"
+                },
+                "enhancedRfcSupport": {
+                  "type": "boolean"
+                },
+                "honorMustRevalidate": {
+                  "type": "boolean"
+                },
+                "honorPrivate": {
+                  "type": "boolean"
+                },
+                "mustRevalidate": {
+                  "type": "boolean"
+                },
+                "ttl": {
+                  "pattern": "^[0-9]+[smhd]$",
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "cpCode": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "cpCode"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "value": {
+                  "additionalProperties": false,
+                  "properties": {
+                    "createdDate": {
+                      "type": "integer"
+                    },
+                    "description": {
+                      "type": "string"
+                    },
+                    "id": {
+                      "minimum": 1,
+                      "type": "integer"
+                    },
+                    "name": {
+                      "type": "string"
+                    },
+                    "products": {
+                      "items": {
+                        "type": "string"
+                      },
+                      "type": "array"
+                    }
+                  },
+                  "required": [
+                    "id"
+                  ],
+                  "type": "object"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "downstreamCache": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "downstreamCache"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "allowBehavior": {
+                  "enum": [
+                    "LESSER",
+                    "GREATER",
+                    "REMAINING_LIFETIME",
+                    "FROM_MAX_AGE",
+                    "FROM_VALUE",
+                    "PASS_ORIGIN"
+                  ],
+                  "type": "string"
+                },
+                "behavior": {
+                  "enum": [
+                    "ALLOW",
+                    "MUST_REVALIDATE",
+                    "BUST",
+                    "TUNNEL_ORIGIN",
+                    "NONE"
+                  ],
+                  "type": "string"
+                },
+                "sendHeaders": {
+                  "enum": [
+                    "CACHE_CONTROL_AND_EXPIRES",
+                    "CACHE_CONTROL",
+                    "EXPIRES",
+                    "PASS_ORIGIN"
+                  ],
+                  "type": "string"
+                },
+                "sendPrivate": {
+                  "type": "boolean"
+                },
+                "ttl": {
+                  "pattern": "^[0-9]+[smhd]$",
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "gzipResponse": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "gzipResponse"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "behavior": {
+                  "enum": [
+                    "ORIGIN_RESPONSE",
+                    "ALWAYS",
+                    "NEVER"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "modifyOutgoingResponseHeader": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "modifyOutgoingResponseHeader"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "action": {
+                  "enum": [
+                    "ADD",
+                    "DELETE",
+                    "MODIFY",
+                    "REGEX"
+                  ],
+                  "type": "string"
+                },
+                "avoidDuplicateHeaders": {
+                  "type": "boolean"
+                },
+                "customHeaderName": {
+                  "pattern": "^[^()<>@,;:\\\\\"/\\[\\]?={}\\s]+$",
+                  "type": "string"
+                },
+                "headerValue": {
+                  "type": "string"
+                },
+                "newHeaderValue": {
+                  "type": "string"
+                },
+                "regexHeaderMatch": {
+                  "type": "string"
+                },
+                "regexHeaderReplace": {
+                  "type": "string"
+                },
+                "standardAddHeaderName": {
+                  "enum": [
+                    "CACHE_CONTROL",
+                    "CONTENT_TYPE",
+                    "EXPIRES",
+                    "OTHER"
+                  ],
+                  "type": "string"
+                },
+                "standardDeleteHeaderName": {
+                  "enum": [
+                    "CACHE_CONTROL",
+                    "CONTENT_TYPE",
+                    "VARY",
+                    "OTHER"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "origin": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "origin"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "cacheKeyHostname": {
+                  "enum": [
+                    "REQUEST_HOST_HEADER",
+                    "ORIGIN_HOSTNAME"
+                  ],
+                  "type": "string"
+                },
+                "compress": {
+                  "type": "boolean"
+                },
+                "customForwardHostHeader": {
+                  "type": "string"
+                },
+                "enableTrueClientIp": {
+                  "type": "boolean"
+                },
+                "forwardHostHeader": {
+                  "enum": [
+                    "REQUEST_HOST_HEADER",
+                    "ORIGIN_HOSTNAME",
+                    "CUSTOM"
+                  ],
+                  "type": "string"
+                },
+                "hostname": {
+                  "type": "string"
+                },
+                "httpPort": {
+                  "maximum": 65535,
+                  "minimum": 1,
+                  "type": "integer"
+                },
+                "httpsPort": {
+                  "maximum": 65535,
+                  "minimum": 1,
+                  "type": "integer"
+                },
+                "netStorage": {
+                  "additionalProperties": false,
+                  "properties": {
+                    "cpCode": {
+                      "type": "integer"
+                    },
+                    "downloadDomainName": {
+                      "type": "string"
+                    },
+                    "g2oToken": {
+                      "type": "string"
+                    }
+                  },
+                  "type": [
+                    "object",
+                    "null"
+                  ]
+                },
+                "originCertificate": {
+                  "type": "string"
+                },
+                "originSni": {
+                  "type": "boolean"
+                },
+                "originType": {
+                  "enum": [
+                    "CUSTOMER",
+                    "NET_STORAGE",
+                    "MEDIA_SERVICE_LIVE",
+                    "EDGE_LOAD_BALANCING_ORIGIN_GROUP",
+                    "SAAS_DYNAMIC_ORIGIN"
+                  ],
+                  "type": "string"
+                },
+                "ports": {
+                  "type": "string"
+                },
+                "trueClientIpClientSetting": {
+                  "type": "boolean"
+                },
+                "trueClientIpHeader": {
+                  "type": "string"
+                },
+                "verificationMode": {
+                  "enum": [
+                    "PLATFORM_SETTINGS",
+                    "CUSTOM",
+                    "THIRD_PARTY"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "prefetch": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "prefetch"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "enabled": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "prefetchable": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "prefetchable"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "enabled": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "removeVary": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "removeVary"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "enabled": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "report": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "report"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "cookies": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                },
+                "customLogField": {
+                  "type": "string"
+                },
+                "logAcceptLanguage": {
+                  "type": "boolean"
+                },
+                "logCookies": {
+                  "enum": [
+                    "OFF",
+                    "ALL",
+                    "SOME"
+                  ],
+                  "type": "string"
+                },
+                "logCustomLogField": {
+                  "type": "boolean"
+                },
+                "logHost": {
+                  "type": "boolean"
+                },
+                "logReferer": {
+                  "type": "boolean"
+                },
+                "logUserAgent": {
+                  "type": "boolean"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "siteShield": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "siteShield"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "ssmap": {
+                  "additionalProperties": false,
+                  "properties": {
+                    "hasMixedHosts": {
+                      "type": "boolean"
+                    },
+                    "src": {
+                      "type": "string"
+                    },
+                    "srmap": {
+                      "type": "string"
+                    },
+                    "value": {
+                      "type": "string"
+                    }
+                  },
+                  "type": "object"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "sureRoute": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "sureRoute"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "customStatKey": {
+                  "type": "string"
+                },
+                "enableCustomKey": {
+                  "type": "boolean"
+                },
+                "enabled": {
+                  "type": "boolean"
+                },
+                "forceSslForward": {
+                  "type": "boolean"
+                },
+                "raceStatTtl": {
+                  "enum": [
+                    "30m",
+                    "1h",
+                    "2h",
+                    "4h",
+                    "10h",
+                    "1d"
+                  ],
+                  "type": "string"
+                },
+                "testObjectUrl": {
+                  "type": "string"
+                },
+                "toHost": {
+                  "type": "string"
+                },
+                "toHostStatus": {
+                  "enum": [
+                    "INCOMING_HH",
+                    "OTHER"
+                  ],
+                  "type": "string"
+                },
+                "type": {
+                  "enum": [
+                    "PERFORMANCE",
+                    "CUSTOM_MAP"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "tieredDistribution": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "tieredDistribution"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "enabled": {
+                  "type": "boolean"
+                },
+                "tieredDistributionMap": {
+                  "enum": [
+                    "CH2",
+                    "CHAPAC",
+                    "CHEU2",
+                    "CHEUS2",
+                    "CHCUS2",
+                    "CHWUS2",
+                    "CHAUS",
+                    "CH"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        }
+      },
+      "criteria": {
+        "cacheability": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "cacheability"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchOperator": {
+                  "enum": [
+                    "IS",
+                    "IS_NOT"
+                  ],
+                  "type": "string"
+                },
+                "value": {
+                  "enum": [
+                    "NO_STORE",
+                    "BYPASS_CACHE",
+                    "CACHEABLE"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "contentType": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "contentType"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchCaseSensitive": {
+                  "type": "boolean"
+                },
+                "matchOperator": {
+                  "enum": [
+                    "IS_ONE_OF",
+                    "IS_NOT_ONE_OF"
+                  ],
+                  "type": "string"
+                },
+                "matchWildcard": {
+                  "type": "boolean"
+                },
+                "values": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "fileExtension": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "fileExtension"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchCaseSensitive": {
+                  "type": "boolean"
+                },
+                "matchOperator": {
+                  "enum": [
+                    "IS_ONE_OF",
+                    "IS_NOT_ONE_OF"
+                  ],
+                  "type": "string"
+                },
+                "values": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "hostname": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "hostname"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchOperator": {
+                  "enum": [
+                    "IS_ONE_OF",
+                    "IS_NOT_ONE_OF"
+                  ],
+                  "type": "string"
+                },
+                "values": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "path": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "path"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchCaseSensitive": {
+                  "type": "boolean"
+                },
+                "matchOperator": {
+                  "enum": [
+                    "MATCHES_ONE_OF",
+                    "DOES_NOT_MATCH_ONE_OF"
+                  ],
+                  "type": "string"
+                },
+                "values": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "requestHeader": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "requestHeader"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "headerName": {
+                  "type": "string"
+                },
+                "matchCaseSensitiveValue": {
+                  "type": "boolean"
+                },
+                "matchOperator": {
+                  "enum": [
+                    "IS_ONE_OF",
+                    "IS_NOT_ONE_OF",
+                    "EXISTS",
+                    "DOES_NOT_EXIST"
+                  ],
+                  "type": "string"
+                },
+                "matchWildcardName": {
+                  "type": "boolean"
+                },
+                "matchWildcardValue": {
+                  "type": "boolean"
+                },
+                "values": {
+                  "items": {
+                    "type": "string"
+                  },
+                  "type": "array"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "requestMethod": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "requestMethod"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "matchOperator": {
+                  "enum": [
+                    "IS",
+                    "IS_NOT"
+                  ],
+                  "type": "string"
+                },
+                "value": {
+                  "enum": [
+                    "GET",
+                    "POST",
+                    "HEAD",
+                    "PUT",
+                    "PATCH",
+                    "HTTP_DELETE",
+                    "OPTIONS"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        },
+        "requestProtocol": {
+          "additionalProperties": false,
+          "properties": {
+            "locked": {
+              "type": "boolean"
+            },
+            "name": {
+              "enum": [
+                "requestProtocol"
+              ]
+            },
+            "options": {
+              "additionalProperties": false,
+              "properties": {
+                "value": {
+                  "enum": [
+                    "HTTP",
+                    "HTTPS"
+                  ],
+                  "type": "string"
+                }
+              },
+              "type": "object"
+            },
+            "uuid": {
+              "type": "string"
+            }
+          },
+          "required": [
+            "name",
+            "options"
+          ],
+          "type": "object"
+        }
+      }
+    },
+    "type_behavior": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/catalog/behaviors/allowPost"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/allowTransferEncoding"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/cacheKeyQueryParams"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/caching"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/cpCode"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/downstreamCache"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/gzipResponse"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/modifyOutgoingResponseHeader"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/origin"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/prefetch"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/prefetchable"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/removeVary"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/report"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/siteShield"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/sureRoute"
+        },
+        {
+          "$ref": "#/definitions/catalog/behaviors/tieredDistribution"
+        }
+      ],
+      "type": "object"
+    },
+    "type_criteria": {
+      "anyOf": [
+        {
+          "$ref": "#/definitions/catalog/criteria/cacheability"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/contentType"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/fileExtension"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/hostname"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/path"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/requestHeader"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/requestMethod"
+        },
+        {
+          "$ref": "#/definitions/catalog/criteria/requestProtocol"
+        }
+      ],
+      "type": "object"
+    },
+    "type_rules": {
+      "additionalProperties": false,
+      "properties": {
+        "behaviors": {
+          "items": {
+            "$ref": "#/definitions/type_behavior"
+          },
+          "type": "array"
+        },
+        "children": {
+          "items": {
+            "$ref": "#/definitions/type_rules"
+          },
+          "type": "array"
+        },
+        "comments": {
+          "type": "string"
+        },
+        "criteria": {
+          "items": {
+            "$ref": "#/definitions/type_criteria"
+          },
+          "type": "array"
+        },
+        "criteriaMustSatisfy": {
+          "enum": [
+            "all",
+            "any"
+          ],
+          "type": "string"
+        },
+        "name": {
+          "type": "string"
+        },
+        "options": {
+          "additionalProperties": false,
+          "properties": {
+            "is_secure": {
+              "type": "boolean"
+            }
+          },
+          "type": "object"
+        },
+        "templateLink": {
+          "type": "string"
+        },
+        "templateUuid": {
+          "type": "string"
+        },
+        "uuid": {
+          "type": "string"
+        },
+        "variables": {
+          "items": {
+            "$ref": "#/definitions/type_variable"
+          },
+          "type": "array"
+        }
+      },
+      "required": [
+        "name"
+      ],
+      "type": "object"
+    },
+    "type_variable": {
+      "additionalProperties": false,
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "hidden": {
+          "type": "boolean"
+        },
+        "name": {
+          "pattern": "^PMUSER_[A-Z0-9_]+$",
+          "type": "string"
+        },
+        "sensitive": {
+          "type": "boolean"
+        },
+        "value": {
+          "type": "string"
+        }
+      },
+      "required": [
+        "name",
+        "value",
+        "hidden",
+        "sensitive"
+      ],
+      "type": "object"
+    }
+  },
+  "properties": {
+    "rules": {
+      "$ref": "#/definitions/type_rules"
+    }
+  },
+  "required": [
+    "rules"
+  ],
+  "type": "object"
+}
+`
@@ -0,0 +1,311 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	edge "github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/jsonhooks-v1"
+)
+
+// ruleTreeChange is a change of a rule tree at a path, where rules, behaviors, criteria and
+// variables are named instead of indexed, e.g. #/rules/children[Static]/behaviors[caching]/options/ttl
+type ruleTreeChange struct {
+	Path string
+	// Action is + for an addition, - for a removal and ~ for a change
+	Action string
+	Old    interface{}
+	New    interface{}
+}
+
+func (c ruleTreeChange) String() string {
+	switch c.Action {
+	case "+":
+		return fmt.Sprintf("+ %s: %s", c.Path, compactValue(c.New))
+	case "-":
+		return fmt.Sprintf("- %s: %s", c.Path, compactValue(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s => %s", c.Path, compactValue(c.Old), compactValue(c.New))
+}
+
+// formatRuleTreeChanges returns the rules_diff attribute, the summary of the changes then
+// the changes one per line
+func formatRuleTreeChanges(changes []ruleTreeChange) string {
+	lines := make([]string, 0, len(changes)+1)
+	lines = append(lines, summarizeRuleTreeChanges(changes))
+	for _, c := range changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ruleChangeKinds are the kinds of rule tree changes, with their plural
+var ruleChangeKinds = [][2]string{
+	{"rule", "rules"},
+	{"behavior", "behaviors"},
+	{"criterion", "criteria"},
+	{"variable", "variables"},
+	{"option", "options"},
+	{"order of rules or behaviors", "orders of rules or behaviors"},
+	{"other value", "other values"},
+}
+
+// ruleChangeKind returns the kind of the value at a change path
+func ruleChangeKind(path string) string {
+	last := path[strings.LastIndex(path, "/")+1:]
+	switch {
+	case strings.Contains(path, "/options/"), last == "options":
+		return "option"
+	case last == "behaviors", last == "children":
+		return "order of rules or behaviors"
+	case strings.HasPrefix(last, "behaviors["):
+		return "behavior"
+	case strings.HasPrefix(last, "criteria["):
+		return "criterion"
+	case strings.HasPrefix(last, "children["):
+		return "rule"
+	case strings.HasPrefix(last, "variables["):
+		return "variable"
+	}
+	return "other value"
+}
+
+// summarizeRuleTreeChanges counts the removed, overwritten and added rules, behaviors,
+// criteria and options
+func summarizeRuleTreeChanges(changes []ruleTreeChange) string {
+	counts := map[string]map[string]int{"-": {}, "~": {}, "+": {}}
+	for _, c := range changes {
+		counts[c.Action][ruleChangeKind(c.Path)]++
+	}
+
+	var parts []string
+	for _, action := range []struct{ op, verb string }{{"-", "removes"}, {"~", "overwrites"}, {"+", "adds"}} {
+		var kinds []string
+		for _, kind := range ruleChangeKinds {
+			switch n := counts[action.op][kind[0]]; n {
+			case 0:
+			case 1:
+				kinds = append(kinds, "1 "+kind[0])
+			default:
+				kinds = append(kinds, fmt.Sprintf("%d %s", n, kind[1]))
+			}
+		}
+		if len(kinds) > 0 {
+			parts = append(parts, action.verb+" "+strings.Join(kinds, ", "))
+		}
+	}
+
+	return "The rules change " + strings.Join(parts, "; ")
+}
+
+// getRulesDiff returns the changes between the old and new rules JSON of a property, once
+// merged with its cp_code, origin and standard behaviors as they would be saved
+func getRulesDiff(d interface{}, old, new string, correlationid string) ([]ruleTreeChange, error) {
+	var trees [2]interface{}
+	for i, rulesJSON := range []string{old, new} {
+		rules, err := getRulesForComp(d, rulesJSON, correlationid)
+		if err != nil {
+			return nil, err
+		}
+		rules.Etag = ""
+		body, err := jsonhooks.Marshal(rules)
+		if err != nil {
+			return nil, err
+		}
+		var tree map[string]interface{}
+		if err := json.Unmarshal(body, &tree); err != nil {
+			return nil, err
+		}
+		trees[i] = tree["rules"]
+	}
+
+	changes := diffRuleTrees(trees[0], trees[1])
+	edge.PrintfCorrelation("[DEBUG]", correlationid, fmt.Sprintf("  getRulesDiff %d changes\n%s", len(changes), formatRuleTreeChanges(changes)))
+	return changes, nil
+}
+
+// diffRuleTrees returns the changes between two default rules
+func diffRuleTrees(old, new interface{}) []ruleTreeChange {
+	var changes []ruleTreeChange
+	oldRule, ok1 := old.(map[string]interface{})
+	newRule, ok2 := new.(map[string]interface{})
+	if !ok1 || !ok2 {
+		diffValue(&changes, "#/rules", old, new, false)
+		return changes
+	}
+	diffRule(&changes, "#/rules", oldRule, newRule)
+	return changes
+}
+
+func diffRule(changes *[]ruleTreeChange, path string, old, new map[string]interface{}) {
+	lists := map[string]bool{"behaviors": true, "criteria": true, "variables": true, "children": true}
+	for _, k := range unionKeys(old, new) {
+		if !lists[k] {
+			diffValue(changes, path+"/"+k, old[k], new[k], false)
+		}
+	}
+
+	// the order of behaviors and child rules matters, later ones override earlier ones
+	diffNamed(changes, path+"/behaviors", old["behaviors"], new["behaviors"], true, diffEntry)
+	diffNamed(changes, path+"/criteria", old["criteria"], new["criteria"], false, diffEntry)
+	diffNamed(changes, path+"/variables", old["variables"], new["variables"], false, func(changes *[]ruleTreeChange, path string, old, new map[string]interface{}) {
+		diffValue(changes, path, old, new, false)
+	})
+	diffNamed(changes, path+"/children", old["children"], new["children"], true, diffRule)
+}
+
+// unorderedOptions are the options holding sets of strings or numbers, e.g. the values of
+// criteria, compared regardless of their order
+var unorderedOptions = map[string]bool{
+	"values":              true,
+	"parameters":          true,
+	"elements":            true,
+	"customValidCnValues": true,
+}
+
+// diffEntry diffs a behavior or criterion, whose unorderedOptions are compared as sets
+func diffEntry(changes *[]ruleTreeChange, path string, old, new map[string]interface{}) {
+	for _, k := range unionKeys(old, new) {
+		diffValue(changes, path+"/"+k, old[k], new[k], k == "options")
+	}
+}
+
+// diffNamed diffs lists of named objects by name, the same name repeated being told apart by
+// its occurrence, e.g. behaviors[modifyOutgoingResponseHeader#2]
+func diffNamed(changes *[]ruleTreeChange, path string, old, new interface{}, ordered bool, diff func(*[]ruleTreeChange, string, map[string]interface{}, map[string]interface{})) {
+	oldKeys, oldItems := namedItems(old)
+	newKeys, newItems := namedItems(new)
+
+	var oldCommon, newCommon []string
+	for _, k := range oldKeys {
+		if _, ok := newItems[k]; !ok {
+			*changes = append(*changes, ruleTreeChange{Path: path + "[" + k + "]", Action: "-", Old: oldItems[k]})
+		} else {
+			oldCommon = append(oldCommon, k)
+		}
+	}
+	for _, k := range newKeys {
+		o, ok := oldItems[k]
+		if !ok {
+			*changes = append(*changes, ruleTreeChange{Path: path + "[" + k + "]", Action: "+", New: newItems[k]})
+			continue
+		}
+		newCommon = append(newCommon, k)
+		if oldItem, ok := o.(map[string]interface{}); ok {
+			if newItem, ok := newItems[k].(map[string]interface{}); ok {
+				diff(changes, path+"["+k+"]", oldItem, newItem)
+				continue
+			}
+		}
+		diffValue(changes, path+"["+k+"]", o, newItems[k], false)
+	}
+
+	if ordered && !reflect.DeepEqual(oldCommon, newCommon) {
+		*changes = append(*changes, ruleTreeChange{Path: path, Action: "~", Old: oldCommon, New: newCommon})
+	}
+}
+
+// namedItems returns the keys of a list of named objects in order, and the objects by key
+func namedItems(list interface{}) ([]string, map[string]interface{}) {
+	items, _ := list.([]interface{})
+	keys := make([]string, 0, len(items))
+	byKey := make(map[string]interface{}, len(items))
+	seen := make(map[string]int)
+	for _, item := range items {
+		name := ""
+		if m, ok := item.(map[string]interface{}); ok {
+			name, _ = m["name"].(string)
+		}
+		seen[name]++
+		key := name
+		if seen[name] > 1 {
+			key = fmt.Sprintf("%s#%d", name, seen[name])
+		}
+		keys = append(keys, key)
+		byKey[key] = item
+	}
+	return keys, byKey
+}
+
+// diffValue diffs JSON values, objects by key, and the arrays of unorderedOptions as sets
+// when in options
+func diffValue(changes *[]ruleTreeChange, path string, old, new interface{}, options bool) {
+	oldMap, ok1 := old.(map[string]interface{})
+	newMap, ok2 := new.(map[string]interface{})
+	if ok1 && ok2 {
+		for _, k := range unionKeys(oldMap, newMap) {
+			o, inOld := oldMap[k]
+			n, inNew := newMap[k]
+			switch {
+			case !inOld:
+				*changes = append(*changes, ruleTreeChange{Path: path + "/" + k, Action: "+", New: n})
+			case !inNew:
+				*changes = append(*changes, ruleTreeChange{Path: path + "/" + k, Action: "-", Old: o})
+			default:
+				diffValue(changes, path+"/"+k, o, n, options)
+			}
+		}
+		return
+	}
+
+	if options && unorderedOptions[path[strings.LastIndex(path, "/")+1:]] {
+		if o, ok := scalarSet(old); ok {
+			if n, ok := scalarSet(new); ok && reflect.DeepEqual(o, n) {
+				return
+			}
+		}
+	}
+	if !reflect.DeepEqual(old, new) {
+		action := "~"
+		if old == nil {
+			action = "+"
+		} else if new == nil {
+			action = "-"
+		}
+		*changes = append(*changes, ruleTreeChange{Path: path, Action: action, Old: old, New: new})
+	}
+}
+
+// scalarSet returns an array of strings or numbers sorted, or false for other values
+func scalarSet(value interface{}) ([]string, bool) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	set := make([]string, 0, len(list))
+	for _, v := range list {
+		switch v.(type) {
+		case string, float64, bool:
+			set = append(set, compactValue(v))
+		default:
+			return nil, false
+		}
+	}
+	sort.Strings(set)
+	return set, true
+}
+
+func unionKeys(old, new map[string]interface{}) []string {
+	keys := make([]string, 0, len(old)+len(new))
+	for k := range old {
+		keys = append(keys, k)
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func compactValue(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,127 @@
+package property
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func diffRulesJSON(t *testing.T, old, new string) []string {
+	var oldTree, newTree interface{}
+	require.NoError(t, json.Unmarshal([]byte(old), &oldTree))
+	require.NoError(t, json.Unmarshal([]byte(new), &newTree))
+	var changes []string
+	for _, c := range diffRuleTrees(oldTree, newTree) {
+		changes = append(changes, c.String())
+	}
+	return changes
+}
+
+func TestDiffRuleTrees(t *testing.T) {
+	old := `{
+		"name": "default",
+		"options": {"is_secure": false},
+		"behaviors": [
+			{"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 80}},
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}},
+			{"name": "gzipResponse", "options": {"behavior": "ALWAYS"}}
+		],
+		"children": [
+			{"name": "Static", "criteria": [{"name": "fileExtension", "options": {"values": ["css", "js", "png"]}}]},
+			{"name": "API", "behaviors": [{"name": "caching", "options": {"behavior": "NO_STORE"}}]}
+		]
+	}`
+
+	assert.Empty(t, diffRulesJSON(t, old, `{
+		"name": "default",
+		"options": {"is_secure": false},
+		"behaviors": [
+			{"name": "origin", "options": {"httpPort": 80, "hostname": "origin.example.com"}},
+			{"name": "caching", "options": {"ttl": "1d", "behavior": "MAX_AGE"}},
+			{"name": "gzipResponse", "options": {"behavior": "ALWAYS"}}
+		],
+		"children": [
+			{"name": "Static", "criteria": [{"name": "fileExtension", "options": {"values": ["png", "css", "js"]}}]},
+			{"name": "API", "behaviors": [{"name": "caching", "options": {"behavior": "NO_STORE"}}]}
+		]
+	}`))
+
+	assert.Equal(t, []string{
+		"~ #/rules/options/is_secure: false => true",
+		"- #/rules/behaviors[gzipResponse]: {\"name\":\"gzipResponse\",\"options\":{\"behavior\":\"ALWAYS\"}}",
+		"~ #/rules/behaviors[caching]/options/ttl: \"1d\" => \"7d\"",
+		"- #/rules/behaviors[origin]/options/httpPort: 80",
+		"+ #/rules/behaviors[origin]/options/httpsPort: 443",
+		"+ #/rules/behaviors[prefetch]: {\"name\":\"prefetch\",\"options\":{\"enabled\":true}}",
+		"~ #/rules/behaviors: [\"origin\",\"caching\"] => [\"caching\",\"origin\"]",
+		"- #/rules/children[API]: {\"behaviors\":[{\"name\":\"caching\",\"options\":{\"behavior\":\"NO_STORE\"}}],\"name\":\"API\"}",
+		"~ #/rules/children[Static]/criteria[fileExtension]/options/values: [\"css\",\"js\",\"png\"] => [\"svg\",\"css\",\"js\"]",
+		"+ #/rules/children[Images]: {\"name\":\"Images\"}",
+	}, diffRulesJSON(t, old, `{
+		"name": "default",
+		"options": {"is_secure": true},
+		"behaviors": [
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "7d"}},
+			{"name": "origin", "options": {"hostname": "origin.example.com", "httpsPort": 443}},
+			{"name": "prefetch", "options": {"enabled": true}}
+		],
+		"children": [
+			{"name": "Static", "criteria": [{"name": "fileExtension", "options": {"values": ["svg", "css", "js"]}}]},
+			{"name": "Images"}
+		]
+	}`))
+}
+
+func TestDiffRuleTreesOrderedOptions(t *testing.T) {
+	// only the known sets of options are compared regardless of their order
+	assert.Equal(t, []string{
+		"~ #/rules/behaviors[cacheId]/options/elements: [\"a\",\"b\"] => [\"b\",\"c\"]",
+		"~ #/rules/behaviors[edgeWorker]/options/list: [\"a\",\"b\"] => [\"b\",\"a\"]",
+	}, diffRulesJSON(t,
+		`{"name": "default", "behaviors": [
+			{"name": "cacheKeyQueryParams", "options": {"parameters": ["a", "b"]}},
+			{"name": "cacheId", "options": {"elements": ["a", "b"]}},
+			{"name": "edgeWorker", "options": {"list": ["a", "b"]}}
+		]}`,
+		`{"name": "default", "behaviors": [
+			{"name": "cacheKeyQueryParams", "options": {"parameters": ["b", "a"]}},
+			{"name": "cacheId", "options": {"elements": ["b", "c"]}},
+			{"name": "edgeWorker", "options": {"list": ["b", "a"]}}
+		]}`,
+	))
+}
+
+func TestDiffRuleTreesRepeatedBehaviors(t *testing.T) {
+	header := func(name string) string {
+		return `{"name": "modifyOutgoingResponseHeader", "options": {"customHeaderName": "` + name + `"}}`
+	}
+	assert.Equal(t, []string{
+		"~ #/rules/behaviors[modifyOutgoingResponseHeader#2]/options/customHeaderName: \"X-B\" => \"X-C\"",
+	}, diffRulesJSON(t,
+		`{"name": "default", "behaviors": [`+header("X-A")+`, `+header("X-B")+`]}`,
+		`{"name": "default", "behaviors": [`+header("X-A")+`, `+header("X-C")+`]}`,
+	))
+}
+
+func TestSummarizeRuleTreeChanges(t *testing.T) {
+	assert.Equal(t, "The rules change removes 1 behavior; overwrites 2 options, 1 order of rules or behaviors; adds 1 rule", summarizeRuleTreeChanges([]ruleTreeChange{
+		{Path: "#/rules/children[Images]", Action: "+"},
+		{Path: "#/rules/behaviors[gzipResponse]", Action: "-"},
+		{Path: "#/rules/behaviors", Action: "~"},
+		{Path: "#/rules/behaviors[caching]/options/ttl", Action: "~"},
+		{Path: "#/rules/options/is_secure", Action: "~"},
+	}))
+	assert.Equal(t, "The rules change adds 2 rules, 1 criterion", summarizeRuleTreeChanges([]ruleTreeChange{
+		{Path: "#/rules/children[Images]", Action: "+"},
+		{Path: "#/rules/children[Video]", Action: "+"},
+		{Path: "#/rules/children[Static]/criteria[path]", Action: "+"},
+	}))
+}
+
+func TestFormatRuleTreeChanges(t *testing.T) {
+	assert.Equal(t, "The rules change removes 1 rule\n- #/rules/children[API]: {\"name\":\"API\"}", formatRuleTreeChanges([]ruleTreeChange{
+		{Path: "#/rules/children[API]", Action: "-", Old: map[string]interface{}{"name": "API"}},
+	}))
+}
@@ -0,0 +1,281 @@
+package property
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// maxRuleDepth is the number of levels of a rule tree, the default rule included
+const maxRuleDepth = 5
+
+// ruleTreeError is an error of a rule tree at a JSON path, e.g. #/rules/children/0/behaviors/1
+type ruleTreeError struct {
+	Path    string
+	Message string
+}
+
+func (e ruleTreeError) String() string {
+	return e.Path + ": " + e.Message
+}
+
+// ruleTreeValidator collects the errors of a rule tree against a rule format schema, and
+// the warnings for behaviors and criteria the schema doesn't know
+type ruleTreeValidator struct {
+	format   *ruleFormat
+	errors   []ruleTreeError
+	warnings []ruleTreeError
+}
+
+// validatePropertyRules validates the rules of a property against the schema of its rule
+// format at plan time, failing when the schema can't be fetched or read from the cache.
+// Behaviors and criteria missing from the schema are only logged, as PAPI validates them on
+// save anyway.
+func validatePropertyRules(d *schema.ResourceDiff) error {
+	if !d.NewValueKnown("rules") || !d.NewValueKnown("rule_format") || !d.NewValueKnown("product") {
+		return nil
+	}
+	rules, format := d.Get("rules").(string), d.Get("rule_format").(string)
+	if rules == "" || format == "" {
+		return nil
+	}
+
+	var tree interface{}
+	if err := json.Unmarshal([]byte(rules), &tree); err != nil {
+		return nil
+	}
+	f, err := getRuleFormat(d.Get("product").(string), format)
+	if err != nil {
+		return fmt.Errorf("validating rules: no schema of rule format %s for product %s: %s", format, d.Get("product"), err)
+	}
+
+	errs, warnings := validateRuleTree(f, tree)
+	for _, w := range warnings {
+		log.Printf("[WARN] [PAPI] Not validating rules at %s", w)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	msg := make([]string, 0, len(errs))
+	for _, e := range errs {
+		msg = append(msg, "\n  "+e.String())
+	}
+	return fmt.Errorf("rules are invalid for rule format %s:%s", format, strings.Join(msg, ""))
+}
+
+// validateRuleTree returns every error of the rules JSON against a rule format schema, and
+// a warning for each behavior and criterion missing from the schema
+func validateRuleTree(f *ruleFormat, tree interface{}) ([]ruleTreeError, []ruleTreeError) {
+	v := &ruleTreeValidator{format: f}
+	root, ok := tree.(map[string]interface{})
+	if !ok {
+		v.fail("#", "must be an object")
+		return v.errors, v.warnings
+	}
+	rules, ok := root["rules"]
+	if !ok {
+		v.fail("#", "rules is required")
+		return v.errors, v.warnings
+	}
+	v.rule(rules, "#/rules", 1)
+	return v.errors, v.warnings
+}
+
+func (v *ruleTreeValidator) fail(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, ruleTreeError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *ruleTreeValidator) warn(path, format string, args ...interface{}) {
+	v.warnings = append(v.warnings, ruleTreeError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *ruleTreeValidator) rule(r interface{}, path string, depth int) {
+	rule, ok := r.(map[string]interface{})
+	if !ok {
+		v.fail(path, "rule must be an object")
+		return
+	}
+	if depth > maxRuleDepth {
+		v.fail(path, "rule is nested %d levels deep, over the limit of %d", depth, maxRuleDepth)
+		return
+	}
+
+	if name, ok := rule["name"].(string); !ok || name == "" {
+		v.fail(path, "rule name is required")
+	}
+	if c, ok := rule["criteriaMustSatisfy"]; ok && c != "all" && c != "any" {
+		v.fail(path+"/criteriaMustSatisfy", "must be all or any, got %v", c)
+	}
+
+	for i, b := range v.list(rule, "behaviors", path) {
+		v.entry(b, fmt.Sprintf("%s/behaviors/%d", path, i), v.format.Behaviors, "behavior")
+	}
+	criteria := v.list(rule, "criteria", path)
+	if depth == 1 && len(criteria) > 0 {
+		v.fail(path+"/criteria", "the default rule can not have criteria")
+	} else {
+		for i, c := range criteria {
+			v.entry(c, fmt.Sprintf("%s/criteria/%d", path, i), v.format.Criteria, "criterion")
+		}
+	}
+	for i, child := range v.list(rule, "children", path) {
+		v.rule(child, fmt.Sprintf("%s/children/%d", path, i), depth+1)
+	}
+}
+
+// list returns an array of a rule, which may be missing
+func (v *ruleTreeValidator) list(rule map[string]interface{}, key, path string) []interface{} {
+	value, ok := rule[key]
+	if !ok || value == nil {
+		return nil
+	}
+	list, ok := value.([]interface{})
+	if !ok {
+		v.fail(path+"/"+key, "must be an array")
+	}
+	return list
+}
+
+// entry validates a behavior or criterion, and its options. Names missing from the schema
+// are left for PAPI to judge.
+func (v *ruleTreeValidator) entry(e interface{}, path string, catalog map[string]*jsonSchema, kind string) {
+	entry, ok := e.(map[string]interface{})
+	if !ok {
+		v.fail(path, "%s must be an object", kind)
+		return
+	}
+	name, _ := entry["name"].(string)
+	options, ok := catalog[name]
+	if !ok {
+		v.warn(path+"/name", "unknown %s %q in rule format %s", kind, name, v.format.Name)
+		return
+	}
+	if value, ok := entry["options"]; ok {
+		v.value(value, path+"/options", options)
+	}
+}
+
+// value validates an option value against its schema
+func (v *ruleTreeValidator) value(value interface{}, path string, s *jsonSchema) {
+	if types := s.types(); len(types) > 0 || s.Type != nil {
+		if !matchesType(value, s.Type) {
+			v.fail(path, "must be %s, got %s", strings.Join(s.types(), " or "), jsonType(value))
+			return
+		}
+	}
+	if value == nil {
+		return
+	}
+
+	switch value := value.(type) {
+	case string:
+		// option values with variables are resolved at the edge
+		if strings.Contains(value, "{{") {
+			return
+		}
+		if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+			v.fail(path, "must be one of %s, got %q", strings.Join(s.enumStrings(), ", "), value)
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(value) {
+				v.fail(path, "must match %s, got %q", s.Pattern, value)
+			}
+		}
+	case float64:
+		if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+			v.fail(path, "must be one of %v, got %v", s.Enum, value)
+		}
+		if s.Minimum != nil && value < *s.Minimum {
+			v.fail(path, "must be at least %v, got %v", *s.Minimum, value)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			v.fail(path, "must be at most %v, got %v", *s.Maximum, value)
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range value {
+				v.value(item, fmt.Sprintf("%s/%d", path, i), s.Items)
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for k := range value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if option, ok := s.Properties[k]; ok {
+				v.value(value[k], path+"/"+k, option)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				v.fail(path+"/"+k, "unknown option %q", k)
+			}
+		}
+		for _, k := range s.Required {
+			if _, ok := value[k]; !ok {
+				v.fail(path, "missing required option %q", k)
+			}
+		}
+	}
+}
+
+// matchesType returns whether a JSON value is of one of the types of a schema
+func matchesType(value interface{}, types interface{}) bool {
+	var list []string
+	switch t := types.(type) {
+	case nil:
+		return true
+	case string:
+		list = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			if v, ok := v.(string); ok {
+				list = append(list, v)
+			}
+		}
+	}
+	actual := jsonType(value)
+	for _, t := range list {
+		switch {
+		case t == actual:
+			return true
+		case t == "number" && actual == "integer":
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType returns the JSON schema type of a value
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if value == math.Trunc(value) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, e := range enum {
+		if e == value {
+			return true
+		}
+	}
+	return false
+}
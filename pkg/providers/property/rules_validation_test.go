@@ -0,0 +1,178 @@
+package property
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	papi "github.com/akamai/AkamaiOPEN-edgegrid-golang/papi-v1"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validateRulesJSON(t *testing.T, f *ruleFormat, rules string) []string {
+	errs, _ := validateRulesJSONWarnings(t, f, rules)
+	return errs
+}
+
+func validateRulesJSONWarnings(t *testing.T, f *ruleFormat, rules string) ([]string, []string) {
+	var tree interface{}
+	require.NoError(t, json.Unmarshal([]byte(rules), &tree))
+	errs, warnings := validateRuleTree(f, tree)
+	var errStrings, warningStrings []string
+	for _, e := range errs {
+		errStrings = append(errStrings, e.String())
+	}
+	for _, w := range warnings {
+		warningStrings = append(warningStrings, w.String())
+	}
+	return errStrings, warningStrings
+}
+
+func TestValidateRuleTree(t *testing.T) {
+	f, err := getBundledRuleFormat("v2020-03-04")
+	require.NoError(t, err)
+
+	assert.Empty(t, validateRulesJSON(t, f, `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "origin", "options": {"hostname": "origin.example.com", "originType": "CUSTOMER", "httpPort": 80, "netStorage": null}},
+			{"name": "cpCode", "options": {"value": {"id": 12345}}},
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "{{user.PMUSER_TTL}}"}}
+		],
+		"children": [{
+			"name": "static",
+			"criteriaMustSatisfy": "any",
+			"criteria": [{"name": "path", "options": {"matchOperator": "MATCHES_ONE_OF", "values": ["/static/*"]}}]
+		}]
+	}}`))
+
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0/options/httpPort: must be at most 65535, got 70000",
+		"#/rules/behaviors/0/options/originType: must be one of CUSTOMER, NET_STORAGE, MEDIA_SERVICE_LIVE, EDGE_LOAD_BALANCING_ORIGIN_GROUP, SAAS_DYNAMIC_ORIGIN, got \"S3\"",
+		"#/rules/behaviors/0/options/port: unknown option \"port\"",
+		"#/rules/behaviors/1/options/value: missing required option \"id\"",
+		"#/rules/behaviors/2/options/ttl: must match ^[0-9]+[smhd]$, got \"1 day\"",
+		"#/rules/criteria: the default rule can not have criteria",
+		"#/rules/children/0: rule name is required",
+		"#/rules/children/0/criteriaMustSatisfy: must be all or any, got some",
+		"#/rules/children/0/criteria/0/options/values/0: must be string, got integer",
+	}, validateRulesJSON(t, f, `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "origin", "options": {"originType": "S3", "httpPort": 70000, "port": 80}},
+			{"name": "cpCode", "options": {"value": {"name": "site"}}},
+			{"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1 day"}}
+		],
+		"criteria": [{"name": "path", "options": {"values": ["/"]}}],
+		"children": [{
+			"criteriaMustSatisfy": "some",
+			"criteria": [{"name": "path", "options": {"values": [1]}}]
+		}]
+	}}`))
+}
+
+func TestValidateRuleTreeUnknownNames(t *testing.T) {
+	f, err := getBundledRuleFormat("v2020-03-04")
+	require.NoError(t, err)
+
+	// behaviors and criteria outside of the builder subset are valid for PAPI
+	errs, warnings := validateRulesJSONWarnings(t, f, `{"rules": {
+		"name": "default",
+		"behaviors": [
+			{"name": "http2", "options": {"enabled": ""}},
+			{"name": "edgeWorkers", "options": {"enabled": true, "edgeWorkerId": "42"}},
+			{"name": "origin", "options": {"httpPort": 80}}
+		],
+		"children": [{
+			"name": "images",
+			"criteria": [{"name": "matchVariable", "options": {"variableName": "PMUSER_IMAGES"}}],
+			"behaviors": [{"name": "imageManager", "options": {"enabled": true}}]
+		}]
+	}}`)
+	assert.Empty(t, errs)
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0/name: unknown behavior \"http2\" in rule format v2020-03-04",
+		"#/rules/behaviors/1/name: unknown behavior \"edgeWorkers\" in rule format v2020-03-04",
+		"#/rules/children/0/behaviors/0/name: unknown behavior \"imageManager\" in rule format v2020-03-04",
+		"#/rules/children/0/criteria/0/name: unknown criterion \"matchVariable\" in rule format v2020-03-04",
+	}, warnings)
+}
+
+func TestValidateRuleTreeDepth(t *testing.T) {
+	f, err := getBundledRuleFormat("v2020-03-04")
+	require.NoError(t, err)
+
+	rule := `{"name": "level6"}`
+	for _, name := range []string{"level5", "level4", "level3", "level2", "default"} {
+		rule = `{"name": "` + name + `", "children": [` + rule + `]}`
+	}
+	assert.Equal(t, []string{
+		"#/rules/children/0/children/0/children/0/children/0/children/0: rule is nested 6 levels deep, over the limit of 5",
+	}, validateRulesJSON(t, f, `{"rules": `+rule+`}`))
+}
+
+// testRuleFormatCache caches the rule formats in a temporary directory, returning the
+// function removing it
+func testRuleFormatCache(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "rule-formats")
+	require.NoError(t, err)
+	cacheDir := ruleFormatCacheDir
+	ruleFormatCacheDir = func() string { return dir }
+	return func() {
+		ruleFormatCacheDir = cacheDir
+		os.RemoveAll(dir)
+	}
+}
+
+func TestGetRuleFormat(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	defer testRuleFormatCache(t)()
+	papi.Config = srv.Config()
+
+	f, err := getRuleFormat("prd_SPM", "v2019-07-25")
+	require.NoError(t, err)
+	assert.Contains(t, f.Behaviors, "origin")
+	assert.Contains(t, f.Criteria, "path")
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0/options/httpPort: must be integer, got string",
+	}, validateRulesJSON(t, f, `{"rules": {"name": "default", "behaviors": [{"name": "origin", "options": {"httpPort": "80"}}]}}`))
+
+	// $ref are resolved, recursive ones take any value
+	assert.Equal(t, []string{
+		"#/rules/behaviors/0/options/httpPort: must be at most 65535, got 70000",
+		"#/rules/behaviors/1/options/value/id: must be integer, got string",
+	}, validateRulesJSON(t, f, `{"rules": {"name": "default", "behaviors": [
+		{"name": "origin", "options": {"httpPort": 70000}},
+		{"name": "cpCode", "options": {"value": {"id": "1", "parent": {"id": 2, "parent": "any"}}}}
+	]}}`))
+
+	cached, err := getRuleFormat("prd_SPM", "v2019-07-25")
+	require.NoError(t, err)
+	assert.True(t, f == cached)
+
+	// the bundled rule format is only for the builder, rules are validated against PAPI's
+	f, err = getRuleFormat("prd_SPM", mockapi.RuleFormat)
+	require.NoError(t, err)
+	bundled, err := getBundledRuleFormat(mockapi.RuleFormat)
+	require.NoError(t, err)
+	assert.False(t, f == bundled)
+	assert.Empty(t, validateRulesJSON(t, f, `{"rules": {"name": "default", "behaviors": [{"name": "http2", "options": {"enabled": ""}}]}}`))
+
+	_, err = getRuleFormat("prd_SPM", "v1999-01-01")
+	assert.Error(t, err)
+
+	// the schemas fetched before are read from the cache when PAPI can't be reached
+	ruleFormats.Delete("prd_SPM/v2019-07-25")
+	ruleFormats.Delete("prd_SPM/" + mockapi.RuleFormat)
+	srv.Close()
+	f, err = getRuleFormat("prd_SPM", "v2019-07-25")
+	require.NoError(t, err)
+	assert.Contains(t, f.Behaviors, "origin")
+	_, err = getRuleFormat("prd_WAA", "v2019-07-25")
+	assert.Error(t, err)
+}
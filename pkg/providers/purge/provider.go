@@ -0,0 +1,141 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	ccu "github.com/akamai/AkamaiOPEN-edgegrid-golang/ccu-v3"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/edgegrid"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/config"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type (
+	provider struct {
+		*schema.Provider
+	}
+)
+
+var (
+	once sync.Once
+
+	inst *provider
+)
+
+// Subprovider returns a core sub provider
+func Subprovider() akamai.Subprovider {
+	once.Do(func() {
+		inst = &provider{Provider: Provider()}
+	})
+
+	return inst
+}
+
+// Provider returns the Akamai terraform.Resource provider.
+func Provider() *schema.Provider {
+
+	provider := &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"purge_section": {
+				Optional:   true,
+				Type:       schema.TypeString,
+				Default:    "default",
+				Deprecated: akamai.NoticeDeprecatedUseAlias("purge_section"),
+			},
+			"purge": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem:     config.Options("purge"),
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{},
+		ResourcesMap: map[string]*schema.Resource{
+			"akamai_fastpurge": resourceFastPurge(),
+		},
+	}
+
+	return provider
+}
+
+type resourceData interface {
+	GetOk(string) (interface{}, bool)
+	Get(string) interface{}
+}
+
+type set interface {
+	List() []interface{}
+}
+
+func getConfigPurgeService(d resourceData) (*edgegrid.Config, error) {
+	var PurgeConfig edgegrid.Config
+	if _, ok := d.GetOk("purge"); ok {
+		log.Printf("[DEBUG] Setting purge config via HCL")
+		config := d.Get("purge").(set).List()[0].(map[string]interface{})
+
+		PurgeConfig = edgegrid.Config{
+			Host:         config["host"].(string),
+			AccessToken:  config["access_token"].(string),
+			ClientToken:  config["client_token"].(string),
+			ClientSecret: config["client_secret"].(string),
+			MaxBody:      config["max_body"].(int),
+		}
+
+		ccu.Init(PurgeConfig)
+		return &PurgeConfig, nil
+	}
+
+	var err error
+
+	edgerc := d.Get("edgerc").(string)
+
+	if section, ok := d.GetOk("purge_section"); ok && section != "default" {
+		PurgeConfig, err = edgegrid.Init(edgerc, section.(string))
+	} else {
+		PurgeConfig, err = edgegrid.Init(edgerc, d.Get("config_section").(string))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ccu.Init(PurgeConfig)
+	return &PurgeConfig, nil
+}
+
+func (p *provider) Name() string {
+	return "purge"
+}
+
+// PurgeProviderVersion update version string anytime provider adds new features
+const PurgeProviderVersion string = "v0.1.0"
+
+func (p *provider) Version() string {
+	return PurgeProviderVersion
+}
+
+func (p *provider) Schema() map[string]*schema.Schema {
+	return p.Provider.Schema
+}
+
+func (p *provider) Resources() map[string]*schema.Resource {
+	return p.Provider.ResourcesMap
+}
+
+func (p *provider) DataSources() map[string]*schema.Resource {
+	return p.Provider.DataSourcesMap
+}
+
+func (p *provider) Configure(ctx context.Context, log hclog.Logger, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	log.Named(p.Name()).Debug("START Configure")
+
+	cfg, err := getConfigPurgeService(d)
+	if err != nil {
+		return nil, nil
+	}
+
+	return cfg, nil
+}
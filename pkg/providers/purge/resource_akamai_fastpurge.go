@@ -0,0 +1,151 @@
+package purge
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	ccu "github.com/akamai/AkamaiOPEN-edgegrid-golang/ccu-v3"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/akamai"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/apiclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	actionInvalidate = "invalidate"
+	actionDelete     = "delete"
+)
+
+// sleep waits out the purge estimate, tests replace it to return immediately
+var sleep = time.Sleep
+
+// Fast Purge (CCU v3)
+//
+// https://developer.akamai.com/api/core_features/fast_purge/v3.html
+func resourceFastPurge() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceFastPurgeCreate,
+		Read:   resourceFastPurgeRead,
+		Delete: resourceFastPurgeDelete,
+		Schema: map[string]*schema.Schema{
+			"objects": {
+				Type:     schema.TypeSet,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(ccu.PurgeByUrl),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(ccu.PurgeByUrl),
+					string(ccu.PurgeByCpCode),
+					string(ccu.PurgeByCacheTag),
+				}, false),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      actionInvalidate,
+				ValidateFunc: validation.StringInSlice([]string{actionInvalidate, actionDelete}, false),
+			},
+			"network": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  string(ccu.NetworkProduction),
+				ValidateFunc: validation.StringInSlice([]string{
+					string(ccu.NetworkStaging),
+					string(ccu.NetworkProduction),
+				}, false),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that purge the objects again when they change",
+			},
+			"purge_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"support_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"estimated_seconds": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceFastPurgeCreate(d *schema.ResourceData, meta interface{}) error {
+	akactx := akamai.ContextGet(inst.Name())
+	log := akactx.Log("PURGE", "resourceFastPurgeCreate")
+
+	purgeType := ccu.PurgeTypeValue(d.Get("type").(string))
+	network := ccu.NetworkValue(d.Get("network").(string))
+	action := d.Get("action").(string)
+
+	objects := make([]string, 0)
+	for _, o := range d.Get("objects").(*schema.Set).List() {
+		object := o.(string)
+		if purgeType == ccu.PurgeByCpCode {
+			object = strings.TrimPrefix(object, "cpc_")
+		}
+		objects = append(objects, object)
+	}
+	sort.Strings(objects)
+
+	log.Debug(fmt.Sprintf("%s %d %s objects on %s", action, len(objects), purgeType, network))
+	purge := ccu.NewPurge(objects)
+	var res *ccu.PurgeResponse
+	var err error
+	if action == actionDelete {
+		res, err = purge.Delete(purgeType, network)
+	} else {
+		res, err = purge.Invalidate(purgeType, network)
+	}
+	if err != nil {
+		log.Error(fmt.Sprintf("purge failed: %s", err))
+		return apiclient.Error(err)
+	}
+
+	d.SetId(res.PurgeID)
+	attrs := map[string]interface{}{
+		"purge_id":          res.PurgeID,
+		"support_id":        res.SupportID,
+		"estimated_seconds": res.EstimatedSeconds,
+	}
+	for k, v := range attrs {
+		if err := d.Set(k, v); err != nil {
+			return err
+		}
+	}
+
+	// CCU has no status endpoint, the purge is done once its estimate has elapsed
+	log.Debug(fmt.Sprintf("purge %s accepted, waiting %d seconds", res.PurgeID, res.EstimatedSeconds))
+	sleep(time.Duration(res.EstimatedSeconds) * time.Second)
+
+	return nil
+}
+
+// Purges can't be read back, the state only records the last request
+func resourceFastPurgeRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// Purges can't be undone, destroying the resource only removes it from the state
+func resourceFastPurgeDelete(d *schema.ResourceData, meta interface{}) error {
+	d.SetId("")
+	return nil
+}
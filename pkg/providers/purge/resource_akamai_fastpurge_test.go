@@ -0,0 +1,134 @@
+package purge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	ccu "github.com/akamai/AkamaiOPEN-edgegrid-golang/ccu-v3"
+	"github.com/akamai/terraform-provider-akamai/v2/pkg/mockapi"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAkamaiFastPurge_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAkamaiFastPurgeConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("akamai_fastpurge.test", "purge_id"),
+					resource.TestCheckResourceAttr("akamai_fastpurge.test", "network", "staging"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAkamaiFastPurgeConfig = `
+provider "akamai" {
+  purge_section = "ccu"
+}
+
+resource "akamai_fastpurge" "test" {
+  objects = ["https://www.example.com/index.html"]
+  network = "staging"
+}
+`
+
+// purgeRequest records a request received by fakeCCU
+type purgeRequest struct {
+	Action  string
+	Type    string
+	Network string
+	Objects []string
+}
+
+// fakeCCU serves the Fast Purge endpoints from the mock server
+type fakeCCU struct {
+	lock     sync.Mutex
+	requests []purgeRequest
+}
+
+func newFakeCCU(srv *mockapi.Server) *fakeCCU {
+	f := &fakeCCU{}
+	srv.Handle("POST", `/ccu/v3/(invalidate|delete)/(url|cpcode|tag)/(staging|production)`, f.purge)
+	return f
+}
+
+func (f *fakeCCU) purge(w http.ResponseWriter, r *http.Request, params []string) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var body struct {
+		Objects []string `json:"objects"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Objects) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	f.requests = append(f.requests, purgeRequest{Action: params[0], Type: params[1], Network: params[2], Objects: body.Objects})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"detail":           "Request accepted",
+		"estimatedSeconds": 5,
+		"httpStatus":       http.StatusCreated,
+		"purgeId":          fmt.Sprintf("purge-%d", len(f.requests)),
+		"supportId":        "17PY1522094889114372-178558144",
+	})
+}
+
+func TestResourceFastPurge(t *testing.T) {
+	srv := mockapi.NewServer()
+	defer srv.Close()
+	defer srv.Install()()
+	fake := newFakeCCU(srv)
+	ccu.Init(srv.Config())
+
+	var waited time.Duration
+	sleep = func(d time.Duration) { waited += d }
+	defer func() { sleep = time.Sleep }()
+
+	d := schema.TestResourceDataRaw(t, resourceFastPurge().Schema, map[string]interface{}{
+		"objects":  []interface{}{"https://www.example.com/b", "https://www.example.com/a"},
+		"triggers": map[string]interface{}{"activation": "atv_1"},
+	})
+	require.NoError(t, resourceFastPurgeCreate(d, nil))
+	assert.Equal(t, "purge-1", d.Id())
+	assert.Equal(t, 5, d.Get("estimated_seconds"))
+	assert.Equal(t, 5*time.Second, waited)
+	require.Len(t, fake.requests, 1)
+	assert.Equal(t, purgeRequest{
+		Action:  actionInvalidate,
+		Type:    "url",
+		Network: "production",
+		Objects: []string{"https://www.example.com/a", "https://www.example.com/b"},
+	}, fake.requests[0])
+
+	cpcodes := schema.TestResourceDataRaw(t, resourceFastPurge().Schema, map[string]interface{}{
+		"objects": []interface{}{"cpc_12345", "67890"},
+		"type":    "cpcode",
+		"action":  actionDelete,
+		"network": "staging",
+	})
+	require.NoError(t, resourceFastPurgeCreate(cpcodes, nil))
+	assert.Equal(t, purgeRequest{
+		Action:  actionDelete,
+		Type:    "cpcode",
+		Network: "staging",
+		Objects: []string{"12345", "67890"},
+	}, fake.requests[1])
+
+	require.NoError(t, resourceFastPurgeDelete(cpcodes, nil))
+	assert.Equal(t, "", cpcodes.Id())
+	assert.Len(t, fake.requests, 2)
+}